@@ -25,6 +25,7 @@ import (
 func init() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		_ = v.RegisterValidation(custvalid.SameLength, custvalid.ValidateSameLength)
+		_ = v.RegisterValidation(custvalid.SubsetOf, custvalid.ValidateSubsetOf)
 	}
 }
 
@@ -31,6 +31,7 @@ import (
 func init() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		_ = v.RegisterValidation(custvalid.SameLength, custvalid.ValidateSameLength)
+		_ = v.RegisterValidation(custvalid.SubsetOf, custvalid.ValidateSubsetOf)
 		v.RegisterTagNameFunc(func(fld reflect.StructField) string {
 			name, _, _ := strings.Cut(fld.Tag.Get("json"), ",")
 			if name == "" || name == "-" {
@@ -49,13 +50,14 @@ type app struct {
 	packController                    *myHttp.PackController
 	packDraftController               *myHttp.PackDraftController
 	roomController                    *myHttp.RoomController
+	ratingController                  *myHttp.RatingController
 	lobbyHandler                      *myWs.LobbyHandler
 	roomHandler                       *myWs.RoomHandler
 	roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter
 }
 
-func NewApp(cfg *config.Config, roomCache cache.Room, authController *myHttp.AuthController, userController *myHttp.UserController, packController *myHttp.PackController, packDraftController *myHttp.PackDraftController, roomController *myHttp.RoomController, lobbyHandler *myWs.LobbyHandler, roomHandler *myWs.RoomHandler, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter) *app {
-	return &app{cfg, roomCache, authController, userController, packController, packDraftController, roomController, lobbyHandler, roomHandler, roomInternalEventsProcessorGetter}
+func NewApp(cfg *config.Config, roomCache cache.Room, authController *myHttp.AuthController, userController *myHttp.UserController, packController *myHttp.PackController, packDraftController *myHttp.PackDraftController, roomController *myHttp.RoomController, ratingController *myHttp.RatingController, lobbyHandler *myWs.LobbyHandler, roomHandler *myWs.RoomHandler, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter) *app {
+	return &app{cfg, roomCache, authController, userController, packController, packDraftController, roomController, ratingController, lobbyHandler, roomHandler, roomInternalEventsProcessorGetter}
 }
 
 // Start sets up background goroutines and returns the HTTP handler.
@@ -127,6 +129,7 @@ func (a *app) Start(ctx context.Context) http.Handler {
 	a.packController.RegisterRoutes(protected)
 	a.packDraftController.RegisterRoutes(protected)
 	a.roomController.RegisterRoutes(protected)
+	a.ratingController.RegisterRoutes(protected)
 
 	wsGroup := protected.Group("/ws")
 	a.lobbyHandler.RegisterRoute(wsGroup)
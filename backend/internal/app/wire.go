@@ -9,6 +9,7 @@ import (
 	"github.com/google/wire"
 	"github.com/holdennekt/sgame/backend/internal/config"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
 	redisCache "github.com/holdennekt/sgame/backend/internal/infrastructure/cache/redis"
 	mongoDatabase "github.com/holdennekt/sgame/backend/internal/infrastructure/database/mongo"
 	"github.com/holdennekt/sgame/backend/internal/infrastructure/realtime/pubsub"
@@ -31,11 +32,16 @@ var RepoSet = wire.NewSet(
 	mongoDatabase.NewRoomRepository,
 	mongoDatabase.NewPackRepository,
 	mongoDatabase.NewPackDraftRepository,
+	mongoDatabase.NewGameLogRepository,
+	mongoDatabase.NewUserStatsRepository,
+	mongoDatabase.NewRatingRepository,
 )
 
 var CacheSet = wire.NewSet(
 	redisCache.NewSessionCache,
 	redisCache.NewRoomCache,
+	redisCache.NewTimerCache,
+	redisCache.NewRateLimiter,
 )
 
 type PubSubChannelGetter struct {
@@ -66,8 +72,12 @@ func provideStreamsPersistentChannelGetter(client *redis.Client, manager *pubsub
 	return StreamsPersistentChannelGetter{streams.NewPersistentManagedChannelGetter(client, manager)}
 }
 
-func provideLobbyEventsProcessorGetter(roomCache cache.Room, pubsubGetter PubSubChannelGetter) eventsprocessor.LobbyEventsProcessorGetter {
-	return eventsprocessor.NewLobbyEventsProcessorGetter(pubsubGetter.ChannelGetter, roomCache)
+func provideLobbyEventsProcessorGetter(roomCache cache.Room, pubsubGetter PubSubChannelGetter, chatGuard *client.ChatGuard) eventsprocessor.LobbyEventsProcessorGetter {
+	return eventsprocessor.NewLobbyEventsProcessorGetter(pubsubGetter.ChannelGetter, roomCache, chatGuard)
+}
+
+func provideChatFilter(cfg *config.Config) ivalidator.ChatFilter {
+	return infravalidator.NewWordFilter(cfg.ChatBannedWords)
 }
 
 func provideAnswerValidator(cfg *config.Config) ivalidator.AnswerValidator {
@@ -85,16 +95,16 @@ func provideAnswerValidator(cfg *config.Config) ivalidator.AnswerValidator {
 	}
 }
 
-func provideRoomEventsProcessorGetter(roomCache cache.Room, roomRepo repository.Room, packRepo repository.Pack, storage storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config, validator ivalidator.AnswerValidator, roomService *service.RoomService) eventsprocessor.RoomEventsProcessorGetter {
-	return eventsprocessor.NewRoomEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, roomRepo, packRepo, storage, cfg, validator, roomService.Disconnect)
+func provideRoomEventsProcessorGetter(roomCache cache.Room, roomRepo repository.Room, packRepo repository.Pack, storage storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config, validator ivalidator.AnswerValidator, chatGuard *client.ChatGuard, roomService *service.RoomService) eventsprocessor.RoomEventsProcessorGetter {
+	return eventsprocessor.NewRoomEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, roomRepo, packRepo, storage, cfg, validator, chatGuard, roomService.Disconnect)
 }
 
-func provideRoomInternalEventsProcessorGetter(roomCache cache.Room, roomRepo repository.Room, packRepo repository.Pack, storage storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config) eventsprocessor.RoomInternalEventsProcessorGetter {
-	return eventsprocessor.NewRoomInternalEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, roomRepo, packRepo, storage, cfg)
+func provideRoomInternalEventsProcessorGetter(roomCache cache.Room, timerCache cache.Timer, roomRepo repository.Room, gameLogRepo repository.GameLog, userStatsRepo repository.UserStats, ratingRepo repository.Rating, packRepo repository.Pack, storage storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config, validator ivalidator.AnswerValidator) eventsprocessor.RoomInternalEventsProcessorGetter {
+	return eventsprocessor.NewRoomInternalEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, timerCache, roomRepo, gameLogRepo, userStatsRepo, ratingRepo, packRepo, storage, cfg, validator)
 }
 
-func provideRoomService(packRepository repository.Pack, roomRepository repository.Room, roomCache cache.Room, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter, cfg *config.Config, validator ivalidator.AnswerValidator) *service.RoomService {
-	return service.NewRoomService(packRepository, roomRepository, roomCache, pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomInternalEventsProcessorGetter, cfg, validator)
+func provideRoomService(userRepository repository.User, packRepository repository.Pack, roomRepository repository.Room, gameLogRepository repository.GameLog, roomCache cache.Room, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter, cfg *config.Config, validator ivalidator.AnswerValidator) *service.RoomService {
+	return service.NewRoomService(userRepository, packRepository, roomRepository, gameLogRepository, roomCache, pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomInternalEventsProcessorGetter, cfg, validator)
 }
 
 var ServiceSet = wire.NewSet(
@@ -104,6 +114,7 @@ var ServiceSet = wire.NewSet(
 	service.NewAttachmentService,
 	service.NewPackService,
 	service.NewPackDraftService,
+	service.NewRatingService,
 )
 
 var ControllerSet = wire.NewSet(
@@ -112,6 +123,7 @@ var ControllerSet = wire.NewSet(
 	http.NewPackController,
 	http.NewPackDraftController,
 	http.NewRoomController,
+	http.NewRatingController,
 )
 
 func provideLobbyHandler(pubsubGetter PubSubChannelGetter, lobbyEventsProcessorGetter eventsprocessor.LobbyEventsProcessorGetter) *ws.LobbyHandler {
@@ -142,6 +154,8 @@ func InitializeApp(mdb *mongo.Database, rds *redis.Client, storage storage.Stora
 		provideRoomEventsProcessorGetter,
 		provideRoomInternalEventsProcessorGetter,
 		provideAnswerValidator,
+		provideChatFilter,
+		client.NewChatGuard,
 		NewApp,
 	)
 	return nil
@@ -11,6 +11,7 @@ import (
 	"github.com/google/wire"
 	"github.com/holdennekt/sgame/backend/internal/config"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
 	redis2 "github.com/holdennekt/sgame/backend/internal/infrastructure/cache/redis"
 	mongo2 "github.com/holdennekt/sgame/backend/internal/infrastructure/database/mongo"
 	"github.com/holdennekt/sgame/backend/internal/infrastructure/realtime/pubsub"
@@ -40,7 +41,8 @@ func InitializeApp(mdb *mongo.Database, rds *redis.Client, storage2 storage.Stor
 	user := mongo2.NewUserRepository(mdb)
 	authService := service.NewAuthService(session, user)
 	authController := http.NewAuthController(authService)
-	userService := service.NewUserService(user, session)
+	userStats := mongo2.NewUserStatsRepository(mdb)
+	userService := service.NewUserService(user, userStats, session)
 	userController := http.NewUserController(userService)
 	pack := mongo2.NewPackRepository(mdb)
 	packDraft := mongo2.NewPackDraftRepository(mdb)
@@ -50,27 +52,35 @@ func InitializeApp(mdb *mongo.Database, rds *redis.Client, storage2 storage.Stor
 	packDraftService := service.NewPackDraftService(packDraft, pack, storage2, attachmentService, packService)
 	packDraftController := http.NewPackDraftController(packDraftService)
 	repositoryRoom := mongo2.NewRoomRepository(mdb)
+	gameLog := mongo2.NewGameLogRepository(mdb)
 	manager := provideManager(rds)
 	pubSubChannelGetter := providePubSubChannelGetter(rds, manager)
 	streamsChannelGetter := provideStreamsChannelGetter(rds, manager)
 	streamsPersistentChannelGetter := provideStreamsPersistentChannelGetter(rds, manager)
-	roomInternalEventsProcessorGetter := provideRoomInternalEventsProcessorGetter(room, repositoryRoom, pack, storage2, pubSubChannelGetter, streamsChannelGetter, streamsPersistentChannelGetter, cfg)
+	timer := redis2.NewTimerCache(rds)
+	rating := mongo2.NewRatingRepository(mdb)
 	answerValidator := provideAnswerValidator(cfg)
-	roomService := provideRoomService(pack, repositoryRoom, room, pubSubChannelGetter, streamsChannelGetter, streamsPersistentChannelGetter, roomInternalEventsProcessorGetter, cfg, answerValidator)
+	roomInternalEventsProcessorGetter := provideRoomInternalEventsProcessorGetter(room, timer, repositoryRoom, gameLog, userStats, rating, pack, storage2, pubSubChannelGetter, streamsChannelGetter, streamsPersistentChannelGetter, cfg, answerValidator)
+	roomService := provideRoomService(user, pack, repositoryRoom, gameLog, room, pubSubChannelGetter, streamsChannelGetter, streamsPersistentChannelGetter, roomInternalEventsProcessorGetter, cfg, answerValidator)
 	roomController := http.NewRoomController(packService, roomService)
-	lobbyEventsProcessorGetter := provideLobbyEventsProcessorGetter(room, pubSubChannelGetter)
+	ratingService := service.NewRatingService(rating)
+	ratingController := http.NewRatingController(ratingService)
+	rateLimiter := redis2.NewRateLimiter(rds)
+	chatFilter := provideChatFilter(cfg)
+	chatGuard := client.NewChatGuard(rateLimiter, chatFilter, cfg)
+	lobbyEventsProcessorGetter := provideLobbyEventsProcessorGetter(room, pubSubChannelGetter, chatGuard)
 	lobbyHandler := provideLobbyHandler(pubSubChannelGetter, lobbyEventsProcessorGetter)
-	roomEventsProcessorGetter := provideRoomEventsProcessorGetter(room, repositoryRoom, pack, storage2, pubSubChannelGetter, streamsChannelGetter, streamsPersistentChannelGetter, cfg, answerValidator, roomService)
+	roomEventsProcessorGetter := provideRoomEventsProcessorGetter(room, repositoryRoom, pack, storage2, pubSubChannelGetter, streamsChannelGetter, streamsPersistentChannelGetter, cfg, answerValidator, chatGuard, roomService)
 	roomHandler := provideRoomHandler(roomService, roomEventsProcessorGetter, pubSubChannelGetter, streamsChannelGetter, streamsPersistentChannelGetter)
-	appApp := NewApp(cfg, room, authController, userController, packController, packDraftController, roomController, lobbyHandler, roomHandler, roomInternalEventsProcessorGetter)
+	appApp := NewApp(cfg, room, authController, userController, packController, packDraftController, roomController, ratingController, lobbyHandler, roomHandler, roomInternalEventsProcessorGetter)
 	return appApp
 }
 
 // wire.go:
 
-var RepoSet = wire.NewSet(mongo2.NewUserRepository, mongo2.NewRoomRepository, mongo2.NewPackRepository, mongo2.NewPackDraftRepository)
+var RepoSet = wire.NewSet(mongo2.NewUserRepository, mongo2.NewRoomRepository, mongo2.NewPackRepository, mongo2.NewPackDraftRepository, mongo2.NewGameLogRepository, mongo2.NewUserStatsRepository, mongo2.NewRatingRepository)
 
-var CacheSet = wire.NewSet(redis2.NewSessionCache, redis2.NewRoomCache)
+var CacheSet = wire.NewSet(redis2.NewSessionCache, redis2.NewRoomCache, redis2.NewTimerCache, redis2.NewRateLimiter)
 
 type PubSubChannelGetter struct {
 	realtime.ChannelGetter
@@ -84,24 +94,28 @@ type StreamsPersistentChannelGetter struct {
 	realtime.ChannelGetter
 }
 
-func provideManager(client *redis.Client) *pubsub.Manager {
-	return pubsub.NewManager(client)
+func provideManager(client2 *redis.Client) *pubsub.Manager {
+	return pubsub.NewManager(client2)
 }
 
-func providePubSubChannelGetter(client *redis.Client, manager *pubsub.Manager) PubSubChannelGetter {
-	return PubSubChannelGetter{pubsub.NewManagedChannelGetter(client, manager)}
+func providePubSubChannelGetter(client2 *redis.Client, manager *pubsub.Manager) PubSubChannelGetter {
+	return PubSubChannelGetter{pubsub.NewManagedChannelGetter(client2, manager)}
 }
 
-func provideStreamsChannelGetter(client *redis.Client, manager *pubsub.Manager) StreamsChannelGetter {
-	return StreamsChannelGetter{streams.NewManagedChannelGetter(client, manager)}
+func provideStreamsChannelGetter(client2 *redis.Client, manager *pubsub.Manager) StreamsChannelGetter {
+	return StreamsChannelGetter{streams.NewManagedChannelGetter(client2, manager)}
 }
 
-func provideStreamsPersistentChannelGetter(client *redis.Client, manager *pubsub.Manager) StreamsPersistentChannelGetter {
-	return StreamsPersistentChannelGetter{streams.NewPersistentManagedChannelGetter(client, manager)}
+func provideStreamsPersistentChannelGetter(client2 *redis.Client, manager *pubsub.Manager) StreamsPersistentChannelGetter {
+	return StreamsPersistentChannelGetter{streams.NewPersistentManagedChannelGetter(client2, manager)}
 }
 
-func provideLobbyEventsProcessorGetter(roomCache cache.Room, pubsubGetter PubSubChannelGetter) eventsprocessor.LobbyEventsProcessorGetter {
-	return eventsprocessor.NewLobbyEventsProcessorGetter(pubsubGetter.ChannelGetter, roomCache)
+func provideLobbyEventsProcessorGetter(roomCache cache.Room, pubsubGetter PubSubChannelGetter, chatGuard *client.ChatGuard) eventsprocessor.LobbyEventsProcessorGetter {
+	return eventsprocessor.NewLobbyEventsProcessorGetter(pubsubGetter.ChannelGetter, roomCache, chatGuard)
+}
+
+func provideChatFilter(cfg *config.Config) validator.ChatFilter {
+	return validator2.NewWordFilter(cfg.ChatBannedWords)
 }
 
 func provideAnswerValidator(cfg *config.Config) validator.AnswerValidator {
@@ -119,21 +133,21 @@ func provideAnswerValidator(cfg *config.Config) validator.AnswerValidator {
 	}
 }
 
-func provideRoomEventsProcessorGetter(roomCache cache.Room, roomRepo repository.Room, packRepo repository.Pack, storage2 storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config, validator3 validator.AnswerValidator, roomService *service.RoomService) eventsprocessor.RoomEventsProcessorGetter {
-	return eventsprocessor.NewRoomEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, roomRepo, packRepo, storage2, cfg, validator3, roomService.Disconnect)
+func provideRoomEventsProcessorGetter(roomCache cache.Room, roomRepo repository.Room, packRepo repository.Pack, storage2 storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config, validator3 validator.AnswerValidator, chatGuard *client.ChatGuard, roomService *service.RoomService) eventsprocessor.RoomEventsProcessorGetter {
+	return eventsprocessor.NewRoomEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, roomRepo, packRepo, storage2, cfg, validator3, chatGuard, roomService.Disconnect)
 }
 
-func provideRoomInternalEventsProcessorGetter(roomCache cache.Room, roomRepo repository.Room, packRepo repository.Pack, storage2 storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config) eventsprocessor.RoomInternalEventsProcessorGetter {
-	return eventsprocessor.NewRoomInternalEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, roomRepo, packRepo, storage2, cfg)
+func provideRoomInternalEventsProcessorGetter(roomCache cache.Room, timerCache cache.Timer, roomRepo repository.Room, gameLogRepo repository.GameLog, userStatsRepo repository.UserStats, ratingRepo repository.Rating, packRepo repository.Pack, storage2 storage.Storage, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, cfg *config.Config, validator3 validator.AnswerValidator) eventsprocessor.RoomInternalEventsProcessorGetter {
+	return eventsprocessor.NewRoomInternalEventsProcessorGetter(pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomCache, timerCache, roomRepo, gameLogRepo, userStatsRepo, ratingRepo, packRepo, storage2, cfg, validator3)
 }
 
-func provideRoomService(packRepository repository.Pack, roomRepository repository.Room, roomCache cache.Room, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter, cfg *config.Config, validator3 validator.AnswerValidator) *service.RoomService {
-	return service.NewRoomService(packRepository, roomRepository, roomCache, pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomInternalEventsProcessorGetter, cfg, validator3)
+func provideRoomService(userRepository repository.User, packRepository repository.Pack, roomRepository repository.Room, gameLogRepository repository.GameLog, roomCache cache.Room, pubsubGetter PubSubChannelGetter, streamsGetter StreamsChannelGetter, persistentGetter StreamsPersistentChannelGetter, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter, cfg *config.Config, validator3 validator.AnswerValidator) *service.RoomService {
+	return service.NewRoomService(userRepository, packRepository, roomRepository, gameLogRepository, roomCache, pubsubGetter.ChannelGetter, streamsGetter.ChannelGetter, persistentGetter.ChannelGetter, roomInternalEventsProcessorGetter, cfg, validator3)
 }
 
-var ServiceSet = wire.NewSet(service.NewAuthService, service.NewUserService, provideRoomService, service.NewAttachmentService, service.NewPackService, service.NewPackDraftService)
+var ServiceSet = wire.NewSet(service.NewAuthService, service.NewUserService, provideRoomService, service.NewAttachmentService, service.NewPackService, service.NewPackDraftService, service.NewRatingService)
 
-var ControllerSet = wire.NewSet(http.NewAuthController, http.NewUserController, http.NewPackController, http.NewPackDraftController, http.NewRoomController)
+var ControllerSet = wire.NewSet(http.NewAuthController, http.NewUserController, http.NewPackController, http.NewPackDraftController, http.NewRoomController, http.NewRatingController)
 
 func provideLobbyHandler(pubsubGetter PubSubChannelGetter, lobbyEventsProcessorGetter eventsprocessor.LobbyEventsProcessorGetter) *ws.LobbyHandler {
 	return ws.NewLobbyHandler(pubsubGetter.ChannelGetter, lobbyEventsProcessorGetter)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
@@ -38,6 +39,15 @@ type Config struct {
 	TimeToPass           int // seconds; env: TIME_TO_PASS, default 60
 	QuestionDemoDuration int // seconds; env: QUESTION_DEMO_DURATION, default 5
 	IdleRoomTTL          int // seconds; env: IDLE_ROOM_TTL, default 600
+	BuzzWindow           int // milliseconds; env: BUZZ_WINDOW, default 150
+	ModeratorFallback    int // seconds; env: MODERATOR_FALLBACK, default 60
+
+	ChatMaxLength   int      // characters; env: CHAT_MAX_LENGTH, default 500
+	ChatRateLimit   int      // messages per window; env: CHAT_RATE_LIMIT, default 5
+	ChatRateWindow  int      // seconds; env: CHAT_RATE_WINDOW, default 10
+	ChatBannedWords []string // env: CHAT_BANNED_WORDS; comma separated
+	ChatHistorySize int      // messages kept per room; env: CHAT_HISTORY_SIZE, default 500
+	ChatReplayCount int      // messages sent on connect; env: CHAT_REPLAY_COUNT, default 50
 
 	ValidatorType       string // env: VALIDATOR_TYPE; "ollama" | "gemini" | "" (disabled)
 	OllamaURL           string // env: OLLAMA_URL
@@ -69,6 +79,43 @@ func Load() (*Config, error) {
 		idleRoomTTL = 600
 	}
 
+	buzzWindow, _ := strconv.Atoi(os.Getenv("BUZZ_WINDOW"))
+	if buzzWindow == 0 {
+		buzzWindow = 150
+	}
+
+	moderatorFallback, _ := strconv.Atoi(os.Getenv("MODERATOR_FALLBACK"))
+	if moderatorFallback == 0 {
+		moderatorFallback = 60
+	}
+
+	chatMaxLength, _ := strconv.Atoi(os.Getenv("CHAT_MAX_LENGTH"))
+	if chatMaxLength == 0 {
+		chatMaxLength = 500
+	}
+	chatRateLimit, _ := strconv.Atoi(os.Getenv("CHAT_RATE_LIMIT"))
+	if chatRateLimit == 0 {
+		chatRateLimit = 5
+	}
+	chatRateWindow, _ := strconv.Atoi(os.Getenv("CHAT_RATE_WINDOW"))
+	if chatRateWindow == 0 {
+		chatRateWindow = 10
+	}
+	chatHistorySize, _ := strconv.Atoi(os.Getenv("CHAT_HISTORY_SIZE"))
+	if chatHistorySize == 0 {
+		chatHistorySize = 500
+	}
+	chatReplayCount, _ := strconv.Atoi(os.Getenv("CHAT_REPLAY_COUNT"))
+	if chatReplayCount == 0 {
+		chatReplayCount = 50
+	}
+	var chatBannedWords []string
+	for _, word := range strings.Split(os.Getenv("CHAT_BANNED_WORDS"), ",") {
+		if word = strings.TrimSpace(word); word != "" {
+			chatBannedWords = append(chatBannedWords, word)
+		}
+	}
+
 	aiValidationTimeout, _ := strconv.Atoi(os.Getenv("AI_VALIDATION_TIMEOUT"))
 	if aiValidationTimeout == 0 {
 		aiValidationTimeout = 10
@@ -105,6 +152,15 @@ func Load() (*Config, error) {
 		TimeToPass:           timeToPass,
 		QuestionDemoDuration: questionDemoDuration,
 		IdleRoomTTL:          idleRoomTTL,
+		BuzzWindow:           buzzWindow,
+		ModeratorFallback:    moderatorFallback,
+
+		ChatMaxLength:   chatMaxLength,
+		ChatRateLimit:   chatRateLimit,
+		ChatRateWindow:  chatRateWindow,
+		ChatBannedWords: chatBannedWords,
+		ChatHistorySize: chatHistorySize,
+		ChatReplayCount: chatReplayCount,
 
 		ValidatorType:       os.Getenv("VALIDATOR_TYPE"),
 		OllamaURL:           os.Getenv("OLLAMA_URL"),
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// KickPlayer removes a player from the game. Unlike a ban, the player is
+// free to join again.
+func (r *Room) KickPlayer(callerUserId, targetUserId string) error {
+	if !r.IsUserModerator(callerUserId) {
+		return custerr.NewForbiddenErr("not allowed to kick players")
+	}
+	if callerUserId == targetUserId {
+		return custerr.NewBadRequestErr("cannot kick yourself")
+	}
+	if r.UsersPlayerIndex(targetUserId) == -1 {
+		return custerr.NewNotFoundErr("player not found")
+	}
+	r.record(GameEvent{Type: PlayerKickedEvent, ActorId: callerUserId, PlayerId: &targetUserId})
+	r.vacatePlayerSlot(targetUserId)
+	return nil
+}
+
+// BanPlayer removes a player or a spectator from the room for good.
+func (r *Room) BanPlayer(callerUserId, targetUserId string) error {
+	return r.ban(callerUserId, targetUserId, nil)
+}
+
+// BanPlayerUntil removes a player or a spectator from the room, letting them
+// back in once the ban expires.
+func (r *Room) BanPlayerUntil(callerUserId, targetUserId string, until time.Time) error {
+	if !until.After(time.Now()) {
+		return custerr.NewBadRequestErr("ban must end in the future")
+	}
+	return r.ban(callerUserId, targetUserId, &until)
+}
+
+func (r *Room) ban(callerUserId, targetUserId string, until *time.Time) error {
+	if !r.IsUserModerator(callerUserId) {
+		return custerr.NewForbiddenErr("not allowed to ban players")
+	}
+	if callerUserId == targetUserId {
+		return custerr.NewBadRequestErr("cannot ban yourself")
+	}
+	if r.IsUserBanned(targetUserId) {
+		return custerr.NewConflictErr("player is already banned")
+	}
+	// an expired ban is replaced
+	r.dropBan(targetUserId)
+	r.BanList = append(r.BanList, targetUserId)
+	if until != nil {
+		if r.BanExpiresAt == nil {
+			r.BanExpiresAt = make(map[string]time.Time)
+		}
+		r.BanExpiresAt[targetUserId] = *until
+	}
+	r.record(GameEvent{Type: PlayerBannedEvent, ActorId: callerUserId, PlayerId: &targetUserId})
+	r.SeatClaims = slices.DeleteFunc(r.SeatClaims, func(c SeatClaim) bool {
+		return c.Id == targetUserId
+	})
+	if r.UsersPlayerIndex(targetUserId) != -1 {
+		r.vacatePlayerSlot(targetUserId)
+	}
+	return nil
+}
+
+func (r *Room) UnbanPlayer(callerUserId, targetUserId string) error {
+	if !r.IsUserModerator(callerUserId) {
+		return custerr.NewForbiddenErr("not allowed to unban players")
+	}
+	if !slices.Contains(r.BanList, targetUserId) {
+		return custerr.NewNotFoundErr("user is not banned")
+	}
+	r.dropBan(targetUserId)
+	r.record(GameEvent{Type: PlayerUnbannedEvent, ActorId: callerUserId, PlayerId: &targetUserId})
+	return nil
+}
+
+func (r *Room) dropBan(userId string) {
+	r.BanList = slices.DeleteFunc(r.BanList, func(id string) bool {
+		return id == userId
+	})
+	delete(r.BanExpiresAt, userId)
+}
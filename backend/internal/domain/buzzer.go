@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// Buzz is a button press collected during a fairness window. PressedAt is the
+// arrival time compensated by half of the connection's round trip time, which
+// is what the window is arbitrated on. The RTT is measured from the client's
+// own pongs, so the compensation is capped at MaxBuzzCompensation and the
+// window's length, and delaying them buys little; CompensationMs is what was
+// actually applied.
+type Buzz struct {
+	PlayerId       string    `json:"playerId" bson:"playerId"`
+	ReceivedAt     time.Time `json:"receivedAt" bson:"receivedAt"`
+	RTTMs          int64     `json:"rttMs" bson:"rttMs"`
+	CompensationMs int64     `json:"compensationMs" bson:"compensationMs"`
+	PressedAt      time.Time `json:"pressedAt" bson:"pressedAt"`
+}
+
+const MaxBuzzCompensation = 75 * time.Millisecond
+
+func NewBuzz(playerId string, receivedAt time.Time, rtt time.Duration, window time.Duration) Buzz {
+	compensation := max(min(rtt/2, window, MaxBuzzCompensation), 0)
+	return Buzz{
+		PlayerId:       playerId,
+		ReceivedAt:     receivedAt,
+		RTTMs:          rtt.Milliseconds(),
+		CompensationMs: compensation.Milliseconds(),
+		PressedAt:      receivedAt.Add(-compensation),
+	}
+}
+
+// Lockout bars a player from buzzing after a false start. Until is nil when it
+// lasts for the rest of the question.
+type Lockout struct {
+	Until *time.Time `json:"until" bson:"until"`
+}
+
+func (r *Room) isLockedOut(userId string, now time.Time) bool {
+	lockout, ok := r.CurrentQuestion.Lockouts[userId]
+	return ok && (lockout.Until == nil || now.Before(*lockout.Until))
+}
+
+// penalizeFalseStart locks out a player who buzzed while the question was
+// still being revealed, if the room penalizes false starts. It reports whether
+// the press was a false start.
+func (r *Room) penalizeFalseStart(userId string) bool {
+	if r.State != RevealingQuestion || r.Options.FalseStartAllowed || r.Options.FalseStartLockout == 0 {
+		return false
+	}
+	var lockout Lockout
+	if r.Options.FalseStartLockout != LockoutWholeQuestion {
+		until := time.Now().Add(time.Duration(r.Options.FalseStartLockout) * time.Second)
+		lockout.Until = &until
+	}
+	if r.CurrentQuestion.Lockouts == nil {
+		r.CurrentQuestion.Lockouts = make(map[string]Lockout)
+	}
+	r.CurrentQuestion.Lockouts[userId] = lockout
+	r.record(GameEvent{Type: FalseStartEvent, ActorId: userId})
+	return true
+}
+
+func (cq *CurrentQuestion) shiftLockouts(elapsed time.Duration) {
+	for playerId, lockout := range cq.Lockouts {
+		if lockout.Until != nil {
+			until := lockout.Until.Add(elapsed)
+			cq.Lockouts[playerId] = Lockout{Until: &until}
+		}
+	}
+}
+
+func (r *Room) HasPendingBuzzes() bool {
+	return r.CurrentQuestion != nil && len(r.CurrentQuestion.Buzzes) > 0
+}
+
+// Buzz collects a button press. The first press opens a window of the given
+// length during which slower connections can still win; a zero window grants
+// the press straight away. It reports whether this press opened the window.
+func (r *Room) Buzz(buzz Buzz, window time.Duration) (bool, error) {
+	if err := r.canBuzz(buzz.PlayerId); err != nil {
+		return false, err
+	}
+	if r.penalizeFalseStart(buzz.PlayerId) {
+		return false, nil
+	}
+	if window <= 0 {
+		r.submitAnswer(buzz.PlayerId, buzz.PressedAt)
+		return false, nil
+	}
+	for _, pending := range r.CurrentQuestion.Buzzes {
+		if r.sameSide(pending.PlayerId, buzz.PlayerId) {
+			return false, custerr.NewConflictErr("already buzzed")
+		}
+	}
+
+	opened := !r.HasPendingBuzzes()
+	if opened {
+		r.CurrentQuestion.BuzzWindowEndsAt = buzz.ReceivedAt.Add(window)
+	}
+	r.CurrentQuestion.Buzzes = append(r.CurrentQuestion.Buzzes, buzz)
+	return opened, nil
+}
+
+// ResolveBuzzes closes the fairness window and grants the question to the
+// earliest compensated press that is still allowed to answer. The whole window
+// is recorded so the decision can be audited later; it is also returned, in
+// arbitration order, along with the winner if there is one.
+func (r *Room) ResolveBuzzes() (*Buzz, []Buzz) {
+	buzzes := r.CurrentQuestion.Buzzes
+	r.CurrentQuestion.Buzzes = nil
+	r.CurrentQuestion.BuzzWindowEndsAt = time.Time{}
+
+	slices.SortStableFunc(buzzes, func(a, b Buzz) int {
+		return a.PressedAt.Compare(b.PressedAt)
+	})
+	var winner *Buzz
+	for i := range buzzes {
+		if slices.Contains(r.AllowedToAnswer, buzzes[i].PlayerId) {
+			winner = &buzzes[i]
+			break
+		}
+	}
+
+	resolved := GameEvent{Type: BuzzResolvedEvent, ActorId: SYSTEM, Buzzes: buzzes}
+	if winner != nil {
+		resolved.PlayerId = &winner.PlayerId
+	}
+	r.record(resolved)
+	if winner != nil {
+		r.submitAnswer(winner.PlayerId, winner.PressedAt)
+	}
+	return winner, buzzes
+}
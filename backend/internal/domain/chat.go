@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// ChatMessage is an entry of a room's chat history. Event tells the room's
+// chat from the spectators' one; system messages have no sender.
+type ChatMessage struct {
+	Event Event     `json:"event" bson:"event"`
+	From  User      `json:"from" bson:"from"`
+	Text  string    `json:"text" bson:"text"`
+	At    time.Time `json:"at" bson:"at"`
+}
+
+// HidesSpectatorChat reports whether the user taking part in the game chose
+// to hide the spectators' chat. The choice is kept with the room so it
+// survives reconnects.
+func (r *Room) HidesSpectatorChat(userId string) bool {
+	return r.IsUserIn(userId) && slices.Contains(r.SpectatorChatHiddenBy, userId)
+}
+
+func (r *Room) SetSpectatorChatHidden(userId string, hidden bool) {
+	r.SpectatorChatHiddenBy = slices.DeleteFunc(r.SpectatorChatHiddenBy, func(id string) bool {
+		return id == userId
+	})
+	if hidden {
+		r.SpectatorChatHiddenBy = append(r.SpectatorChatHiddenBy, userId)
+	}
+}
+
+// ChatVisibleTo is the part of the chat history the user gets to see.
+func (r *Room) ChatVisibleTo(userId string, chat []ChatMessage) []ChatMessage {
+	if !r.HidesSpectatorChat(userId) {
+		return chat
+	}
+	return slices.DeleteFunc(slices.Clone(chat), func(m ChatMessage) bool {
+		return m.Event == SpectatorChat
+	})
+}
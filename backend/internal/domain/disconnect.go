@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// ActivePlayerId is the player the game is waiting on right now, if any.
+func (r *Room) ActivePlayerId() *string {
+	switch r.State {
+	case Answering:
+		if r.AnsweringPlayer != nil {
+			return &r.AnsweringPlayer.Id
+		}
+	case SelectingQuestion, Passing, ChoosingPrice, Betting, SelectingFinalRoundCategory:
+		return r.CurrentPlayer
+	}
+	return nil
+}
+
+// PauseForDisconnect pauses the game when the room asks for it and the user
+// that dropped is one the game can't go on without: the moderator or the
+// active player. It reports whether the game was paused.
+func (r *Room) PauseForDisconnect(userId string) bool {
+	if !r.Options.PauseOnDisconnect || r.PausedState.Paused || !r.canPause() {
+		return false
+	}
+	isModerator := r.IsUserModerator(userId) && !r.Options.AIHost
+	activePlayerId := r.ActivePlayerId()
+	isActivePlayer := activePlayerId != nil && *activePlayerId == userId
+	if !isModerator && !isActivePlayer {
+		return false
+	}
+	r.pause(SYSTEM)
+	r.PausedState.AwaitingUserId = &userId
+	return true
+}
+
+// ResumeOnReconnect resumes a game that paused itself for the user. It
+// reports whether the game was resumed.
+func (r *Room) ResumeOnReconnect(userId string) bool {
+	awaiting := r.PausedState.AwaitingUserId
+	if !r.PausedState.Paused || awaiting == nil || *awaiting != userId {
+		return false
+	}
+	r.unpause(SYSTEM)
+	return true
+}
+
+// HandOverTurn gives up on the user the game paused for and resumes it. A
+// board the user was selecting from goes to another connected side; any other
+// turn of theirs runs out on its own timer.
+func (r *Room) HandOverTurn() {
+	awaiting := r.PausedState.AwaitingUserId
+	r.unpause(SYSTEM)
+	if awaiting == nil || r.CurrentPlayer == nil || *r.CurrentPlayer != *awaiting {
+		return
+	}
+
+	var candidates []string
+	switch r.State {
+	case SelectingQuestion:
+		for _, p := range r.contenders() {
+			candidates = append(candidates, p.Id)
+		}
+	case SelectingFinalRoundCategory:
+		candidates = slices.Clone(r.FinalRoundState.Players)
+	default:
+		return
+	}
+	candidates = slices.DeleteFunc(candidates, func(playerId string) bool {
+		playerIndex := r.UsersPlayerIndex(playerId)
+		return playerId == *awaiting || playerIndex == -1 || !r.Players[playerIndex].IsConnected
+	})
+	if len(candidates) == 0 {
+		return
+	}
+	nextPlayer := candidates[rand.Intn(len(candidates))]
+	r.CurrentPlayer = &nextPlayer
+	r.record(GameEvent{Type: TurnHandedOverEvent, ActorId: SYSTEM, PlayerId: &nextPlayer})
+	if r.State == SelectingQuestion {
+		r.startSelectionTimer()
+	}
+}
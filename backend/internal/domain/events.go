@@ -3,39 +3,74 @@ package domain
 type Event string
 
 const (
-	Chat                      Event = "chat"
-	StartGame                 Event = "start_game"
-	RoundStarted              Event = "round_started"
-	RoundDemo                 Event = "round_demo"
-	SelectQuestion            Event = "select_question"
-	QuestionDemo              Event = "question_demo"
-	RevealingStarted          Event = "revealing_started"
-	QuestionStarted           Event = "question_started"
-	StartAnswer               Event = "start_answer"
-	SubmitAnswer              Event = "submit_answer"
-	BanPlayer                 Event = "ban_player"
-	PassingStarted            Event = "passing_started"
-	PassQuestion              Event = "pass_question"
-	BettingStarted            Event = "betting_started"
-	PlaceBet                  Event = "place_bet"
-	AnswerStarted             Event = "answer_started"
-	ValidateAnswer            Event = "validate_answer"
-	QuestionEnded             Event = "question_ended"
-	CorrectAnswerDemo         Event = "correct_answer_demo"
-	RemoveFinalRoundCategory  Event = "remove_final_round_category"
-	FinalRoundBettingStarted  Event = "final_round_betting_started"
-	PlaceFinalRoundBet        Event = "place_final_round_bet"
-	FinalRoundQuestionStarted Event = "final_round_question_started"
-	SubmitFinalRoundAnswer    Event = "submit_final_round_answer"
-	ValidateFinalRoundAnswer  Event = "validate_final_round_answer"
-	SkipQuestion              Event = "skip_question"
-	SkipRound                 Event = "skip_round"
-	ChangeScore               Event = "change_score"
-	Pause                     Event = "pause"
-	Unpause                   Event = "unpause"
-	GameEnded                 Event = "game_ended"
-	RoomUpdated               Event = "room_updated"
-	RoomDeleted               Event = "room_deleted"
-	UserDisconnected          Event = "user_disconnected"
-	Error                     Event = "error"
+	Chat                       Event = "chat"
+	TimeSync                   Event = "time_sync"
+	StartGame                  Event = "start_game"
+	RoundStarted               Event = "round_started"
+	RoundDemo                  Event = "round_demo"
+	SelectQuestion             Event = "select_question"
+	SelectionStarted           Event = "selection_started"
+	QuestionAutoSelected       Event = "question_auto_selected"
+	QuestionDemo               Event = "question_demo"
+	RevealingStarted           Event = "revealing_started"
+	QuestionStarted            Event = "question_started"
+	StartAnswer                Event = "start_answer"
+	BuzzWindowStarted          Event = "buzz_window_started"
+	BuzzResolved               Event = "buzz_resolved"
+	SubmitAnswer               Event = "submit_answer"
+	ChooseOption               Event = "choose_option"
+	BanPlayer                  Event = "ban_player"
+	UnbanPlayer                Event = "unban_player"
+	KickPlayer                 Event = "kick_player"
+	MutePlayer                 Event = "mute_player"
+	UnmutePlayer               Event = "unmute_player"
+	UserRemoved                Event = "user_removed"
+	ClaimSeat                  Event = "claim_seat"
+	PromoteSpectator           Event = "promote_spectator"
+	SpectatorPromoted          Event = "spectator_promoted"
+	SpectatorChat              Event = "spectator_chat"
+	ChatHistory                Event = "chat_history"
+	ToggleSpectatorChat        Event = "toggle_spectator_chat"
+	ResolveSeatClaim           Event = "resolve_seat_claim"
+	SeatClaimed                Event = "seat_claimed"
+	JoinTeam                   Event = "join_team"
+	AssignTeam                 Event = "assign_team"
+	SetCaptain                 Event = "set_captain"
+	PassingStarted             Event = "passing_started"
+	PassQuestion               Event = "pass_question"
+	PriceChoosingStarted       Event = "price_choosing_started"
+	ChoosePrice                Event = "choose_price"
+	BettingStarted             Event = "betting_started"
+	PlaceBet                   Event = "place_bet"
+	RaiseBet                   Event = "raise_bet"
+	PassBet                    Event = "pass_bet"
+	AllInBet                   Event = "all_in_bet"
+	AnswerStarted              Event = "answer_started"
+	ValidateAnswer             Event = "validate_answer"
+	QuestionEnded              Event = "question_ended"
+	CorrectAnswerDemo          Event = "correct_answer_demo"
+	RemoveFinalRoundCategory   Event = "remove_final_round_category"
+	FinalRoundBettingStarted   Event = "final_round_betting_started"
+	PlaceFinalRoundBet         Event = "place_final_round_bet"
+	FinalRoundQuestionStarted  Event = "final_round_question_started"
+	SubmitFinalRoundAnswer     Event = "submit_final_round_answer"
+	ValidateFinalRoundAnswer   Event = "validate_final_round_answer"
+	FinalRoundAnswersSubmitted Event = "final_round_answers_submitted"
+	FinalRoundVerdictReveal    Event = "final_round_verdict_reveal"
+	SkipQuestion               Event = "skip_question"
+	SkipRound                  Event = "skip_round"
+	ChangeScore                Event = "change_score"
+	Undo                       Event = "undo"
+	Pause                      Event = "pause"
+	Unpause                    Event = "unpause"
+	TransferModerator          Event = "transfer_moderator"
+	ModeratorChanged           Event = "moderator_changed"
+	ModeratorFallback          Event = "moderator_fallback"
+	GameEnded                  Event = "game_ended"
+	RoomUpdated                Event = "room_updated"
+	RoomDeleted                Event = "room_deleted"
+	UserDisconnected           Event = "user_disconnected"
+	DisconnectGraceEnded       Event = "disconnect_grace_ended"
+	RoomExpired                Event = "room_expired"
+	Error                      Event = "error"
 )
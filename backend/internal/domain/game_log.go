@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type GameEventType string
+
+const (
+	GameStartedEvent               GameEventType = "game_started"
+	RoundStartedEvent              GameEventType = "round_started"
+	RoundSkippedEvent              GameEventType = "round_skipped"
+	QuestionSelectedEvent          GameEventType = "question_selected"
+	QuestionPassedEvent            GameEventType = "question_passed"
+	BetPlacedEvent                 GameEventType = "bet_placed"
+	BetPassedEvent                 GameEventType = "bet_passed"
+	BuzzEvent                      GameEventType = "buzz"
+	BuzzResolvedEvent              GameEventType = "buzz_resolved"
+	FalseStartEvent                GameEventType = "false_start"
+	TypedAnswerEvent               GameEventType = "typed_answer"
+	VerdictEvent                   GameEventType = "verdict"
+	ScoreChangedEvent              GameEventType = "score_changed"
+	PriceChosenEvent               GameEventType = "price_chosen"
+	QuestionSkippedEvent           GameEventType = "question_skipped"
+	QuestionEndedEvent             GameEventType = "question_ended"
+	FinalRoundStartedEvent         GameEventType = "final_round_started"
+	FinalRoundCategoryRemovedEvent GameEventType = "final_round_category_removed"
+	FinalRoundQuestionEvent        GameEventType = "final_round_question"
+	TieBreakerStartedEvent         GameEventType = "tie_breaker_started"
+	PausedEvent                    GameEventType = "paused"
+	TurnHandedOverEvent            GameEventType = "turn_handed_over"
+	UnpausedEvent                  GameEventType = "unpaused"
+	PlayerBannedEvent              GameEventType = "player_banned"
+	PlayerUnbannedEvent            GameEventType = "player_unbanned"
+	PlayerKickedEvent              GameEventType = "player_kicked"
+	PlayerJoinedEvent              GameEventType = "player_joined"
+	SeatClaimedEvent               GameEventType = "seat_claimed"
+	ModeratorChangedEvent          GameEventType = "moderator_changed"
+	AIHostEnabledEvent             GameEventType = "ai_host_enabled"
+	UndoneEvent                    GameEventType = "undone"
+	GameEndedEvent                 GameEventType = "game_ended"
+)
+
+// GameEvent is a single entry of a room's append-only game log. Only the fields
+// relevant to Type are set.
+type GameEvent struct {
+	Type     GameEventType `json:"type" bson:"type"`
+	ActorId  string        `json:"actorId" bson:"actorId"`
+	At       time.Time     `json:"at" bson:"at"`
+	PlayerId *string       `json:"playerId,omitempty" bson:"playerId,omitempty"`
+	// PreviousPlayerId is who held the seat before a seat claim.
+	PreviousPlayerId *string               `json:"previousPlayerId,omitempty" bson:"previousPlayerId,omitempty"`
+	TeamId           *string               `json:"teamId,omitempty" bson:"teamId,omitempty"`
+	Round            *string               `json:"round,omitempty" bson:"round,omitempty"`
+	Category         *string               `json:"category,omitempty" bson:"category,omitempty"`
+	Question         *GameEventQuestion    `json:"question,omitempty" bson:"question,omitempty"`
+	Answer           *string               `json:"answer,omitempty" bson:"answer,omitempty"`
+	IsCorrect        *bool                 `json:"isCorrect,omitempty" bson:"isCorrect,omitempty"`
+	Confidence       *float64              `json:"confidence,omitempty" bson:"confidence,omitempty"`
+	Amount           *int                  `json:"amount,omitempty" bson:"amount,omitempty"`
+	Score            *int                  `json:"score,omitempty" bson:"score,omitempty"`
+	TeamScore        *int                  `json:"teamScore,omitempty" bson:"teamScore,omitempty"`
+	ReactionMs       *int64                `json:"reactionMs,omitempty" bson:"reactionMs,omitempty"`
+	Players          []string              `json:"players,omitempty" bson:"players,omitempty"`
+	Buzzes           []Buzz                `json:"buzzes,omitempty" bson:"buzzes,omitempty"`
+	Board            CurrentRoundQuestions `json:"board,omitempty" bson:"board,omitempty"`
+	// RevertedFrom is, on an undo, the index of the first log entry it rolled
+	// back; the range ends right before the undo entry itself.
+	RevertedFrom *int `json:"revertedFrom,omitempty" bson:"revertedFrom,omitempty"`
+}
+
+type GameEventQuestion struct {
+	Round    string       `json:"round" bson:"round"`
+	Category string       `json:"category" bson:"category"`
+	Index    int          `json:"index" bson:"index"`
+	Value    int          `json:"value" bson:"value"`
+	Type     QuestionType `json:"type" bson:"type"`
+	Text     *string      `json:"text" bson:"text"`
+	Options  []string     `json:"options,omitempty" bson:"options,omitempty"`
+	Answers  []string     `json:"answers" bson:"answers"`
+}
+
+type GameLog struct {
+	RoomId string      `json:"roomId" bson:"_id"`
+	Events []GameEvent `json:"events" bson:"events"`
+}
+
+func newGameEventQuestion(q Question) *GameEventQuestion {
+	return &GameEventQuestion{
+		Round:    q.Round,
+		Category: q.Category,
+		Index:    q.Index,
+		Value:    q.Value,
+		Type:     q.Type,
+		Text:     q.Text,
+		Options:  q.Options,
+		Answers:  q.Answers,
+	}
+}
+
+// record queues a game event; the room cache appends queued events to the
+// room's log together with the state update that produced them.
+func (r *Room) record(event GameEvent) {
+	event.At = time.Now()
+	r.pendingEvents = append(r.pendingEvents, event)
+}
+
+func (r *Room) recordScoreChange(actorId string, playerIndex int, delta int) {
+	playerId := r.Players[playerIndex].Id
+	score := r.Players[playerIndex].Score
+	event := GameEvent{
+		Type:     ScoreChangedEvent,
+		ActorId:  actorId,
+		PlayerId: &playerId,
+		Amount:   &delta,
+		Score:    &score,
+	}
+	if team := r.TeamOf(playerId); team != nil {
+		teamId, teamScore := team.Id, team.Score
+		event.TeamId = &teamId
+		event.TeamScore = &teamScore
+	}
+	r.record(event)
+}
+
+func (r *Room) recordTeamScoreChange(actorId string, teamIndex int, delta int) {
+	teamId, teamScore := r.Teams[teamIndex].Id, r.Teams[teamIndex].Score
+	r.record(GameEvent{
+		Type:      ScoreChangedEvent,
+		ActorId:   actorId,
+		TeamId:    &teamId,
+		Amount:    &delta,
+		TeamScore: &teamScore,
+	})
+}
+
+// keptByUndo are events about live state, which an undo leaves as it is now.
+var keptByUndo = []GameEventType{
+	PausedEvent,
+	UnpausedEvent,
+	PlayerBannedEvent,
+	PlayerUnbannedEvent,
+	PlayerJoinedEvent,
+	ModeratorChangedEvent,
+	AIHostEnabledEvent,
+}
+
+// withoutUndone drops the events that a later undo rolled back.
+func withoutUndone(events []GameEvent) []GameEvent {
+	reverted := make([]bool, len(events))
+	revertedFrom := len(events)
+	for i := len(events) - 1; i >= 0; i-- {
+		if i >= revertedFrom && !slices.Contains(keptByUndo, events[i].Type) {
+			reverted[i] = true
+		}
+		if events[i].Type == UndoneEvent && events[i].RevertedFrom != nil {
+			revertedFrom = min(revertedFrom, *events[i].RevertedFrom)
+		}
+	}
+	kept := make([]GameEvent, 0, len(events))
+	for i, event := range events {
+		if !reverted[i] {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
+
+// DrainEvents returns the game events queued since the room was loaded and
+// clears the queue.
+func (r *Room) DrainEvents() []GameEvent {
+	events := r.pendingEvents
+	r.pendingEvents = nil
+	return events
+}
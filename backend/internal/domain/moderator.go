@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// TransferModerator hands the moderator role to a connected player or
+// spectator. Outside AI mode the moderator does not play, so a player giving
+// up their slot leaves the game and the previous moderator becomes a
+// spectator; in AI mode everyone keeps playing.
+func (r *Room) TransferModerator(userId string, target User) error {
+	if !r.IsUserModerator(userId) {
+		return custerr.NewForbiddenErr("only moderator can transfer the moderator role")
+	}
+	if userId == target.Id {
+		return custerr.NewBadRequestErr("you are already the moderator")
+	}
+	if r.State == GameOver {
+		return custerr.NewConflictErr("the game is over")
+	}
+	if r.IsUserBanned(target.Id) {
+		return custerr.NewForbiddenErr("user is banned from this room")
+	}
+	playerIndex := r.UsersPlayerIndex(target.Id)
+	if playerIndex != -1 && !r.Players[playerIndex].IsConnected {
+		return custerr.NewConflictErr("player is not connected")
+	}
+	if err := r.canTakeModeratorRole(target.Id); err != nil {
+		return err
+	}
+	r.handModeratorRole(userId, target)
+	return nil
+}
+
+// FallBackModerator recovers a room whose moderator has been away for too
+// long. With an answer validator the room switches to AI host, otherwise the
+// role goes to the first connected player who can take it. A game paused for
+// the moderator resumes.
+func (r *Room) FallBackModerator(canUseAIHost bool) error {
+	if r.Moderator == nil || r.Moderator.IsConnected {
+		return custerr.NewConflictErr("the moderator is connected")
+	}
+	if r.State == GameOver {
+		return custerr.NewConflictErr("the game is over")
+	}
+	enableAIHost := canUseAIHost && !r.Options.AIHost
+	if enableAIHost {
+		r.Options.AIHost = true
+		r.record(GameEvent{Type: AIHostEnabledEvent, ActorId: SYSTEM})
+	}
+	if awaiting := r.PausedState.AwaitingUserId; r.PausedState.Paused && awaiting != nil && *awaiting == r.Moderator.Id {
+		r.unpause(SYSTEM)
+	}
+
+	candidateIndex := slices.IndexFunc(r.Players, func(p Player) bool {
+		return p.IsConnected && r.canTakeModeratorRole(p.Id) == nil
+	})
+	if candidateIndex == -1 {
+		if enableAIHost {
+			return nil
+		}
+		return custerr.NewConflictErr("no connected player can take the moderator role")
+	}
+	r.handModeratorRole(SYSTEM, r.Players[candidateIndex].User)
+	return nil
+}
+
+// canTakeModeratorRole checks that the user can become the moderator without
+// leaving the current question waiting on a player that is gone.
+func (r *Room) canTakeModeratorRole(userId string) error {
+	playerIndex := r.UsersPlayerIndex(userId)
+	if r.Options.AIHost {
+		if playerIndex == -1 && len(r.Players) >= r.Options.MaxPlayers {
+			return custerr.NewConflictErr("the room is already full")
+		}
+		return nil
+	}
+	if playerIndex == -1 {
+		return nil
+	}
+	if r.AnsweringPlayer != nil && r.AnsweringPlayer.Id == userId {
+		return custerr.NewConflictErr("player is answering right now")
+	}
+	if r.CurrentPlayer != nil && *r.CurrentPlayer == userId && r.State != SelectingQuestion {
+		return custerr.NewConflictErr("the question is waiting on this player")
+	}
+	if r.FinalRoundState != nil && slices.Contains(r.FinalRoundState.Players, userId) {
+		return custerr.NewConflictErr("player is taking part in the final round")
+	}
+	if r.State == SelectingQuestion && r.CurrentPlayer != nil && *r.CurrentPlayer == userId && len(r.contenders()) < 2 {
+		return custerr.NewConflictErr("nobody else can select questions")
+	}
+	return nil
+}
+
+func (r *Room) handModeratorRole(actorId string, target User) {
+	playerIndex := r.UsersPlayerIndex(target.Id)
+	if r.Options.AIHost {
+		if playerIndex == -1 {
+			r.Players = append(r.Players, Player{User: target, IsConnected: true})
+		}
+	} else if playerIndex != -1 {
+		r.vacatePlayerSlot(target.Id)
+	}
+	r.Moderator = &Moderator{User: target, IsConnected: true}
+	r.record(GameEvent{Type: ModeratorChangedEvent, ActorId: actorId, PlayerId: &target.Id})
+}
+
+// vacatePlayerSlot removes the player from the game, handing the board to
+// another side if it was theirs to select from.
+func (r *Room) vacatePlayerSlot(playerId string) {
+	r.RemoveFromTeam(playerId)
+	r.Players = slices.DeleteFunc(r.Players, func(p Player) bool {
+		return p.Id == playerId
+	})
+	r.AllowedToAnswer = slices.DeleteFunc(r.AllowedToAnswer, func(id string) bool {
+		return id == playerId
+	})
+	r.SeatClaims = slices.DeleteFunc(r.SeatClaims, func(c SeatClaim) bool {
+		return c.PlayerId == playerId
+	})
+	if r.CurrentPlayer == nil || *r.CurrentPlayer != playerId {
+		return
+	}
+	r.CurrentPlayer = nil
+	if contenders := r.contenders(); len(contenders) > 0 {
+		r.CurrentPlayer = &contenders[rand.Intn(len(contenders))].Id
+	}
+}
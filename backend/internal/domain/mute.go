@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"slices"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+func (r *Room) IsUserMuted(userId string) bool {
+	return slices.Contains(r.MutedUsers, userId)
+}
+
+// MutePlayer stops a player or a spectator from writing to the room's chats.
+func (r *Room) MutePlayer(callerUserId, targetUserId string) error {
+	if !r.IsUserModerator(callerUserId) {
+		return custerr.NewForbiddenErr("not allowed to mute players")
+	}
+	if callerUserId == targetUserId {
+		return custerr.NewBadRequestErr("cannot mute yourself")
+	}
+	if r.IsUserMuted(targetUserId) {
+		return custerr.NewConflictErr("user is already muted")
+	}
+	r.MutedUsers = append(r.MutedUsers, targetUserId)
+	return nil
+}
+
+func (r *Room) UnmutePlayer(callerUserId, targetUserId string) error {
+	if !r.IsUserModerator(callerUserId) {
+		return custerr.NewForbiddenErr("not allowed to unmute players")
+	}
+	if !r.IsUserMuted(targetUserId) {
+		return custerr.NewNotFoundErr("user is not muted")
+	}
+	r.MutedUsers = slices.DeleteFunc(r.MutedUsers, func(id string) bool {
+		return id == targetUserId
+	})
+	return nil
+}
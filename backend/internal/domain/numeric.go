@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// NumericAnswer is the answer of a question won by the closest number. Any
+// answer within Tolerance of Value is an exact hit: it wins even if someone
+// got closer, and earns ExactBonus on top.
+type NumericAnswer struct {
+	Value      float64 `json:"value" bson:"value"`
+	Tolerance  float64 `json:"tolerance" bson:"tolerance"`
+	ExactBonus int     `json:"exactBonus" bson:"exactBonus"`
+}
+
+// ParseNumericAnswer reads a written number, accepting a comma as the decimal
+// separator and spaces between digit groups.
+func ParseNumericAnswer(answer string) (float64, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(answer), " ", "")
+	normalized = strings.ReplaceAll(normalized, ",", ".")
+	value, err := strconv.ParseFloat(normalized, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, custerr.NewBadRequestErr("answer must be a number")
+	}
+	return value, nil
+}
+
+// IsNumeric reports whether the question in play is won by the closest number.
+func (s *FinalRoundState) IsNumeric() bool {
+	return s.Question != nil && s.Question.Numeric != nil
+}
+
+// InNumericQuestion reports whether the final round states are being used by
+// a numeric question of a regular round.
+func (r *Room) InNumericQuestion() bool {
+	return r.CurrentQuestion != nil && r.CurrentQuestion.Type == Numeric && r.FinalRoundState != nil
+}
+
+// startNumericQuestion lets every side write down a number, reusing the final
+// round flow without the stakes.
+func (r *Room) startNumericQuestion() {
+	players := make([]string, 0)
+	for _, p := range r.contenders() {
+		players = append(players, p.Id)
+	}
+
+	selectedBy := *r.CurrentPlayer
+	timerEndsAt := time.Now().Add(time.Duration(r.Options.QuestionThinkingTimeFinal) * time.Second)
+	r.FinalRoundState = &FinalRoundState{
+		Question: &FinalRoundQuestion{
+			HiddenFinalRoundQuestion: HiddenFinalRoundQuestion{
+				Category:   r.CurrentQuestion.Category,
+				Text:       r.CurrentQuestion.Text,
+				Attachment: r.CurrentQuestion.Attachment,
+			},
+			Answers: r.CurrentQuestion.Answers,
+			Comment: r.CurrentQuestion.Comment,
+			Numeric: r.CurrentQuestion.Numeric,
+		},
+		Players:        players,
+		PlayersAnswers: make(map[string]string),
+		TimerEndsAt:    &timerEndsAt,
+		SelectedBy:     &selectedBy,
+	}
+	r.AllowedToAnswer = slices.Clone(players)
+	r.CurrentPlayer = nil
+	r.State = ShowingFinalRoundQuestion
+}
+
+// numericStake is what a numeric answer is played for: the board value of a
+// regular question, the bet in the final round and nothing in a tie-breaker,
+// which is only played for the win. Only in the final round does a miss cost
+// the stake.
+func (r *Room) numericStake(playerIndex int) (stake int, atRisk bool) {
+	switch {
+	case r.InNumericQuestion():
+		return r.CurrentQuestion.Value, false
+	case r.InTieBreaker():
+		return 0, false
+	default:
+		return *r.Players[playerIndex].BetAmount, true
+	}
+}
+
+// scoreNumericAnswers grades every written number at once: the closest
+// answers win, as does every exact hit, and the question ends.
+func (r *Room) scoreNumericAnswers() {
+	numeric := r.FinalRoundState.Question.Numeric
+	distances := make(map[string]float64, len(r.FinalRoundState.PlayersAnswers))
+	closest := math.Inf(1)
+	for playerId, answer := range r.FinalRoundState.PlayersAnswers {
+		value, err := ParseNumericAnswer(answer)
+		if err != nil {
+			continue
+		}
+		distances[playerId] = math.Abs(value - numeric.Value)
+		closest = math.Min(closest, distances[playerId])
+	}
+
+	for _, playerId := range r.FinalRoundState.Players {
+		playerIndex := r.UsersPlayerIndex(playerId)
+		if playerIndex == -1 {
+			continue
+		}
+		distance, answered := distances[playerId]
+		exact := answered && distance <= numeric.Tolerance
+		won := answered && (distance == closest || exact)
+
+		stake, atRisk := r.numericStake(playerIndex)
+		delta := 0
+		if won {
+			delta = stake
+		} else if atRisk {
+			delta = -stake
+		}
+		if exact {
+			delta += numeric.ExactBonus
+		}
+
+		answer := r.FinalRoundState.PlayersAnswers[playerId]
+		r.record(GameEvent{
+			Type:      VerdictEvent,
+			ActorId:   SYSTEM,
+			PlayerId:  &playerId,
+			Answer:    &answer,
+			IsCorrect: &won,
+			Amount:    &stake,
+		})
+		if r.InTieBreaker() {
+			if won {
+				r.winTieBreaker(playerId)
+			}
+			continue
+		}
+		if delta != 0 {
+			r.addScore(SYSTEM, playerIndex, delta)
+		}
+	}
+
+	r.CurrentPlayer = nil
+	if r.InNumericQuestion() {
+		r.endWrittenQuestion()
+	} else {
+		r.EndGame()
+	}
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/holdennekt/sgame/backend/pkg/custerr"
 )
 
+// TieBreakerCategory is the category shown for tie-breaker questions, which
+// don't belong to any category of the pack.
+const TieBreakerCategory = "Tie-breaker"
+
 type Pack struct {
 	Id             string      `json:"id"`
 	CreatedBy      User        `json:"createdBy"`
@@ -18,8 +22,11 @@ type Pack struct {
 	Type           PrivacyType `json:"type"`
 	Rounds         []Round     `json:"rounds"`
 	FinalRound     FinalRound  `json:"finalRound"`
-	CreatedAt      time.Time   `json:"createdAt"`
-	UpdatedAt      time.Time   `json:"updatedAt"`
+	// TieBreakers are played in order, only by the leaders, when the game
+	// would otherwise end in a draw.
+	TieBreakers []FinalRoundQuestion `json:"tieBreakers"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
 }
 
 type PackPreview struct {
@@ -66,6 +73,18 @@ const (
 	Regular  QuestionType = "regular"
 	Auction  QuestionType = "auction"
 	CatInBag QuestionType = "catInBag"
+	// MultipleChoice questions are answered by picking one of Options and are
+	// graded without a moderator: an option is correct when it is in Answers.
+	MultipleChoice QuestionType = "multipleChoice"
+	// ForEveryone questions are played like a final round in the middle of a
+	// regular round: everyone stakes, answers in writing and is graded at once.
+	ForEveryone QuestionType = "forEveryone"
+	// NoRisk questions are answered by the player who selected them alone: a
+	// correct answer pays a multiple of the value, a wrong one costs nothing.
+	NoRisk QuestionType = "noRisk"
+	// Numeric questions have a number for an answer: everyone writes one down
+	// and the closest wins, scored without a moderator.
+	Numeric QuestionType = "numeric"
 )
 
 type ToQuestionCorrectAnswerDemoer interface {
@@ -82,11 +101,39 @@ type QuestionCorrectAnswerDemo struct {
 
 type Question struct {
 	HiddenQuestion `bson:"inline"`
-	Type           QuestionType `json:"type" bson:"type"`
-	Text           *string      `json:"text" bson:"text"`
-	Attachment     *Attachment  `json:"attachment" bson:"attachment"`
-	Answers        []string     `json:"answers" bson:"answers"`
-	Comment        *Comment     `json:"comment" bson:"comment"`
+	Type           QuestionType    `json:"type" bson:"type"`
+	Text           *string         `json:"text" bson:"text"`
+	Attachment     *Attachment     `json:"attachment" bson:"attachment"`
+	Options        []string        `json:"options,omitempty" bson:"options,omitempty"`
+	Answers        []string        `json:"answers" bson:"answers"`
+	Comment        *Comment        `json:"comment" bson:"comment"`
+	CatInBagParams *CatInBagParams `json:"catInBag,omitempty" bson:"catInBag,omitempty"`
+	Numeric        *NumericAnswer  `json:"numeric,omitempty" bson:"numeric,omitempty"`
+}
+
+// CatInBagParams override what the receiver of a cat in bag plays: a theme of
+// its own instead of the board category, and a price picked from
+// MinPrice..MaxPrice in Step increments instead of the board value.
+type CatInBagParams struct {
+	Theme    *string `json:"theme" bson:"theme"`
+	MinPrice int     `json:"minPrice" bson:"minPrice"`
+	MaxPrice int     `json:"maxPrice" bson:"maxPrice"`
+	Step     int     `json:"step" bson:"step"`
+}
+
+func (p *CatInBagParams) hasPrice() bool {
+	return p != nil && p.MaxPrice > 0
+}
+
+func (p *CatInBagParams) hasPriceChoice() bool {
+	return p.hasPrice() && p.MinPrice < p.MaxPrice
+}
+
+func (p *CatInBagParams) isValidPrice(price int) bool {
+	if price < p.MinPrice || price > p.MaxPrice {
+		return false
+	}
+	return p.Step <= 0 || price == p.MaxPrice || (price-p.MinPrice)%p.Step == 0
 }
 
 func (q Question) GetMediaRevealingDuration() time.Duration {
@@ -142,8 +189,9 @@ type FinalRoundCategory struct {
 
 type FinalRoundQuestion struct {
 	HiddenFinalRoundQuestion `bson:"inline"`
-	Answers                  []string `json:"answers" bson:"answers"`
-	Comment                  *Comment `json:"comment" bson:"comment"`
+	Answers                  []string       `json:"answers" bson:"answers"`
+	Comment                  *Comment       `json:"comment" bson:"comment"`
+	Numeric                  *NumericAnswer `json:"numeric,omitempty" bson:"numeric,omitempty"`
 }
 
 func (frq FinalRoundQuestion) ToQuestionCorrectAnswerDemo(getAttachmentUrl func(key string) (string, error)) QuestionCorrectAnswerDemo {
@@ -299,6 +347,14 @@ func (p *Pack) AttachmentKeys() map[string]struct{} {
 			}
 		}
 	}
+	for _, q := range p.TieBreakers {
+		if q.Attachment != nil {
+			set[q.Attachment.Key] = struct{}{}
+		}
+		if q.Comment != nil && q.Comment.Attachment != nil {
+			set[q.Comment.Attachment.Key] = struct{}{}
+		}
+	}
 	return set
 }
 
@@ -323,6 +379,14 @@ func (p *Pack) GetAttachment(key string) *Attachment {
 			return category.Question.Comment.Attachment
 		}
 	}
+	for _, question := range p.TieBreakers {
+		if question.Attachment != nil && question.Attachment.Key == key {
+			return question.Attachment
+		}
+		if question.Comment != nil && question.Comment.Attachment != nil && question.Comment.Attachment.Key == key {
+			return question.Comment.Attachment
+		}
+	}
 	return nil
 }
 
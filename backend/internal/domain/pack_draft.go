@@ -3,16 +3,17 @@ package domain
 import "time"
 
 type PackDraft struct {
-	LinkedPackId *string     `json:"linkedPackId"`
-	Id           string      `json:"id"`
-	CreatedBy    User        `json:"createdBy"`
-	Content      string      `json:"-"`
-	Name         string      `json:"name"`
-	Type         PrivacyType `json:"type"`
-	Rounds       []Round     `json:"rounds"`
-	FinalRound   FinalRound  `json:"finalRound"`
-	CreatedAt    time.Time   `json:"createdAt"`
-	UpdatedAt    time.Time   `json:"updatedAt"`
+	LinkedPackId *string              `json:"linkedPackId"`
+	Id           string               `json:"id"`
+	CreatedBy    User                 `json:"createdBy"`
+	Content      string               `json:"-"`
+	Name         string               `json:"name"`
+	Type         PrivacyType          `json:"type"`
+	Rounds       []Round              `json:"rounds"`
+	FinalRound   FinalRound           `json:"finalRound"`
+	TieBreakers  []FinalRoundQuestion `json:"tieBreakers"`
+	CreatedAt    time.Time            `json:"createdAt"`
+	UpdatedAt    time.Time            `json:"updatedAt"`
 }
 
 func (d *PackDraft) AttachmentKeys() map[string]struct{} {
@@ -37,6 +38,14 @@ func (d *PackDraft) AttachmentKeys() map[string]struct{} {
 			set[c.Question.Comment.Attachment.Key] = struct{}{}
 		}
 	}
+	for _, q := range d.TieBreakers {
+		if q.Attachment != nil {
+			set[q.Attachment.Key] = struct{}{}
+		}
+		if q.Comment != nil && q.Comment.Attachment != nil {
+			set[q.Comment.Attachment.Key] = struct{}{}
+		}
+	}
 	return set
 }
 
@@ -61,5 +70,13 @@ func (p *PackDraft) GetAttachment(key string) *Attachment {
 			return category.Question.Comment.Attachment
 		}
 	}
+	for _, question := range p.TieBreakers {
+		if question.Attachment != nil && question.Attachment.Key == key {
+			return question.Attachment
+		}
+		if question.Comment != nil && question.Comment.Attachment != nil && question.Comment.Attachment.Key == key {
+			return question.Comment.Attachment
+		}
+	}
 	return nil
 }
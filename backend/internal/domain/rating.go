@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RatingPool separates ladders whose games are not comparable: answers in AI
+// host rooms are graded by the validator, in moderated rooms by a human.
+type RatingPool string
+
+const (
+	ModeratedPool RatingPool = "moderated"
+	AIHostPool    RatingPool = "aiHost"
+)
+
+const (
+	InitialRating = 1500.0
+	RatingK       = 32.0
+)
+
+type Rating struct {
+	UserId    string     `json:"userId" bson:"userId"`
+	Name      string     `json:"name" bson:"name"`
+	Pool      RatingPool `json:"pool" bson:"pool"`
+	Rating    float64    `json:"rating" bson:"rating"`
+	Games     int        `json:"games" bson:"games"`
+	UpdatedAt time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+// RatingChange is one player's rating movement after one game.
+type RatingChange struct {
+	UserId string     `json:"userId" bson:"userId"`
+	Name   string     `json:"name" bson:"name"`
+	Pool   RatingPool `json:"pool" bson:"pool"`
+	RoomId string     `json:"roomId" bson:"roomId"`
+	PackId string     `json:"packId" bson:"packId"`
+	Score  int        `json:"score" bson:"score"`
+	Before float64    `json:"before" bson:"before"`
+	After  float64    `json:"after" bson:"after"`
+	At     time.Time  `json:"at" bson:"at"`
+}
+
+type LeaderboardEntry struct {
+	UserId string  `json:"userId" bson:"_id"`
+	Name   string  `json:"name" bson:"name"`
+	Rating float64 `json:"rating" bson:"rating"`
+	Games  int     `json:"games" bson:"games"`
+}
+
+func IsValidRatingPool(pool RatingPool) bool {
+	return pool == ModeratedPool || pool == AIHostPool
+}
+
+func (r *Room) RatingPool() RatingPool {
+	if r.Options.AIHost {
+		return AIHostPool
+	}
+	return ModeratedPool
+}
+
+// RatedPlayers returns the registered players whose ratings the game affects,
+// or nil when the room is unrated or has fewer than two of them.
+func (r *Room) RatedPlayers() []Player {
+	if r.Options.Unrated {
+		return nil
+	}
+	players := make([]Player, 0, len(r.Players))
+	for _, player := range r.Players {
+		if !player.IsGuest {
+			players = append(players, player)
+		}
+	}
+	if len(players) < 2 {
+		return nil
+	}
+	return players
+}
+
+// NewRatingChanges rates a finished game as a multiplayer Elo: every pair of
+// rated players is scored as a head-to-head by final score, and each player's
+// K factor is shared across their opponents. Players missing from ratings
+// start at InitialRating. In team mode players are compared by team score and
+// teammates are not rated against each other.
+func NewRatingChanges(room *Room, ratings map[string]float64, at time.Time) []RatingChange {
+	players := room.RatedPlayers()
+	if players == nil {
+		return nil
+	}
+	before := make([]float64, len(players))
+	for i, player := range players {
+		before[i] = InitialRating
+		if rating, ok := ratings[player.Id]; ok {
+			before[i] = rating
+		}
+	}
+
+	changes := make([]RatingChange, len(players))
+	for i, player := range players {
+		opponents := 0
+		for _, opponent := range players {
+			if !room.sameSide(player.Id, opponent.Id) {
+				opponents++
+			}
+		}
+		delta := 0.0
+		for j, opponent := range players {
+			if room.sameSide(player.Id, opponent.Id) {
+				continue
+			}
+			k := RatingK / float64(opponents)
+			expected := 1 / (1 + math.Pow(10, (before[j]-before[i])/400))
+			actual := 0.5
+			if standing := room.CompareStanding(player.Id, opponent.Id); standing > 0 {
+				actual = 1
+			} else if standing < 0 {
+				actual = 0
+			}
+			delta += k * (actual - expected)
+		}
+		changes[i] = RatingChange{
+			UserId: player.Id,
+			Name:   player.Name,
+			Pool:   room.RatingPool(),
+			RoomId: room.Id,
+			PackId: room.PackPreview.Id,
+			Score:  room.ScoreOf(player.Id),
+			Before: before[i],
+			After:  before[i] + delta,
+			At:     at,
+		}
+	}
+	return changes
+}
@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// Replay is the timeline of a finished game rebuilt from its game log.
+type Replay struct {
+	RoomId     string            `json:"roomId"`
+	Players    []User            `json:"players"`
+	Rounds     []ReplayRound     `json:"rounds"`
+	FinalRound *ReplayFinalRound `json:"finalRound"`
+	// TieBreakers reuse the final round shape; they have no bets or removed
+	// categories.
+	TieBreakers []ReplayFinalRound `json:"tieBreakers"`
+	Steps       []ReplayStep       `json:"steps"`
+}
+
+type ReplayRound struct {
+	Name      string                `json:"name"`
+	Board     CurrentRoundQuestions `json:"board"`
+	Questions []ReplayQuestion      `json:"questions"`
+}
+
+type ReplayQuestion struct {
+	Question   GameEventQuestion  `json:"question"`
+	SelectedBy string             `json:"selectedBy"`
+	PassedTo   *string            `json:"passedTo"`
+	Bets       map[string]int     `json:"bets"`
+	Buzzes     []string           `json:"buzzes"`
+	Windows    []ReplayBuzzWindow `json:"buzzWindows"`
+	Verdicts   []ReplayVerdict    `json:"verdicts"`
+	Skipped    bool               `json:"skipped"`
+}
+
+// ReplayBuzzWindow is one fairness window: every press it collected, ordered
+// by compensated time, and who was granted the question.
+type ReplayBuzzWindow struct {
+	Winner *string `json:"winner"`
+	Buzzes []Buzz  `json:"buzzes"`
+}
+
+type ReplayFinalRound struct {
+	Players           []string           `json:"players"`
+	RemovedCategories []string           `json:"removedCategories"`
+	Question          *GameEventQuestion `json:"question"`
+	Bets              map[string]int     `json:"bets"`
+	Verdicts          []ReplayVerdict    `json:"verdicts"`
+}
+
+type ReplayVerdict struct {
+	PlayerId   string   `json:"playerId"`
+	ActorId    string   `json:"actorId"`
+	Answer     *string  `json:"answer"`
+	IsCorrect  bool     `json:"isCorrect"`
+	Confidence *float64 `json:"confidence"`
+	Amount     int      `json:"amount"`
+}
+
+// ReplayStep is a logged event together with the scores right after it.
+type ReplayStep struct {
+	GameEvent
+	Scores map[string]int `json:"scores"`
+}
+
+// ReplayState is the game as it stood right after the event at Step.
+type ReplayState struct {
+	Step       int                   `json:"step"`
+	At         time.Time             `json:"at"`
+	Round      *string               `json:"round"`
+	Board      CurrentRoundQuestions `json:"board"`
+	Scores     map[string]int        `json:"scores"`
+	Question   *ReplayQuestion       `json:"question"`
+	FinalRound *ReplayFinalRound     `json:"finalRound"`
+	TieBreaker *ReplayFinalRound     `json:"tieBreaker"`
+	Paused     bool                  `json:"paused"`
+	GameOver   bool                  `json:"gameOver"`
+}
+
+// NewReplay leaves out the events that were undone, so steps index the log
+// without them.
+func NewReplay(room *Room, events []GameEvent) Replay {
+	events = withoutUndone(events)
+	players := make([]User, len(room.Players))
+	for i, player := range room.Players {
+		players[i] = player.User
+	}
+
+	b := newReplayBuilder()
+	steps := make([]ReplayStep, len(events))
+	for i, event := range events {
+		b.apply(i, event)
+		steps[i] = ReplayStep{GameEvent: event, Scores: maps.Clone(b.state.Scores)}
+	}
+	b.closeQuestion()
+	b.closeTieBreaker()
+
+	return Replay{
+		RoomId:      room.Id,
+		Players:     players,
+		Rounds:      b.rounds,
+		FinalRound:  b.state.FinalRound,
+		TieBreakers: b.tieBreakers,
+		Steps:       steps,
+	}
+}
+
+// NewReplayState folds the game log up to and including step, counting steps
+// the way NewReplay does.
+func NewReplayState(events []GameEvent, step int) (ReplayState, error) {
+	events = withoutUndone(events)
+	if step < 0 || step >= len(events) {
+		return ReplayState{}, custerr.NewNotFoundErr(fmt.Sprintf("no step %d in game log of %d events", step, len(events)))
+	}
+	b := newReplayBuilder()
+	for i, event := range events[:step+1] {
+		b.apply(i, event)
+	}
+	return b.state, nil
+}
+
+type replayBuilder struct {
+	state       ReplayState
+	rounds      []ReplayRound
+	tieBreakers []ReplayFinalRound
+}
+
+func newReplayBuilder() *replayBuilder {
+	return &replayBuilder{
+		state:       ReplayState{Scores: make(map[string]int)},
+		rounds:      make([]ReplayRound, 0),
+		tieBreakers: make([]ReplayFinalRound, 0),
+	}
+}
+
+func (b *replayBuilder) apply(step int, event GameEvent) {
+	s := &b.state
+	s.Step = step
+	s.At = event.At
+
+	switch event.Type {
+	case GameStartedEvent:
+		for _, playerId := range event.Players {
+			s.Scores[playerId] = 0
+		}
+	case RoundStartedEvent:
+		b.closeQuestion()
+		s.Round = event.Round
+		s.Board = event.Board.clone()
+		b.rounds = append(b.rounds, ReplayRound{
+			Name:      *event.Round,
+			Board:     event.Board.clone(),
+			Questions: make([]ReplayQuestion, 0),
+		})
+	case QuestionSelectedEvent:
+		b.closeQuestion()
+		s.Question = &ReplayQuestion{
+			Question:   *event.Question,
+			SelectedBy: event.ActorId,
+			Bets:       make(map[string]int),
+			Buzzes:     make([]string, 0),
+			Windows:    make([]ReplayBuzzWindow, 0),
+			Verdicts:   make([]ReplayVerdict, 0),
+		}
+		markPlayed(s.Board, *event.Question)
+		if len(b.rounds) > 0 {
+			markPlayed(b.rounds[len(b.rounds)-1].Board, *event.Question)
+		}
+	case QuestionPassedEvent:
+		if s.Question != nil {
+			s.Question.PassedTo = event.PlayerId
+		}
+	case BetPlacedEvent:
+		playerId := event.ActorId
+		if event.PlayerId != nil {
+			playerId = *event.PlayerId
+		}
+		if s.FinalRound != nil {
+			s.FinalRound.Bets[playerId] = *event.Amount
+		} else if s.Question != nil {
+			s.Question.Bets[playerId] = *event.Amount
+		}
+	case BuzzEvent:
+		if s.Question != nil {
+			s.Question.Buzzes = append(s.Question.Buzzes, event.ActorId)
+		}
+	case BuzzResolvedEvent:
+		if s.Question != nil {
+			s.Question.Windows = append(s.Question.Windows, ReplayBuzzWindow{Winner: event.PlayerId, Buzzes: event.Buzzes})
+		}
+	case VerdictEvent:
+		verdict := ReplayVerdict{
+			PlayerId:   *event.PlayerId,
+			ActorId:    event.ActorId,
+			Answer:     event.Answer,
+			IsCorrect:  *event.IsCorrect,
+			Confidence: event.Confidence,
+			Amount:     *event.Amount,
+		}
+		if s.TieBreaker != nil {
+			s.TieBreaker.Verdicts = append(s.TieBreaker.Verdicts, verdict)
+		} else if s.FinalRound != nil {
+			s.FinalRound.Verdicts = append(s.FinalRound.Verdicts, verdict)
+		} else if s.Question != nil {
+			s.Question.Verdicts = append(s.Question.Verdicts, verdict)
+		}
+	case PlayerJoinedEvent:
+		s.Scores[*event.PlayerId] = *event.Score
+	case SeatClaimedEvent:
+		s.Scores[*event.PlayerId] = s.Scores[*event.PreviousPlayerId]
+		delete(s.Scores, *event.PreviousPlayerId)
+	case ScoreChangedEvent:
+		if event.PlayerId != nil {
+			s.Scores[*event.PlayerId] = *event.Score
+		}
+		if event.TeamId != nil {
+			s.Scores[*event.TeamId] = *event.TeamScore
+		}
+	case QuestionSkippedEvent:
+		if s.Question != nil {
+			s.Question.Skipped = true
+		}
+	case QuestionEndedEvent:
+		b.closeQuestion()
+	case FinalRoundStartedEvent:
+		b.closeQuestion()
+		s.Round = nil
+		s.Board = nil
+		s.FinalRound = &ReplayFinalRound{
+			Players:           event.Players,
+			RemovedCategories: make([]string, 0),
+			Bets:              make(map[string]int),
+			Verdicts:          make([]ReplayVerdict, 0),
+		}
+	case FinalRoundCategoryRemovedEvent:
+		if s.FinalRound != nil {
+			s.FinalRound.RemovedCategories = append(s.FinalRound.RemovedCategories, *event.Category)
+		}
+	case FinalRoundQuestionEvent:
+		if s.FinalRound != nil {
+			s.FinalRound.Question = event.Question
+		}
+	case TieBreakerStartedEvent:
+		b.closeQuestion()
+		b.closeTieBreaker()
+		s.TieBreaker = &ReplayFinalRound{
+			Players:           event.Players,
+			RemovedCategories: make([]string, 0),
+			Question:          event.Question,
+			Bets:              make(map[string]int),
+			Verdicts:          make([]ReplayVerdict, 0),
+		}
+	case PausedEvent:
+		s.Paused = true
+	case UnpausedEvent:
+		s.Paused = false
+	case GameEndedEvent:
+		b.closeQuestion()
+		b.closeTieBreaker()
+		s.GameOver = true
+	}
+}
+
+// closeQuestion files the question in play under the current round.
+func (b *replayBuilder) closeQuestion() {
+	if b.state.Question == nil {
+		return
+	}
+	if len(b.rounds) > 0 {
+		round := &b.rounds[len(b.rounds)-1]
+		round.Questions = append(round.Questions, *b.state.Question)
+	}
+	b.state.Question = nil
+}
+
+// closeTieBreaker files the tie-breaker in play.
+func (b *replayBuilder) closeTieBreaker() {
+	if b.state.TieBreaker == nil {
+		return
+	}
+	b.tieBreakers = append(b.tieBreakers, *b.state.TieBreaker)
+	b.state.TieBreaker = nil
+}
+
+func markPlayed(board CurrentRoundQuestions, question GameEventQuestion) {
+	categoryQuestions := board.findCategory(question.Category)
+	if categoryQuestions == nil || question.Index < 0 || question.Index >= len(categoryQuestions.Questions) {
+		return
+	}
+	categoryQuestions.Questions[question.Index].HasBeenPlayed = true
+}
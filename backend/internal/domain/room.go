@@ -11,16 +11,25 @@ import (
 )
 
 const (
-	LOBBY              = "lobby"
-	ROOM_PREFIX        = "room:"
-	STATE_POSTFIX      = ":state"
-	LOCK_POSTFIX       = ":lock"
-	OWNER_POSTFIX      = ":owner"
-	INTERNAL_POSTFIX   = ":internal"
-	SPECTATORS_POSTFIX = ":spectators"
+	LOBBY                   = "lobby"
+	ROOM_PREFIX             = "room:"
+	STATE_POSTFIX           = ":state"
+	LOCK_POSTFIX            = ":lock"
+	OWNER_POSTFIX           = ":owner"
+	INTERNAL_POSTFIX        = ":internal"
+	SPECTATORS_POSTFIX      = ":spectators"
+	SPECTATOR_USERS_POSTFIX = ":spectator_users"
+	TIMERS_POSTFIX          = ":timers"
+	TIMER_PAYLOADS_POSTFIX  = ":timer_payloads"
+	GAME_LOG_POSTFIX        = ":log"
+	UNDO_POSTFIX            = ":undo"
+	CHAT_POSTFIX            = ":chat"
+	CHAT_RATE_PREFIX        = "chat_rate:"
 
 	ExtraQuestionThinkingTime = time.Second
+	DefaultNoRiskMultiplier   = 2
 	MaxPauseDuration          = time.Hour
+	UndoStackSize             = 20
 )
 
 type Room struct {
@@ -31,33 +40,63 @@ type Room struct {
 	PackPreview           PackPreview           `json:"packPreview" bson:"packPreview"`
 	Moderator             *Moderator            `json:"moderator" bson:"moderator"`
 	Players               []Player              `json:"players" bson:"players"`
+	Teams                 []Team                `json:"teams" bson:"teams"`
 	BanList               []string              `json:"banList" bson:"banList"`
+	BanExpiresAt          map[string]time.Time  `json:"banExpiresAt" bson:"banExpiresAt"`
+	MutedUsers            []string              `json:"mutedUsers" bson:"mutedUsers"`
+	SpectatorChatHiddenBy []string              `json:"spectatorChatHiddenBy" bson:"spectatorChatHiddenBy"`
+	SeatClaims            []SeatClaim           `json:"seatClaims" bson:"seatClaims"`
 	State                 RoomState             `json:"state" bson:"state"`
 	CurrentRoundName      *string               `json:"currentRoundName" bson:"currentRoundName"`
 	CurrentRoundQuestions CurrentRoundQuestions `json:"currentRoundQuestions" bson:"currentRoundQuestions"`
 	CurrentPlayer         *string               `json:"currentPlayer" bson:"currentPlayer"`
+	SelectionEndsAt       *time.Time            `json:"selectionEndsAt" bson:"selectionEndsAt"`
 	CurrentQuestion       *CurrentQuestion      `json:"currentQuestion" bson:"currentQuestion"`
 	AnsweringPlayer       *AnsweringPlayer      `json:"answeringPlayer" bson:"answeringPlayer"`
 	AllowedToAnswer       []string              `json:"allowedToAnswer" bson:"allowedToAnswer"`
 	FinalRoundState       *FinalRoundState      `json:"finalRoundState" bson:"finalRoundState"`
+	TieBreakers           []FinalRoundQuestion  `json:"tieBreakers" bson:"tieBreakers"`
 	PausedState           PausedState           `json:"pausedState" bson:"pausedState"`
 	FinishedAt            *time.Time            `json:"finishedAt" bson:"finishedAt"`
+	// TieBreakerWins counts the correct tie-breaker answers of each side, by
+	// player id or, in team mode, team id. They only rank equal scores.
+	TieBreakerWins map[string]int `json:"tieBreakerWins" bson:"tieBreakerWins"`
+	// Chat is the chat transcript, only filled in when the room is archived.
+	Chat []ChatMessage `json:"chat,omitempty" bson:"chat,omitempty"`
+
+	pendingEvents []GameEvent
 }
 
 type RoomOptions struct {
-	MaxPlayers                int         `json:"maxPlayers" bson:"maxPlayers" binding:"min=1,max=10"`
-	Type                      PrivacyType `json:"type" bson:"type" binding:"oneof=public private"`
-	Password                  *string     `json:"password" bson:"password" binding:"omitnil,min=4,max=16"`
-	ReadingSymbolsPerSecond   int         `json:"readingSymbolsPerSecond" bson:"readingSymbolsPerSecond" binding:"min=10,max=50"`
-	QuestionThinkingTime      int         `json:"questionThinkingTime" bson:"questionThinkingTime" binding:"min=1,max=30"`
-	AnswerThinkingTime        int         `json:"answerThinkingTime" bson:"answerThinkingTime" binding:"min=1,max=30"`
-	QuestionThinkingTimeFinal int         `json:"questionThinkingTimeFinal" bson:"questionThinkingTimeFinal" binding:"min=1,max=120"`
-	FalseStartAllowed         bool        `json:"falseStartAllowed" bson:"falseStartAllowed"`
-	TimeToBet                 int         `json:"timeToBet,omitempty" bson:"timeToBet"`
-	TimeToPass                int         `json:"timeToPass,omitempty" bson:"timeToPass"`
-	AIHost                    bool        `json:"aiHost" bson:"aiHost"`
+	MaxPlayers                int           `json:"maxPlayers" bson:"maxPlayers" binding:"min=1,max=10"`
+	Type                      PrivacyType   `json:"type" bson:"type" binding:"oneof=public private"`
+	Password                  *string       `json:"password" bson:"password" binding:"omitnil,min=4,max=16"`
+	ReadingSymbolsPerSecond   int           `json:"readingSymbolsPerSecond" bson:"readingSymbolsPerSecond" binding:"min=10,max=50"`
+	QuestionThinkingTime      int           `json:"questionThinkingTime" bson:"questionThinkingTime" binding:"min=1,max=30"`
+	AnswerThinkingTime        int           `json:"answerThinkingTime" bson:"answerThinkingTime" binding:"min=1,max=30"`
+	QuestionThinkingTimeFinal int           `json:"questionThinkingTimeFinal" bson:"questionThinkingTimeFinal" binding:"min=1,max=120"`
+	FalseStartAllowed         bool          `json:"falseStartAllowed" bson:"falseStartAllowed"`
+	FalseStartLockout         int           `json:"falseStartLockout" bson:"falseStartLockout" binding:"min=-1,max=10"`
+	TimeToBet                 int           `json:"timeToBet,omitempty" bson:"timeToBet"`
+	TimeToPass                int           `json:"timeToPass,omitempty" bson:"timeToPass"`
+	AIHost                    bool          `json:"aiHost" bson:"aiHost"`
+	OpenAuction               bool          `json:"openAuction" bson:"openAuction"`
+	Unrated                   bool          `json:"unrated" bson:"unrated"`
+	TeamMode                  bool          `json:"teamMode" bson:"teamMode"`
+	TeamCount                 int           `json:"teamCount,omitempty" bson:"teamCount" binding:"omitempty,min=2,max=5"`
+	TeamAnswering             TeamAnswering `json:"teamAnswering,omitempty" bson:"teamAnswering" binding:"omitempty,oneof=captain anyMember"`
+	NoRiskMultiplier          int           `json:"noRiskMultiplier,omitempty" bson:"noRiskMultiplier" binding:"omitempty,min=1,max=5"`
+	PauseOnDisconnect         bool          `json:"pauseOnDisconnect" bson:"pauseOnDisconnect"`
+	DisconnectGracePeriod     int           `json:"disconnectGracePeriod,omitempty" bson:"disconnectGracePeriod" binding:"omitempty,min=5,max=600"`
+	SelectionTimeout          int           `json:"selectionTimeout,omitempty" bson:"selectionTimeout" binding:"omitempty,min=5,max=120"`
+	LateJoinScore             LateJoinScore `json:"lateJoinScore,omitempty" bson:"lateJoinScore" binding:"omitempty,oneof=zero average lowest"`
 }
 
+// LockoutWholeQuestion as FalseStartLockout bars a player who buzzed early
+// for the rest of the question. Any other positive value is in seconds, and
+// zero just rejects early buzzes.
+const LockoutWholeQuestion = -1
+
 type PrivacyType string
 
 const (
@@ -75,10 +114,12 @@ const (
 	Answering                   RoomState = "answering"
 	Betting                     RoomState = "betting"
 	Passing                     RoomState = "passing"
+	ChoosingPrice               RoomState = "choosing_price"
 	SelectingFinalRoundCategory RoomState = "selecting_final_round_category"
 	FinalRoundBetting           RoomState = "final_round_betting"
 	ShowingFinalRoundQuestion   RoomState = "showing_final_round_question"
 	ValidatingFinalRoundAnswers RoomState = "validating_final_round_answers"
+	TieBreaker                  RoomState = "tie_breaker"
 	GameOver                    RoomState = "game_over"
 )
 
@@ -101,16 +142,46 @@ func (crq CurrentRoundQuestions) findCategory(category string) *CategoryQuestion
 	return nil
 }
 
+func (crq CurrentRoundQuestions) clone() CurrentRoundQuestions {
+	if crq == nil {
+		return nil
+	}
+	cloned := make(CurrentRoundQuestions, len(crq))
+	for i, cq := range crq {
+		cloned[i] = CategoryQuestions{
+			Category:  cq.Category,
+			Questions: slices.Clone(cq.Questions),
+		}
+	}
+	return cloned
+}
+
 type CurrentQuestion struct {
 	Question
-	AttachmentRevealEndsAt       time.Time `json:"attachmentRevealEndsAt" bson:"attachmentRevealEndsAt"`
-	AttachmentRevealLastProgress float64   `json:"attachmentRevealLastProgress" bson:"attachmentRevealLastProgress"`
-	TextRevealLastProgress       float64   `json:"textRevealLastProgress" bson:"textRevealLastProgress"`
-	TimerStartsAt                time.Time `json:"timerStartsAt" bson:"timerStartsAt"`
-	TimerEndsAt                  time.Time `json:"timerEndsAt" bson:"timerEndsAt"`
-	TimerLastProgress            float64   `json:"timerLastProgress" bson:"timerLastProgress"`
-	BettingEndsAt                time.Time `json:"bettingEndsAt" bson:"bettingEndsAt"`
-	PassingEndsAt                time.Time `json:"passingEndsAt" bson:"passingEndsAt"`
+	AttachmentRevealEndsAt       time.Time          `json:"attachmentRevealEndsAt" bson:"attachmentRevealEndsAt"`
+	AttachmentRevealLastProgress float64            `json:"attachmentRevealLastProgress" bson:"attachmentRevealLastProgress"`
+	TextRevealLastProgress       float64            `json:"textRevealLastProgress" bson:"textRevealLastProgress"`
+	TimerStartsAt                time.Time          `json:"timerStartsAt" bson:"timerStartsAt"`
+	TimerEndsAt                  time.Time          `json:"timerEndsAt" bson:"timerEndsAt"`
+	TimerLastProgress            float64            `json:"timerLastProgress" bson:"timerLastProgress"`
+	BettingEndsAt                time.Time          `json:"bettingEndsAt" bson:"bettingEndsAt"`
+	PassingEndsAt                time.Time          `json:"passingEndsAt" bson:"passingEndsAt"`
+	PriceEndsAt                  time.Time          `json:"priceEndsAt" bson:"priceEndsAt"`
+	BuzzWindowEndsAt             time.Time          `json:"buzzWindowEndsAt" bson:"buzzWindowEndsAt"`
+	Buzzes                       []Buzz             `json:"buzzes" bson:"buzzes"`
+	Lockouts                     map[string]Lockout `json:"lockouts" bson:"lockouts"`
+	Theme                        *string            `json:"theme" bson:"theme"`
+	Auction                      *AuctionState      `json:"auction" bson:"auction"`
+}
+
+// AuctionState tracks an open ascending auction. Players take turns (the turn
+// holder is Room.CurrentPlayer) raising above Bid, passing or going all in.
+type AuctionState struct {
+	Bid        int      `json:"bid" bson:"bid"`
+	Bidder     *string  `json:"bidder" bson:"bidder"`
+	AllIn      bool     `json:"allIn" bson:"allIn"`
+	Passed     []string `json:"passed" bson:"passed"`
+	SelectedBy string   `json:"selectedBy" bson:"selectedBy"`
 }
 
 type AnsweringPlayer struct {
@@ -127,11 +198,27 @@ type FinalRoundState struct {
 	PlayersAnswers      map[string]string   `json:"playersAnswers" bson:"playersAnswers"`
 	BettingEndsAt       *time.Time          `json:"bettingEndsAt" bson:"bettingEndsAt"`
 	TimerEndsAt         *time.Time          `json:"timerEndsAt" bson:"timerEndsAt"`
+	// SelectedBy is set for a "for everyone" question and gets the turn back
+	// once it has been played.
+	SelectedBy *string `json:"selectedBy,omitempty" bson:"selectedBy,omitempty"`
+	// TieBreaker is set while the leaders of a drawn game play off.
+	TieBreaker bool `json:"tieBreaker,omitempty" bson:"tieBreaker,omitempty"`
+	// SystemVerdicts are the AI host's grades of the answers, kept until each
+	// one is revealed.
+	SystemVerdicts map[string]SystemVerdict `json:"systemVerdicts,omitempty" bson:"systemVerdicts,omitempty"`
+}
+
+type SystemVerdict struct {
+	IsCorrect  bool    `json:"isCorrect" bson:"isCorrect"`
+	Confidence float64 `json:"confidence" bson:"confidence"`
 }
 
 type PausedState struct {
 	Paused   bool       `json:"paused" bson:"paused"`
 	PausedAt *time.Time `json:"pausedAt" bson:"pausedAt"`
+	// AwaitingUserId is set when the game paused itself for a disconnected
+	// user, and resumes once they reconnect.
+	AwaitingUserId *string `json:"awaitingUserId" bson:"awaitingUserId"`
 }
 
 func (r *Room) IsUserModerator(userId string) bool {
@@ -152,21 +239,33 @@ func (r *Room) IsUserIn(userId string) bool {
 }
 
 func (r *Room) IsUserBanned(userId string) bool {
-	return slices.ContainsFunc(r.BanList, func(id string) bool {
-		return userId == id
-	})
+	if !slices.Contains(r.BanList, userId) {
+		return false
+	}
+	until, ok := r.BanExpiresAt[userId]
+	return !ok || time.Now().Before(until)
 }
 
-func (r *Room) GetProjection(userId string, spectatorCount int) any {
+func (r *Room) GetProjection(userId string, spectatorCount int, spectators []User) any {
 	if r.IsUserModerator(userId) && !r.Options.AIHost {
-		return NewModeratorRoom(r, spectatorCount)
+		return NewModeratorRoom(r, spectatorCount, spectators)
 	}
-	return NewPlayerRoom(r, spectatorCount)
+	return NewPlayerRoom(r, spectatorCount, spectators)
 }
 
 func (r *Room) StartGame(pack *Pack) {
+	actorId := SYSTEM
+	if r.Moderator != nil {
+		actorId = r.Moderator.Id
+	}
+	players := make([]string, len(r.Players))
+	for i, player := range r.Players {
+		players[i] = player.Id
+	}
+	r.record(GameEvent{Type: GameStartedEvent, ActorId: actorId, Players: players})
 	r.StartNextRegularRound(pack)
-	r.CurrentPlayer = &r.Players[rand.Intn(len(r.Players))].Id
+	contenders := r.contenders()
+	r.CurrentPlayer = &contenders[rand.Intn(len(contenders))].Id
 }
 
 func (r *Room) StartNextRegularRound(pack *Pack) bool {
@@ -184,21 +283,39 @@ func (r *Room) StartNextRegularRound(pack *Pack) bool {
 		r.CurrentRoundName = &nextRound.Name
 		r.CurrentRoundQuestions = nextRound.getCurrentRoundQuestions()
 		r.State = SelectingQuestion
+		r.startSelectionTimer()
+		r.record(GameEvent{
+			Type:    RoundStartedEvent,
+			ActorId: SYSTEM,
+			Round:   &nextRound.Name,
+			Board:   r.CurrentRoundQuestions.clone(),
+		})
 		return true
 	}
 	return false
 }
 
 func (r *Room) SelectQuestion(userId string, pack *Pack, category string, index int, getAttachmentUrl func(key string) (string, error)) error {
+	if err := r.canSelectQuestion(userId); err != nil {
+		return err
+	}
+	return r.selectQuestion(userId, pack, category, index, getAttachmentUrl)
+}
+
+func (r *Room) canSelectQuestion(userId string) error {
 	if r.PausedState.Paused {
 		return custerr.NewConflictErr("game is paused")
 	}
 	if r.State != SelectingQuestion {
 		return custerr.NewConflictErr("can not select question now")
 	}
-	if userId != *r.CurrentPlayer && !r.IsUserModerator(userId) {
+	if userId != SYSTEM && userId != *r.CurrentPlayer && !r.IsUserModerator(userId) {
 		return custerr.NewForbiddenErr("not allowed to select question")
 	}
+	return nil
+}
+
+func (r *Room) selectQuestion(userId string, pack *Pack, category string, index int, getAttachmentUrl func(key string) (string, error)) error {
 	catQuestions := r.CurrentRoundQuestions.findCategory(category)
 	if catQuestions == nil || catQuestions.Questions[index].HasBeenPlayed {
 		return custerr.NewConflictErr("question has already been played")
@@ -218,52 +335,105 @@ func (r *Room) SelectQuestion(userId string, pack *Pack, category string, index
 	}
 
 	r.CurrentQuestion = &CurrentQuestion{Question: *question}
+	r.SelectionEndsAt = nil
 	catQuestions.Questions[index].HasBeenPlayed = true
+	r.record(GameEvent{Type: QuestionSelectedEvent, ActorId: userId, Question: newGameEventQuestion(*question)})
 
 	switch question.Type {
-	case Regular:
-		allowedToAnswer := make([]string, len(r.Players))
-		for i, player := range r.Players {
-			allowedToAnswer[i] = player.Id
-		}
-		r.revealRegularQuestion(allowedToAnswer)
+	case Regular, MultipleChoice:
+		r.revealRegularQuestion(r.buzzers())
 	case CatInBag:
 		canPassTo := make([]Player, 0)
-		for _, p := range r.Players {
-			if p.Id != userId && p.IsConnected {
+		for _, p := range r.contenders() {
+			if !r.sameSide(userId, p.Id) && p.IsConnected {
 				canPassTo = append(canPassTo, p)
 			}
 		}
 		if len(canPassTo) == 0 {
-			r.startNonRegularQuestion(userId)
+			r.startCatInBagQuestion(userId)
 		} else if len(canPassTo) == 1 {
-			r.startNonRegularQuestion(canPassTo[0].Id)
+			r.startCatInBagQuestion(canPassTo[0].Id)
 		} else {
 			r.State = Passing
 			r.CurrentQuestion.PassingEndsAt = time.Now().Add(time.Duration(r.Options.TimeToPass) * time.Second)
 		}
 	case Auction:
 		canBet := make([]Player, 0)
-		for _, p := range r.Players {
-			if p.Score > 0 {
+		for _, p := range r.contenders() {
+			if r.ScoreOf(p.Id) > 0 {
 				canBet = append(canBet, p)
 			}
 		}
 		if len(canBet) > 0 {
 			r.State = Betting
 			r.CurrentQuestion.BettingEndsAt = time.Now().Add(time.Duration(r.Options.TimeToBet) * time.Second)
-		} else {
-			allowedToAnswer := make([]string, len(r.Players))
-			for i, player := range r.Players {
-				allowedToAnswer[i] = player.Id
+			if r.Options.OpenAuction {
+				r.startOpenAuction()
 			}
-			r.revealRegularQuestion(allowedToAnswer)
+		} else {
+			r.revealRegularQuestion(r.buzzers())
+		}
+	case ForEveryone:
+		if !r.startForEveryoneQuestion() {
+			r.revealRegularQuestion(r.buzzers())
 		}
+	case NoRisk:
+		r.startNonRegularQuestion(*r.CurrentPlayer)
+	case Numeric:
+		r.startNumericQuestion()
 	}
 
 	return nil
 }
 
+// startForEveryoneQuestion opens the stakes of a "for everyone" question to
+// every side with a positive score, reusing the final round flow.
+func (r *Room) startForEveryoneQuestion() bool {
+	players := make([]string, 0)
+	for _, p := range r.contenders() {
+		if r.ScoreOf(p.Id) > 0 {
+			players = append(players, p.Id)
+		}
+	}
+	if len(players) == 0 {
+		return false
+	}
+
+	selectedBy := *r.CurrentPlayer
+	bettingEndsAt := time.Now().Add(time.Duration(r.Options.TimeToBet) * time.Second)
+	r.FinalRoundState = &FinalRoundState{
+		Question: &FinalRoundQuestion{
+			HiddenFinalRoundQuestion: HiddenFinalRoundQuestion{
+				Category:   r.CurrentQuestion.Category,
+				Text:       r.CurrentQuestion.Text,
+				Attachment: r.CurrentQuestion.Attachment,
+			},
+			Answers: r.CurrentQuestion.Answers,
+			Comment: r.CurrentQuestion.Comment,
+		},
+		Players:       players,
+		BettingEndsAt: &bettingEndsAt,
+		SelectedBy:    &selectedBy,
+	}
+	r.CurrentPlayer = nil
+	r.State = FinalRoundBetting
+	return true
+}
+
+// InForEveryoneQuestion reports whether the final round states are being used
+// by a "for everyone" question of a regular round.
+func (r *Room) InForEveryoneQuestion() bool {
+	return r.CurrentQuestion != nil && r.CurrentQuestion.Type == ForEveryone && r.FinalRoundState != nil
+}
+
+// endWrittenQuestion ends a "for everyone" or numeric question and hands the
+// turn back to whoever selected it.
+func (r *Room) endWrittenQuestion() {
+	r.CurrentPlayer = r.FinalRoundState.SelectedBy
+	r.FinalRoundState = nil
+	r.EndQuestion()
+}
+
 func (r *Room) revealRegularQuestion(allowedToAnswer []string) {
 	r.AllowedToAnswer = allowedToAnswer
 	mediaRevealingDuration := r.CurrentQuestion.GetMediaRevealingDuration()
@@ -301,8 +471,17 @@ func (r *Room) startNonRegularQuestion(allowedToAnswer string) {
 }
 
 func (r *Room) SubmitAnswer(userId string) error {
-	now := time.Now()
+	if err := r.canBuzz(userId); err != nil {
+		return err
+	}
+	if r.penalizeFalseStart(userId) {
+		return nil
+	}
+	r.submitAnswer(userId, time.Now())
+	return nil
+}
 
+func (r *Room) canBuzz(userId string) error {
 	if r.PausedState.Paused {
 		return custerr.NewConflictErr("game is paused")
 	}
@@ -312,40 +491,56 @@ func (r *Room) SubmitAnswer(userId string) error {
 	if !slices.Contains(r.AllowedToAnswer, userId) {
 		return custerr.NewConflictErr("not allowed to submit answer")
 	}
-	if r.State == RevealingQuestion && !r.Options.FalseStartAllowed {
+	if r.isLockedOut(userId, time.Now()) {
+		return custerr.NewConflictErr("locked out after a false start")
+	}
+	if r.State == RevealingQuestion && !r.Options.FalseStartAllowed && r.Options.FalseStartLockout == 0 {
 		return custerr.NewConflictErr("can not submit answer now")
 	}
+	return nil
+}
 
+// submitAnswer hands the question to userId, freezing the reveal and question
+// clocks at the moment the button was pressed.
+func (r *Room) submitAnswer(userId string, pressedAt time.Time) {
+	buzz := GameEvent{Type: BuzzEvent, ActorId: userId}
 	switch r.State {
 	case RevealingQuestion:
 		if r.CurrentQuestion.Attachment != nil {
 			fullDuration := float64(r.CurrentQuestion.GetMediaRevealingDuration())
-			remainedDuration := math.Max(0, float64(r.CurrentQuestion.AttachmentRevealEndsAt.Sub(now)))
+			remainedDuration := math.Max(0, float64(r.CurrentQuestion.AttachmentRevealEndsAt.Sub(pressedAt)))
 			r.CurrentQuestion.AttachmentRevealLastProgress = 1 - remainedDuration/fullDuration
 		}
 		if r.CurrentQuestion.Text != nil {
 			fullDuration := float64(r.CurrentQuestion.GetTextRevealingDuration(r.Options.ReadingSymbolsPerSecond))
-			remainedDuration := math.Min(fullDuration, float64(r.CurrentQuestion.TimerStartsAt.Sub(now)))
+			remainedDuration := math.Min(fullDuration, float64(r.CurrentQuestion.TimerStartsAt.Sub(pressedAt)))
 			r.CurrentQuestion.TextRevealLastProgress = 1 - remainedDuration/fullDuration
 		}
 	case ShowingQuestion:
 		fullDuration := float64((time.Duration(r.Options.QuestionThinkingTime) * time.Second))
-		remainedDuration := float64(r.CurrentQuestion.TimerEndsAt.Sub(now))
-		r.CurrentQuestion.TimerLastProgress = remainedDuration / fullDuration
+		remainedDuration := float64(r.CurrentQuestion.TimerEndsAt.Sub(pressedAt))
+		// a press from a fairness window may predate the question clock
+		r.CurrentQuestion.TimerLastProgress = math.Min(1, remainedDuration/fullDuration)
+		// reaction is the thinking time used on the question clock
+		reactionMs := time.Duration(math.Max(0, fullDuration-remainedDuration)).Milliseconds()
+		buzz.ReactionMs = &reactionMs
 	}
 
+	// the answer clock starts when the buzz is granted, not when it was pressed
+	grantedAt := time.Now()
 	thinkingDuration := time.Duration(r.Options.AnswerThinkingTime) * time.Second
 	r.AnsweringPlayer = &AnsweringPlayer{
 		Id:            userId,
-		TimerStartsAt: now,
-		TimerEndsAt:   now.Add(thinkingDuration),
+		TimerStartsAt: grantedAt,
+		TimerEndsAt:   grantedAt.Add(thinkingDuration),
 	}
 	r.CurrentPlayer = &userId
+	// a team buzzes once, whoever of its members pressed the button
 	r.AllowedToAnswer = slices.DeleteFunc(r.AllowedToAnswer, func(playerId string) bool {
-		return userId == playerId
+		return r.sameSide(userId, playerId)
 	})
 	r.State = Answering
-	return nil
+	r.record(buzz)
 }
 
 // SubmitTypedAnswer stores the player's typed answer and immediately cancels the
@@ -364,12 +559,48 @@ func (r *Room) SubmitTypedAnswer(userId, answer string) error {
 	if !r.Options.AIHost {
 		return custerr.NewForbiddenErr("text answers are only used in AI host mode")
 	}
+	if r.CurrentQuestion.Type == MultipleChoice {
+		return custerr.NewConflictErr("multiple choice questions are answered by choosing an option")
+	}
 	r.AnsweringPlayer.Answer = answer
 	r.AnsweringPlayer.TimerEndsAt = time.Now()
+	r.record(GameEvent{Type: TypedAnswerEvent, ActorId: userId, Answer: &answer})
 	return nil
 }
 
+// ChooseOption answers a multiple choice question and grades it right away.
+func (r *Room) ChooseOption(userId string, option int) error {
+	if r.PausedState.Paused {
+		return custerr.NewConflictErr("game is paused")
+	}
+	if r.State != Answering {
+		return custerr.NewConflictErr("cannot choose option now")
+	}
+	if r.AnsweringPlayer == nil || r.AnsweringPlayer.Id != userId {
+		return custerr.NewForbiddenErr("not your turn to answer")
+	}
+	if r.CurrentQuestion.Type != MultipleChoice {
+		return custerr.NewConflictErr("question has no options to choose from")
+	}
+	if option < 0 || option >= len(r.CurrentQuestion.Options) {
+		return custerr.NewBadRequestErr(fmt.Sprintf("no option %d", option))
+	}
+	r.AnsweringPlayer.Answer = r.CurrentQuestion.Options[option]
+	isCorrect := slices.Contains(r.CurrentQuestion.Answers, r.AnsweringPlayer.Answer)
+	return r.validateAnswer(SYSTEM, isCorrect, nil)
+}
+
 func (r *Room) ValidateAnswer(userId string, isCorrect bool) error {
+	return r.validateAnswer(userId, isCorrect, nil)
+}
+
+// ValidateAnswerBySystem applies an automatic verdict, keeping the validator's
+// confidence in the game log.
+func (r *Room) ValidateAnswerBySystem(isCorrect bool, confidence float64) error {
+	return r.validateAnswer(SYSTEM, isCorrect, &confidence)
+}
+
+func (r *Room) validateAnswer(userId string, isCorrect bool, confidence *float64) error {
 	if r.PausedState.Paused {
 		return custerr.NewConflictErr("game is paused")
 	}
@@ -382,6 +613,9 @@ func (r *Room) ValidateAnswer(userId string, isCorrect bool) error {
 	if !r.IsUserModerator(userId) && userId != SYSTEM {
 		return custerr.NewForbiddenErr("not allowed to validate answer")
 	}
+	if r.CurrentQuestion.Type == MultipleChoice && userId != SYSTEM {
+		return custerr.NewForbiddenErr("multiple choice answers are graded automatically")
+	}
 
 	playerIndex := slices.IndexFunc(r.Players, func(p Player) bool {
 		return r.AnsweringPlayer.Id == p.Id
@@ -390,10 +624,28 @@ func (r *Room) ValidateAnswer(userId string, isCorrect bool) error {
 	if betAmount := r.Players[playerIndex].BetAmount; betAmount != nil {
 		questionValue = *betAmount
 	}
-	if isCorrect {
-		r.Players[playerIndex].Score += questionValue
-	} else {
-		r.Players[playerIndex].Score -= questionValue
+	delta := questionValue
+	if r.CurrentQuestion.Type == NoRisk {
+		questionValue *= r.noRiskMultiplier()
+		delta = questionValue
+		if !isCorrect {
+			delta = 0
+		}
+	} else if !isCorrect {
+		delta = -questionValue
+	}
+	answeringPlayer := *r.AnsweringPlayer
+	r.record(GameEvent{
+		Type:       VerdictEvent,
+		ActorId:    userId,
+		PlayerId:   &answeringPlayer.Id,
+		Answer:     &answeringPlayer.Answer,
+		IsCorrect:  &isCorrect,
+		Confidence: confidence,
+		Amount:     &questionValue,
+	})
+	if delta != 0 {
+		r.addScore(userId, playerIndex, delta)
 	}
 
 	if isCorrect || len(r.AllowedToAnswer) == 0 {
@@ -404,6 +656,13 @@ func (r *Room) ValidateAnswer(userId string, isCorrect bool) error {
 	return nil
 }
 
+func (r *Room) noRiskMultiplier() int {
+	if r.Options.NoRiskMultiplier > 0 {
+		return r.Options.NoRiskMultiplier
+	}
+	return DefaultNoRiskMultiplier
+}
+
 func (r *Room) continueRegularQuestion() {
 	now := time.Now()
 	answerDuration := now.Sub(r.AnsweringPlayer.TimerStartsAt)
@@ -439,19 +698,28 @@ func (r *Room) PassQuestion(fromUserId string, toUserId string) error {
 	if *r.CurrentPlayer == toUserId {
 		return custerr.NewConflictErr("can not pass question to current player")
 	}
+	if r.IsTeamMode() {
+		if r.sameSide(*r.CurrentPlayer, toUserId) {
+			return custerr.NewConflictErr("can not pass question to own team")
+		}
+		if !r.isCaptain(toUserId) {
+			return custerr.NewConflictErr("question can only be passed to a team captain")
+		}
+	}
 	if !r.Players[toUserIndex].IsConnected {
 		return custerr.NewConflictErr("can not pass question to disconnected player")
 	}
 
-	r.startNonRegularQuestion(toUserId)
+	r.record(GameEvent{Type: QuestionPassedEvent, ActorId: fromUserId, PlayerId: &toUserId})
+	r.startCatInBagQuestion(toUserId)
 	return nil
 }
 
 func (r *Room) PassQuestionAuto() {
 	var passTo string
 	canPassTo := make([]Player, 0)
-	for _, p := range r.Players {
-		if p.Id != *r.CurrentPlayer && p.IsConnected {
+	for _, p := range r.contenders() {
+		if !r.sameSide(*r.CurrentPlayer, p.Id) && p.IsConnected {
 			canPassTo = append(canPassTo, p)
 		}
 	}
@@ -460,7 +728,59 @@ func (r *Room) PassQuestionAuto() {
 	} else {
 		passTo = canPassTo[rand.Intn(len(canPassTo))].Id
 	}
-	r.startNonRegularQuestion(passTo)
+	r.record(GameEvent{Type: QuestionPassedEvent, ActorId: SYSTEM, PlayerId: &passTo})
+	r.startCatInBagQuestion(passTo)
+}
+
+// startCatInBagQuestion hands the cat in bag to its receiver, revealing its own
+// theme. A fixed override price applies at once; a price range is left for the
+// receiver to choose from first.
+func (r *Room) startCatInBagQuestion(receiver string) {
+	params := r.CurrentQuestion.CatInBagParams
+	if params != nil && params.Theme != nil {
+		r.CurrentQuestion.Theme = params.Theme
+	}
+	if params.hasPriceChoice() {
+		r.CurrentPlayer = &receiver
+		r.CurrentQuestion.PriceEndsAt = time.Now().Add(time.Duration(r.Options.TimeToBet) * time.Second)
+		r.State = ChoosingPrice
+		return
+	}
+	if params.hasPrice() {
+		r.CurrentQuestion.Value = params.MaxPrice
+	}
+	r.startNonRegularQuestion(receiver)
+}
+
+func (r *Room) ChoosePrice(userId string, price int) error {
+	if r.PausedState.Paused {
+		return custerr.NewConflictErr("game is paused")
+	}
+	if r.State != ChoosingPrice {
+		return custerr.NewConflictErr("can not choose price now")
+	}
+	if *r.CurrentPlayer != userId {
+		return custerr.NewForbiddenErr("not allowed to choose price")
+	}
+	params := r.CurrentQuestion.CatInBagParams
+	if !params.isValidPrice(price) {
+		return custerr.NewConflictErr(fmt.Sprintf("price must be between %d and %d in steps of %d", params.MinPrice, params.MaxPrice, params.Step))
+	}
+	r.choosePrice(userId, price)
+	return nil
+}
+
+// ChoosePriceAuto settles on the lowest price when the receiver runs out of
+// time.
+func (r *Room) ChoosePriceAuto() {
+	r.choosePrice(SYSTEM, r.CurrentQuestion.CatInBagParams.MinPrice)
+}
+
+func (r *Room) choosePrice(actorId string, price int) {
+	r.CurrentQuestion.Value = price
+	receiver := *r.CurrentPlayer
+	r.record(GameEvent{Type: PriceChosenEvent, ActorId: actorId, PlayerId: &receiver, Amount: &price})
+	r.startNonRegularQuestion(receiver)
 }
 
 func (r *Room) PlaceBet(userId string, amount int) error {
@@ -470,6 +790,12 @@ func (r *Room) PlaceBet(userId string, amount int) error {
 	if r.State != Betting {
 		return custerr.NewConflictErr("can not place bet now")
 	}
+	if r.CurrentQuestion.Auction != nil {
+		return custerr.NewConflictErr("bets are raised in turns in open auction")
+	}
+	if !r.isContender(userId) {
+		return custerr.NewForbiddenErr("only team captains can bet")
+	}
 	playerIndex := slices.IndexFunc(r.Players, func(p Player) bool {
 		return userId == p.Id
 	})
@@ -477,16 +803,17 @@ func (r *Room) PlaceBet(userId string, amount int) error {
 	if alreadyBet {
 		return custerr.NewConflictErr("can not place bet again")
 	}
-	insufficientScore := amount > r.Players[playerIndex].Score || amount < 0
+	insufficientScore := amount > r.ScoreOf(userId) || amount < 0
 	if insufficientScore {
 		return custerr.NewConflictErr("insufficient bet size")
 	}
 
 	r.Players[playerIndex].BetAmount = &amount
+	r.record(GameEvent{Type: BetPlacedEvent, ActorId: userId, Amount: &amount})
 
 	canBet := make([]Player, 0)
-	for _, p := range r.Players {
-		if p.Score > 0 {
+	for _, p := range r.contenders() {
+		if r.ScoreOf(p.Id) > 0 {
 			canBet = append(canBet, p)
 		}
 	}
@@ -510,9 +837,16 @@ func (r *Room) PlaceBet(userId string, amount int) error {
 }
 
 func (r *Room) PlaceBetsAuto() {
+	if r.CurrentQuestion.Auction != nil {
+		passedBy := *r.CurrentPlayer
+		r.CurrentQuestion.Auction.Passed = append(r.CurrentQuestion.Auction.Passed, passedBy)
+		r.record(GameEvent{Type: BetPassedEvent, ActorId: SYSTEM, PlayerId: &passedBy})
+		r.advanceAuction()
+		return
+	}
 	canBet := make([]*Player, 0)
 	for pi, p := range r.Players {
-		if p.Score > 0 {
+		if r.isContender(p.Id) && r.ScoreOf(p.Id) > 0 {
 			canBet = append(canBet, &r.Players[pi])
 		}
 	}
@@ -520,6 +854,8 @@ func (r *Room) PlaceBetsAuto() {
 		if player.BetAmount == nil {
 			zero := 0
 			canBet[i].BetAmount = &zero
+			playerId := player.Id
+			r.record(GameEvent{Type: BetPlacedEvent, ActorId: SYSTEM, PlayerId: &playerId, Amount: &zero})
 		}
 	}
 	playerMaxBet := canBet[0]
@@ -535,7 +871,132 @@ func (r *Room) PlaceBetsAuto() {
 	}
 }
 
+func (r *Room) startOpenAuction() {
+	selectedBy := *r.CurrentPlayer
+	r.CurrentQuestion.Auction = &AuctionState{
+		Passed:     make([]string, 0),
+		SelectedBy: selectedBy,
+	}
+	contenders := r.contenders()
+	selectorIndex := max(slices.IndexFunc(contenders, func(p Player) bool {
+		return p.Id == selectedBy
+	}), 0)
+	for i := range contenders {
+		p := contenders[(selectorIndex+i)%len(contenders)]
+		if r.canBidInAuction(p) {
+			r.CurrentPlayer = &p.Id
+			return
+		}
+	}
+}
+
+func (r *Room) canBidInAuction(p Player) bool {
+	auction := r.CurrentQuestion.Auction
+	if auction.Bidder != nil && *auction.Bidder == p.Id {
+		return false
+	}
+	return r.ScoreOf(p.Id) > auction.Bid && !slices.Contains(auction.Passed, p.Id)
+}
+
+// advanceAuction hands the turn to the next player still able to outbid the
+// current high bid. When nobody is left the high bidder answers the question
+// for the amount bid, or the question ends if nobody has bid at all.
+func (r *Room) advanceAuction() {
+	auction := r.CurrentQuestion.Auction
+	contenders := r.contenders()
+	currentIndex := slices.IndexFunc(contenders, func(p Player) bool {
+		return p.Id == *r.CurrentPlayer
+	})
+	for i := 1; i <= len(contenders); i++ {
+		p := contenders[(currentIndex+i)%len(contenders)]
+		if r.canBidInAuction(p) {
+			r.CurrentPlayer = &p.Id
+			r.CurrentQuestion.BettingEndsAt = time.Now().Add(time.Duration(r.Options.TimeToBet) * time.Second)
+			return
+		}
+	}
+
+	if auction.Bidder == nil {
+		r.CurrentPlayer = &auction.SelectedBy
+		r.EndQuestion()
+		return
+	}
+	bidderIndex := r.UsersPlayerIndex(*auction.Bidder)
+	bid := auction.Bid
+	r.Players[bidderIndex].BetAmount = &bid
+	r.startNonRegularQuestion(*auction.Bidder)
+}
+
+func (r *Room) checkAuctionTurn(userId string) error {
+	if r.PausedState.Paused {
+		return custerr.NewConflictErr("game is paused")
+	}
+	if r.State != Betting || r.CurrentQuestion.Auction == nil {
+		return custerr.NewConflictErr("no open auction in progress")
+	}
+	if *r.CurrentPlayer != userId {
+		return custerr.NewForbiddenErr("not your turn to bid")
+	}
+	return nil
+}
+
+func (r *Room) RaiseBet(userId string, amount int) error {
+	if err := r.checkAuctionTurn(userId); err != nil {
+		return err
+	}
+	auction := r.CurrentQuestion.Auction
+	if auction.AllIn {
+		return custerr.NewConflictErr("only all in can outbid all in")
+	}
+	minBid := max(r.CurrentQuestion.Value, auction.Bid+1)
+	if amount < minBid {
+		return custerr.NewConflictErr(fmt.Sprintf("bid must be at least %d", minBid))
+	}
+	score := r.ScoreOf(userId)
+	if amount > score {
+		return custerr.NewConflictErr("insufficient bet size")
+	}
+
+	auction.Bid = amount
+	auction.Bidder = &userId
+	auction.AllIn = amount == score
+	r.record(GameEvent{Type: BetPlacedEvent, ActorId: userId, Amount: &amount})
+	r.advanceAuction()
+	return nil
+}
+
+func (r *Room) AllInBet(userId string) error {
+	if err := r.checkAuctionTurn(userId); err != nil {
+		return err
+	}
+	auction := r.CurrentQuestion.Auction
+	score := r.ScoreOf(userId)
+	if score <= auction.Bid {
+		return custerr.NewConflictErr("insufficient score to outbid")
+	}
+
+	auction.Bid = score
+	auction.Bidder = &userId
+	auction.AllIn = true
+	r.record(GameEvent{Type: BetPlacedEvent, ActorId: userId, Amount: &score})
+	r.advanceAuction()
+	return nil
+}
+
+func (r *Room) PassBet(userId string) error {
+	if err := r.checkAuctionTurn(userId); err != nil {
+		return err
+	}
+	r.CurrentQuestion.Auction.Passed = append(r.CurrentQuestion.Auction.Passed, userId)
+	r.record(GameEvent{Type: BetPassedEvent, ActorId: userId})
+	r.advanceAuction()
+	return nil
+}
+
 func (r *Room) EndQuestion() {
+	if r.CurrentQuestion != nil {
+		r.record(GameEvent{Type: QuestionEndedEvent, ActorId: SYSTEM, Question: newGameEventQuestion(r.CurrentQuestion.Question)})
+	}
 	r.CurrentQuestion = nil
 	r.AnsweringPlayer = nil
 	r.AllowedToAnswer = make([]string, 0)
@@ -543,6 +1004,7 @@ func (r *Room) EndQuestion() {
 		r.Players[i].BetAmount = nil
 	}
 	r.State = SelectingQuestion
+	r.startSelectionTimer()
 }
 
 func (r *Room) AnyAvailableQuestions() bool {
@@ -561,13 +1023,14 @@ func (r *Room) StartFinalRound(pack *Pack, getAttachmentUrl func(key string) (st
 	r.CurrentRoundName = nil
 	r.CurrentRoundQuestions = nil
 	r.CurrentPlayer = nil
+	r.SelectionEndsAt = nil
 	r.CurrentQuestion = nil
 	r.AnsweringPlayer = nil
 	r.AllowedToAnswer = nil
 
 	finalRoundPlayers := make([]string, 0)
-	for _, player := range r.Players {
-		if player.Score > 0 {
+	for _, player := range r.contenders() {
+		if r.ScoreOf(player.Id) > 0 {
 			finalRoundPlayers = append(finalRoundPlayers, player.Id)
 		}
 	}
@@ -579,6 +1042,7 @@ func (r *Room) StartFinalRound(pack *Pack, getAttachmentUrl func(key string) (st
 		Players:             finalRoundPlayers,
 		AvailableCategories: pack.FinalRound.getAvailableCategories(),
 	}
+	r.record(GameEvent{Type: FinalRoundStartedEvent, ActorId: SYSTEM, Players: finalRoundPlayers})
 	r.CurrentPlayer = &r.FinalRoundState.Players[rand.Intn(len(r.FinalRoundState.Players))]
 	r.State = SelectingFinalRoundCategory
 
@@ -621,6 +1085,7 @@ func (r *Room) RemoveFinalRoundCategory(pack *Pack, userId string, category stri
 	}
 
 	r.FinalRoundState.AvailableCategories[category] = false
+	r.record(GameEvent{Type: FinalRoundCategoryRemovedEvent, ActorId: userId, Category: &category})
 	playerIndex := slices.IndexFunc(r.FinalRoundState.Players, func(p string) bool {
 		return p == userId
 	})
@@ -644,6 +1109,16 @@ func (r *Room) chooseFinalRoundCategory(pack *Pack, category string, getAttachme
 		return frc.Name == category
 	})
 	r.FinalRoundState.Question = &pack.FinalRound.Categories[categoryIndex].Question
+	r.record(GameEvent{
+		Type:     FinalRoundQuestionEvent,
+		ActorId:  SYSTEM,
+		Category: &category,
+		Question: &GameEventQuestion{
+			Category: category,
+			Text:     r.FinalRoundState.Question.Text,
+			Answers:  r.FinalRoundState.Question.Answers,
+		},
+	})
 	if r.FinalRoundState.Question.Attachment != nil {
 		u, err := getAttachmentUrl(r.FinalRoundState.Question.Attachment.Key)
 		if err != nil {
@@ -676,12 +1151,13 @@ func (r *Room) PlaceFinalRoundBet(userId string, amount int) error {
 	if alreadyBet {
 		return custerr.NewConflictErr("can not place bet again")
 	}
-	insufficientScore := amount > r.Players[playerIndex].Score || amount < 0
+	insufficientScore := amount > r.ScoreOf(userId) || amount < 0
 	if insufficientScore {
 		return custerr.NewConflictErr("insufficient score")
 	}
 
 	r.Players[playerIndex].BetAmount = &amount
+	r.record(GameEvent{Type: BetPlacedEvent, ActorId: userId, Amount: &amount})
 
 	allBet := !slices.ContainsFunc(r.Players, func(p Player) bool {
 		return slices.Contains(r.FinalRoundState.Players, p.Id) && p.BetAmount == nil
@@ -697,6 +1173,10 @@ func (r *Room) PlaceFinalRoundBetsAuto() {
 		if r.Players[i].BetAmount == nil {
 			zero := 0
 			r.Players[i].BetAmount = &zero
+			if r.FinalRoundState != nil && slices.Contains(r.FinalRoundState.Players, r.Players[i].Id) {
+				playerId := r.Players[i].Id
+				r.record(GameEvent{Type: BetPlacedEvent, ActorId: SYSTEM, PlayerId: &playerId, Amount: &zero})
+			}
 		}
 	}
 	r.startFinalRoundQuestion()
@@ -711,12 +1191,16 @@ func (r *Room) startFinalRoundQuestion() {
 	}
 
 	if len(finalRoundPlayers) == 0 {
+		if r.InForEveryoneQuestion() {
+			r.endWrittenQuestion()
+			return
+		}
 		r.EndGame()
 		return
 	}
 
 	r.FinalRoundState.Players = finalRoundPlayers
-	r.AllowedToAnswer = finalRoundPlayers
+	r.AllowedToAnswer = slices.Clone(finalRoundPlayers)
 	r.FinalRoundState.PlayersAnswers = make(map[string]string)
 
 	duration := time.Duration(r.Options.QuestionThinkingTimeFinal) * time.Second
@@ -729,14 +1213,21 @@ func (r *Room) SubmitFinalRoundAnswer(userId string, answer string) error {
 	if r.PausedState.Paused {
 		return custerr.NewConflictErr("game is paused")
 	}
-	if r.State != ShowingFinalRoundQuestion {
+	if r.State != ShowingFinalRoundQuestion && r.State != TieBreaker {
 		return custerr.NewConflictErr("can not submit final round answer now")
 	}
 	if !slices.Contains(r.AllowedToAnswer, userId) {
 		return custerr.NewConflictErr("not allowed to submit final round answer")
 	}
 
+	if r.FinalRoundState.IsNumeric() {
+		if _, err := ParseNumericAnswer(answer); err != nil {
+			return err
+		}
+	}
+
 	r.FinalRoundState.PlayersAnswers[userId] = answer
+	r.record(GameEvent{Type: TypedAnswerEvent, ActorId: userId, Answer: &answer})
 	r.AllowedToAnswer = slices.DeleteFunc(r.AllowedToAnswer, func(playerId string) bool {
 		return userId == playerId
 	})
@@ -749,29 +1240,90 @@ func (r *Room) SubmitFinalRoundAnswer(userId string, answer string) error {
 }
 
 func (r *Room) EndFinalRoundQuestion() {
+	if r.FinalRoundState.IsNumeric() {
+		r.scoreNumericAnswers()
+		return
+	}
 	r.CurrentPlayer = &r.FinalRoundState.Players[0]
 	r.State = ValidatingFinalRoundAnswers
 }
 
 func (r *Room) ValidateFinalRoundAnswer(userId string, isCorrect bool) error {
+	return r.validateFinalRoundAnswer(userId, isCorrect, nil)
+}
+
+// ValidateFinalRoundAnswerBySystem applies an automatic verdict to the current
+// final round answer, keeping the validator's confidence in the game log.
+func (r *Room) ValidateFinalRoundAnswerBySystem(isCorrect bool, confidence float64) error {
+	return r.validateFinalRoundAnswer(SYSTEM, isCorrect, &confidence)
+}
+
+// SetSystemVerdicts stores the graded final round answers, so they can be
+// revealed one player at a time across pauses and changes of the room owner.
+func (r *Room) SetSystemVerdicts(verdicts map[string]SystemVerdict) error {
+	if r.State != ValidatingFinalRoundAnswers {
+		return custerr.NewConflictErr("can not grade final round answers now")
+	}
+	if r.FinalRoundState.SystemVerdicts != nil {
+		return custerr.NewConflictErr("final round answers are already graded")
+	}
+	r.FinalRoundState.SystemVerdicts = verdicts
+	return nil
+}
+
+// RevealSystemVerdict applies the stored verdict of the current player.
+func (r *Room) RevealSystemVerdict() error {
+	if r.State != ValidatingFinalRoundAnswers {
+		return custerr.NewConflictErr("can not validate final round answer now")
+	}
+	verdict, ok := r.FinalRoundState.SystemVerdicts[*r.CurrentPlayer]
+	if !ok {
+		return custerr.NewConflictErr("final round answer is not graded yet")
+	}
+	return r.ValidateFinalRoundAnswerBySystem(verdict.IsCorrect, verdict.Confidence)
+}
+
+func (r *Room) validateFinalRoundAnswer(userId string, isCorrect bool, confidence *float64) error {
 	if r.PausedState.Paused {
 		return custerr.NewConflictErr("game is paused")
 	}
 	if r.State != ValidatingFinalRoundAnswers {
 		return custerr.NewConflictErr("can not validate final round answer now")
 	}
-	if !r.IsUserModerator(userId) {
+	if r.Options.AIHost && userId != SYSTEM {
+		return custerr.NewForbiddenErr("final round answers are validated by AI in this room")
+	}
+	if !r.IsUserModerator(userId) && userId != SYSTEM {
 		return custerr.NewForbiddenErr("not allowed to validate final round answer")
 	}
 
 	playerIndex := slices.IndexFunc(r.Players, func(p Player) bool {
 		return p.Id == *r.CurrentPlayer
 	})
-	betAmount := *r.Players[playerIndex].BetAmount
-	if isCorrect {
-		r.Players[playerIndex].Score += betAmount
-	} else {
-		r.Players[playerIndex].Score -= betAmount
+	// tie-breakers are played for nothing but the win
+	var betAmount, delta int
+	if !r.InTieBreaker() {
+		betAmount = *r.Players[playerIndex].BetAmount
+		delta = betAmount
+		if !isCorrect {
+			delta = -betAmount
+		}
+	}
+	playerId := *r.CurrentPlayer
+	answer := r.FinalRoundState.PlayersAnswers[playerId]
+	r.record(GameEvent{
+		Type:       VerdictEvent,
+		ActorId:    userId,
+		PlayerId:   &playerId,
+		Answer:     &answer,
+		IsCorrect:  &isCorrect,
+		Confidence: confidence,
+		Amount:     &betAmount,
+	})
+	if !r.InTieBreaker() {
+		r.addScore(userId, playerIndex, delta)
+	} else if isCorrect {
+		r.winTieBreaker(playerId)
 	}
 
 	playerIndex = slices.IndexFunc(r.FinalRoundState.Players, func(p string) bool {
@@ -779,6 +1331,8 @@ func (r *Room) ValidateFinalRoundAnswer(userId string, isCorrect bool) error {
 	})
 	if playerIndex < len(r.FinalRoundState.Players)-1 {
 		r.CurrentPlayer = &r.FinalRoundState.Players[playerIndex+1]
+	} else if r.InForEveryoneQuestion() {
+		r.endWrittenQuestion()
 	} else {
 		r.EndGame()
 	}
@@ -791,13 +1345,19 @@ func (r *Room) SkipQuestion(userId string) error {
 		return custerr.NewConflictErr("game is paused")
 	}
 	skippable := r.State == RevealingQuestion || r.State == ShowingQuestion ||
-		r.State == Answering || r.State == Passing || r.State == Betting
+		r.State == Answering || r.State == Passing || r.State == Betting || r.State == ChoosingPrice ||
+		r.InForEveryoneQuestion() || r.InNumericQuestion()
 	if !skippable {
 		return custerr.NewConflictErr("can not skip question now")
 	}
 	if !r.IsUserModerator(userId) {
 		return custerr.NewForbiddenErr("not allowed to skip question")
 	}
+	r.record(GameEvent{Type: QuestionSkippedEvent, ActorId: userId})
+	if r.InForEveryoneQuestion() || r.InNumericQuestion() {
+		r.endWrittenQuestion()
+		return nil
+	}
 	r.EndQuestion()
 	return nil
 }
@@ -812,6 +1372,7 @@ func (r *Room) SkipRound(userId string) error {
 	if r.State != SelectingQuestion {
 		return custerr.NewConflictErr("can not skip round now")
 	}
+	r.record(GameEvent{Type: RoundSkippedEvent, ActorId: userId, Round: r.CurrentRoundName})
 	return nil
 }
 
@@ -825,24 +1386,28 @@ func (r *Room) ChangeScore(userId string, playerId string, score int) error {
 	if playerIndex == -1 {
 		return custerr.NewNotFoundErr(fmt.Sprintf("no player with id \"%s\" in room", playerId))
 	}
+	delta := score - r.Players[playerIndex].Score
 	r.Players[playerIndex].Score = score
+	r.recordScoreChange(userId, playerIndex, delta)
 	return nil
 }
 
-func (r *Room) BanPlayer(callerUserId, targetUserId string) error {
-	if !r.IsUserModerator(callerUserId) {
-		return custerr.NewForbiddenErr("not allowed to ban players")
+// ChangeTeamScore sets the shared score of a team, leaving its members' own
+// scores as they are.
+func (r *Room) ChangeTeamScore(userId string, teamId string, score int) error {
+	if !r.IsUserModerator(userId) {
+		return custerr.NewForbiddenErr("not allowed to change score")
 	}
-	if callerUserId == targetUserId {
-		return custerr.NewBadRequestErr("cannot ban yourself")
+	if !r.IsTeamMode() {
+		return custerr.NewConflictErr("room is not in team mode")
 	}
-	if r.IsUserBanned(targetUserId) {
-		return custerr.NewConflictErr("player is already banned")
+	teamIndex := r.teamIndex(teamId)
+	if teamIndex == -1 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no team with id \"%s\"", teamId))
 	}
-	r.BanList = append(r.BanList, targetUserId)
-	r.Players = slices.DeleteFunc(r.Players, func(p Player) bool {
-		return p.Id == targetUserId
-	})
+	delta := score - r.Teams[teamIndex].Score
+	r.Teams[teamIndex].Score = score
+	r.recordTeamScoreChange(userId, teamIndex, delta)
 	return nil
 }
 
@@ -871,7 +1436,18 @@ func (r *Room) captureTimerProgress(now time.Time) {
 // shiftTimers moves all active timer deadlines forward by the elapsed pause
 // duration, so remaining time is preserved across a pause.
 func (r *Room) shiftTimers(elapsed time.Duration) {
+	if r.HasPendingBuzzes() {
+		r.CurrentQuestion.BuzzWindowEndsAt = r.CurrentQuestion.BuzzWindowEndsAt.Add(elapsed)
+	}
+	if r.CurrentQuestion != nil {
+		r.CurrentQuestion.shiftLockouts(elapsed)
+	}
 	switch r.State {
+	case SelectingQuestion:
+		if r.SelectionEndsAt != nil {
+			newSelectionEndsAt := r.SelectionEndsAt.Add(elapsed)
+			r.SelectionEndsAt = &newSelectionEndsAt
+		}
 	case RevealingQuestion:
 		if r.CurrentQuestion.Attachment != nil {
 			r.CurrentQuestion.AttachmentRevealEndsAt = r.CurrentQuestion.AttachmentRevealEndsAt.Add(elapsed)
@@ -886,40 +1462,49 @@ func (r *Room) shiftTimers(elapsed time.Duration) {
 		r.CurrentQuestion.BettingEndsAt = r.CurrentQuestion.BettingEndsAt.Add(elapsed)
 	case Passing:
 		r.CurrentQuestion.PassingEndsAt = r.CurrentQuestion.PassingEndsAt.Add(elapsed)
+	case ChoosingPrice:
+		r.CurrentQuestion.PriceEndsAt = r.CurrentQuestion.PriceEndsAt.Add(elapsed)
 	case FinalRoundBetting:
 		newBettingEndsAt := r.FinalRoundState.BettingEndsAt.Add(elapsed)
 		r.FinalRoundState.BettingEndsAt = &newBettingEndsAt
-	case ShowingFinalRoundQuestion:
+	case ShowingFinalRoundQuestion, TieBreaker:
 		newTimerEndsAt := r.FinalRoundState.TimerEndsAt.Add(elapsed)
 		r.FinalRoundState.TimerEndsAt = &newTimerEndsAt
 	}
 }
 
 func (r *Room) Pause(userId string) error {
-	now := time.Now()
-
 	if !r.IsUserModerator(userId) {
 		return custerr.NewForbiddenErr("not allowed to pause")
 	}
 	if r.PausedState.Paused {
 		return custerr.NewConflictErr("already paused")
 	}
-	if !slices.Contains(
+	if !r.canPause() {
+		return custerr.NewConflictErr("can not pause now")
+	}
+	r.pause(userId)
+	return nil
+}
+
+func (r *Room) canPause() bool {
+	return slices.Contains(
 		[]RoomState{
 			SelectingQuestion, RevealingQuestion, ShowingQuestion,
-			Answering, Betting, Passing, SelectingFinalRoundCategory,
-			FinalRoundBetting, ShowingFinalRoundQuestion,
+			Answering, Betting, Passing, ChoosingPrice, SelectingFinalRoundCategory,
+			FinalRoundBetting, ShowingFinalRoundQuestion, TieBreaker,
 		},
 		r.State,
-	) {
-		return custerr.NewConflictErr("can not pause now")
-	}
+	)
+}
 
+func (r *Room) pause(actorId string) {
+	now := time.Now()
 	r.captureTimerProgress(now)
 
 	r.PausedState.Paused = true
 	r.PausedState.PausedAt = &now
-	return nil
+	r.record(GameEvent{Type: PausedEvent, ActorId: actorId})
 }
 
 func (r *Room) Unpause(userId string) error {
@@ -929,27 +1514,37 @@ func (r *Room) Unpause(userId string) error {
 	if !r.PausedState.Paused {
 		return custerr.NewConflictErr("not paused")
 	}
-	elapsed := time.Since(*r.PausedState.PausedAt)
-	r.PausedState.Paused = false
-	r.PausedState.PausedAt = nil
-
-	r.shiftTimers(elapsed)
+	r.unpause(userId)
 	return nil
 }
 
 func (r *Room) UnpauseSystem(pausedAt time.Time) error {
-	if !r.PausedState.Paused || !r.PausedState.PausedAt.Equal(pausedAt) {
+	if !r.IsPauseSession(pausedAt) {
 		return custerr.NewConflictErr("pause session changed")
 	}
+	r.unpause(SYSTEM)
+	return nil
+}
+
+// IsPauseSession reports whether the game is still in the pause that started
+// at pausedAt.
+func (r *Room) IsPauseSession(pausedAt time.Time) bool {
+	return r.PausedState.Paused && r.PausedState.PausedAt.Equal(pausedAt)
+}
+
+func (r *Room) unpause(actorId string) {
 	elapsed := time.Since(*r.PausedState.PausedAt)
-	r.PausedState.Paused = false
-	r.PausedState.PausedAt = nil
+	r.PausedState = PausedState{}
 
 	r.shiftTimers(elapsed)
-	return nil
+	r.record(GameEvent{Type: UnpausedEvent, ActorId: actorId})
 }
 
 func (r *Room) EndGame() {
+	r.SelectionEndsAt = nil
+	if r.startTieBreaker() {
+		return
+	}
 	// TODO: maybe some cleanup
 	r.CurrentPlayer = nil
 	if r.FinalRoundState != nil && r.FinalRoundState.TimerEndsAt != nil {
@@ -960,4 +1555,5 @@ func (r *Room) EndGame() {
 		r.Players[pi].BetAmount = &newBetAmount
 	}
 	r.State = GameOver
+	r.record(GameEvent{Type: GameEndedEvent, ActorId: SYSTEM})
 }
@@ -7,6 +7,7 @@ type RoomLobby struct {
 	Options     RoomOptions `json:"options"`
 	Moderator   *Moderator  `json:"moderator"`
 	Players     []Player    `json:"players"`
+	Teams       []Team      `json:"teams"`
 	MaxPlayers  int         `json:"maxPlayers"`
 	Type        PrivacyType `json:"type"`
 	Status      string      `json:"status"`
@@ -30,6 +31,7 @@ func NewRoomLobby(room *Room) RoomLobby {
 		Options:     room.Options,
 		Moderator:   room.Moderator,
 		Players:     room.Players,
+		Teams:       room.Teams,
 		MaxPlayers:  room.Options.MaxPlayers,
 		Type:        room.Options.Type,
 		Status:      status,
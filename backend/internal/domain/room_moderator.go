@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type RoomModerator struct {
 	Id                    string                `json:"id"`
 	Name                  string                `json:"name"`
@@ -7,19 +9,28 @@ type RoomModerator struct {
 	Options               RoomOptions           `json:"options"`
 	Moderator             *Moderator            `json:"moderator"`
 	Players               []Player              `json:"players"`
+	Teams                 []Team                `json:"teams"`
 	State                 RoomState             `json:"state"`
 	CurrentRoundName      *string               `json:"currentRoundName"`
 	CurrentRoundQuestions CurrentRoundQuestions `json:"currentRoundQuestions"`
 	CurrentPlayer         *string               `json:"currentPlayer"`
+	SelectionEndsAt       *time.Time            `json:"selectionEndsAt"`
 	CurrentQuestion       *CurrentQuestion      `json:"currentQuestion"`
 	AnsweringPlayer       *AnsweringPlayer      `json:"answeringPlayer"`
 	AllowedToAnswer       []string              `json:"allowedToAnswer"`
 	FinalRoundState       *FinalRoundState      `json:"finalRoundState"`
+	SeatClaims            []SeatClaim           `json:"seatClaims"`
+	MutedUsers            []string              `json:"mutedUsers"`
+	SpectatorChatHiddenBy []string              `json:"spectatorChatHiddenBy"`
+	TieBreakerWins        map[string]int        `json:"tieBreakerWins"`
+	BanList               []string              `json:"banList"`
+	BanExpiresAt          map[string]time.Time  `json:"banExpiresAt"`
 	PausedState           PausedState           `json:"pausedState"`
 	SpectatorCount        int                   `json:"spectatorCount"`
+	Spectators            []User                `json:"spectators"`
 }
 
-func NewModeratorRoom(room *Room, spectatorCount int) RoomModerator {
+func NewModeratorRoom(room *Room, spectatorCount int, spectators []User) RoomModerator {
 	return RoomModerator{
 		Id:                    room.Id,
 		Name:                  room.Name,
@@ -27,15 +38,24 @@ func NewModeratorRoom(room *Room, spectatorCount int) RoomModerator {
 		Options:               room.Options,
 		Moderator:             room.Moderator,
 		Players:               room.Players,
+		Teams:                 room.Teams,
 		State:                 room.State,
 		CurrentRoundName:      room.CurrentRoundName,
 		CurrentRoundQuestions: room.CurrentRoundQuestions,
 		CurrentPlayer:         room.CurrentPlayer,
+		SelectionEndsAt:       room.SelectionEndsAt,
 		CurrentQuestion:       room.CurrentQuestion,
 		AnsweringPlayer:       room.AnsweringPlayer,
 		AllowedToAnswer:       room.AllowedToAnswer,
 		FinalRoundState:       room.FinalRoundState,
+		SeatClaims:            room.SeatClaims,
+		MutedUsers:            room.MutedUsers,
+		SpectatorChatHiddenBy: room.SpectatorChatHiddenBy,
+		TieBreakerWins:        room.TieBreakerWins,
+		BanList:               room.BanList,
+		BanExpiresAt:          room.BanExpiresAt,
 		PausedState:           room.PausedState,
 		SpectatorCount:        spectatorCount,
+		Spectators:            spectators,
 	}
 }
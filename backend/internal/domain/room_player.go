@@ -9,31 +9,46 @@ type RoomPlayer struct {
 	Options               RoomOptions            `json:"options"`
 	Moderator             *Moderator             `json:"moderator"`
 	Players               []Player               `json:"players"`
+	Teams                 []Team                 `json:"teams"`
 	State                 RoomState              `json:"state"`
 	CurrentRoundName      *string                `json:"currentRoundName"`
 	CurrentRoundQuestions CurrentRoundQuestions  `json:"currentRoundQuestions"`
 	CurrentPlayer         *string                `json:"currentPlayer"`
+	SelectionEndsAt       *time.Time             `json:"selectionEndsAt"`
 	CurrentQuestion       *HiddenCurrentQuestion `json:"currentQuestion"`
 	AnsweringPlayer       *AnsweringPlayer       `json:"answeringPlayer"`
 	AllowedToAnswer       []string               `json:"allowedToAnswer"`
 	FinalRoundState       *HiddenFinalRoundState `json:"finalRoundState"`
+	SeatClaims            []SeatClaim            `json:"seatClaims"`
+	MutedUsers            []string               `json:"mutedUsers"`
+	SpectatorChatHiddenBy []string               `json:"spectatorChatHiddenBy"`
+	TieBreakerWins        map[string]int         `json:"tieBreakerWins"`
 	PausedState           PausedState            `json:"pausedState"`
 	SpectatorCount        int                    `json:"spectatorCount"`
+	Spectators            []User                 `json:"spectators"`
 }
 
 type HiddenCurrentQuestion struct {
 	HiddenQuestion
-	Type                         QuestionType `json:"type"`
-	Text                         *string      `json:"text"`
-	Attachment                   *Attachment  `json:"attachment"`
-	AttachmentRevealEndsAt       time.Time    `json:"attachmentRevealEndsAt"`
-	AttachmentRevealLastProgress float64      `json:"attachmentRevealLastProgress"`
-	TextRevealLastProgress       float64      `json:"textRevealLastProgress"`
-	TimerStartsAt                time.Time    `json:"timerStartsAt"`
-	TimerEndsAt                  time.Time    `json:"timerEndsAt"`
-	TimerLastProgress            float64      `json:"timerLastProgress"`
-	BettingEndsAt                time.Time    `json:"bettingEndsAt"`
-	PassingEndsAt                time.Time    `json:"passingEndsAt"`
+	Type                         QuestionType       `json:"type"`
+	Text                         *string            `json:"text"`
+	Attachment                   *Attachment        `json:"attachment"`
+	Options                      []string           `json:"options,omitempty"`
+	AttachmentRevealEndsAt       time.Time          `json:"attachmentRevealEndsAt"`
+	AttachmentRevealLastProgress float64            `json:"attachmentRevealLastProgress"`
+	TextRevealLastProgress       float64            `json:"textRevealLastProgress"`
+	TimerStartsAt                time.Time          `json:"timerStartsAt"`
+	TimerEndsAt                  time.Time          `json:"timerEndsAt"`
+	TimerLastProgress            float64            `json:"timerLastProgress"`
+	BettingEndsAt                time.Time          `json:"bettingEndsAt"`
+	PassingEndsAt                time.Time          `json:"passingEndsAt"`
+	PriceEndsAt                  time.Time          `json:"priceEndsAt"`
+	BuzzWindowEndsAt             time.Time          `json:"buzzWindowEndsAt"`
+	Buzzes                       []Buzz             `json:"buzzes"`
+	Lockouts                     map[string]Lockout `json:"lockouts"`
+	Theme                        *string            `json:"theme"`
+	PriceRange                   *PriceRange        `json:"priceRange"`
+	Auction                      *AuctionState      `json:"auction"`
 }
 
 type HiddenFinalRoundState struct {
@@ -43,9 +58,11 @@ type HiddenFinalRoundState struct {
 	PlayersAnswers      map[string]bool           `json:"playersAnswers"`
 	BettingEndsAt       *time.Time                `json:"bettingEndsAt"`
 	TimerEndsAt         *time.Time                `json:"timerEndsAt"`
+	TieBreaker          bool                      `json:"tieBreaker"`
+	Numeric             bool                      `json:"numeric"`
 }
 
-func NewPlayerRoom(room *Room, spectatorCount int) RoomPlayer {
+func NewPlayerRoom(room *Room, spectatorCount int, spectators []User) RoomPlayer {
 	var currentQuestion *HiddenCurrentQuestion
 	if room.CurrentQuestion != nil {
 		currentQuestion = &HiddenCurrentQuestion{
@@ -58,6 +75,7 @@ func NewPlayerRoom(room *Room, spectatorCount int) RoomPlayer {
 			Type:                         room.CurrentQuestion.Type,
 			Text:                         room.CurrentQuestion.Text,
 			Attachment:                   room.CurrentQuestion.Attachment,
+			Options:                      room.CurrentQuestion.Options,
 			AttachmentRevealEndsAt:       room.CurrentQuestion.AttachmentRevealEndsAt,
 			AttachmentRevealLastProgress: room.CurrentQuestion.AttachmentRevealLastProgress,
 			TextRevealLastProgress:       room.CurrentQuestion.TextRevealLastProgress,
@@ -66,6 +84,13 @@ func NewPlayerRoom(room *Room, spectatorCount int) RoomPlayer {
 			TimerLastProgress:            room.CurrentQuestion.TimerLastProgress,
 			BettingEndsAt:                room.CurrentQuestion.BettingEndsAt,
 			PassingEndsAt:                room.CurrentQuestion.PassingEndsAt,
+			PriceEndsAt:                  room.CurrentQuestion.PriceEndsAt,
+			BuzzWindowEndsAt:             room.CurrentQuestion.BuzzWindowEndsAt,
+			Buzzes:                       room.CurrentQuestion.Buzzes,
+			Lockouts:                     room.CurrentQuestion.Lockouts,
+			Theme:                        room.CurrentQuestion.Theme,
+			PriceRange:                   newPriceRange(room),
+			Auction:                      room.CurrentQuestion.Auction,
 		}
 	}
 	var finalRoundState *HiddenFinalRoundState
@@ -89,6 +114,8 @@ func NewPlayerRoom(room *Room, spectatorCount int) RoomPlayer {
 			PlayersAnswers:      finalRoundPlayersAnswers,
 			BettingEndsAt:       room.FinalRoundState.BettingEndsAt,
 			TimerEndsAt:         room.FinalRoundState.TimerEndsAt,
+			TieBreaker:          room.FinalRoundState.TieBreaker,
+			Numeric:             room.FinalRoundState.IsNumeric(),
 		}
 	}
 	return RoomPlayer{
@@ -98,15 +125,38 @@ func NewPlayerRoom(room *Room, spectatorCount int) RoomPlayer {
 		Options:               room.Options,
 		Moderator:             room.Moderator,
 		Players:               room.Players,
+		Teams:                 room.Teams,
 		State:                 room.State,
 		CurrentRoundName:      room.CurrentRoundName,
 		CurrentRoundQuestions: room.CurrentRoundQuestions,
 		CurrentPlayer:         room.CurrentPlayer,
+		SelectionEndsAt:       room.SelectionEndsAt,
 		CurrentQuestion:       currentQuestion,
 		AnsweringPlayer:       room.AnsweringPlayer,
 		AllowedToAnswer:       room.AllowedToAnswer,
 		FinalRoundState:       finalRoundState,
+		SeatClaims:            room.SeatClaims,
+		MutedUsers:            room.MutedUsers,
+		SpectatorChatHiddenBy: room.SpectatorChatHiddenBy,
+		TieBreakerWins:        room.TieBreakerWins,
 		PausedState:           room.PausedState,
 		SpectatorCount:        spectatorCount,
+		Spectators:            spectators,
 	}
 }
+
+// PriceRange is the part of a cat in bag's params the receiver needs while
+// choosing its price; it stays hidden before and after that step.
+type PriceRange struct {
+	Min  int `json:"min"`
+	Max  int `json:"max"`
+	Step int `json:"step"`
+}
+
+func newPriceRange(room *Room) *PriceRange {
+	if room.State != ChoosingPrice {
+		return nil
+	}
+	params := room.CurrentQuestion.CatInBagParams
+	return &PriceRange{Min: params.MinPrice, Max: params.MaxPrice, Step: params.Step}
+}
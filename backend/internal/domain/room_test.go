@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"encoding/json"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -1318,6 +1320,50 @@ func TestValidateFinalRoundAnswer_LastPlayer_EndsGame(t *testing.T) {
 	assert.Equal(t, GameOver, r.State)
 }
 
+func TestValidateFinalRoundAnswer_AIHost_ModeratorForbidden(t *testing.T) {
+	r := buildRoom(withValidatingFinalRound(), func(r *Room) {
+		r.Options.AIHost = true
+	})
+	err := r.ValidateFinalRoundAnswer("host1", true)
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, err, &fe)
+}
+
+func TestValidateFinalRoundAnswer_AIHost_SystemAllowed(t *testing.T) {
+	r := buildRoom(withValidatingFinalRound(), func(r *Room) {
+		r.Options.AIHost = true
+	})
+	err := r.ValidateFinalRoundAnswer(SYSTEM, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1300, r.Players[0].Score)
+	assert.Equal(t, "p2", *r.CurrentPlayer)
+}
+
+func TestRevealSystemVerdict_KeptAcrossPause(t *testing.T) {
+	r := buildRoom(withValidatingFinalRound(), func(r *Room) {
+		r.Options.AIHost = true
+	})
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.RevealSystemVerdict(), &ce, "nothing graded yet")
+
+	assert.NoError(t, r.SetSystemVerdicts(map[string]SystemVerdict{
+		"p1": {IsCorrect: true, Confidence: 0.9},
+		"p2": {IsCorrect: false, Confidence: 0.7},
+	}))
+	assert.ErrorAs(t, r.SetSystemVerdicts(map[string]SystemVerdict{}), &ce, "graded only once")
+
+	// an undo can bring the room back to this state while it is paused
+	r.PausedState = PausedState{Paused: true, PausedAt: ptr(time.Now())}
+	assert.ErrorAs(t, r.RevealSystemVerdict(), &ce)
+	assert.NoError(t, r.Unpause("host1"))
+
+	assert.NoError(t, r.RevealSystemVerdict())
+	assert.Equal(t, 1300, r.Players[0].Score)
+	assert.Equal(t, "p2", *r.CurrentPlayer)
+	assert.NoError(t, r.RevealSystemVerdict())
+	assert.Equal(t, 500, r.Players[1].Score)
+}
+
 // ---- 20. EndGame ----
 
 func TestEndGame_SetsGameOverAndClearsTimers(t *testing.T) {
@@ -1494,13 +1540,13 @@ func TestStartGame_SetsCurrentPlayerAndStartsRound(t *testing.T) {
 
 func TestGetProjection_Moderator_ReturnsRoomModerator(t *testing.T) {
 	r := buildRoom()
-	_, ok := r.GetProjection("host1", 0).(RoomModerator)
+	_, ok := r.GetProjection("host1", 0, nil).(RoomModerator)
 	assert.True(t, ok)
 }
 
 func TestGetProjection_Player_ReturnsRoomPlayer(t *testing.T) {
 	r := buildRoom()
-	_, ok := r.GetProjection("p1", 0).(RoomPlayer)
+	_, ok := r.GetProjection("p1", 0, nil).(RoomPlayer)
 	assert.True(t, ok)
 }
 
@@ -1508,7 +1554,7 @@ func TestGetProjection_Outsider_ReturnsRoomPlayer(t *testing.T) {
 	r := buildRoom()
 	// Non-members (spectators) receive the same live player view as players —
 	// board, scores, timers — never the lobby card or host view.
-	_, ok := r.GetProjection("nobody", 0).(RoomPlayer)
+	_, ok := r.GetProjection("nobody", 0, nil).(RoomPlayer)
 	assert.True(t, ok)
 }
 
@@ -1740,3 +1786,1884 @@ func TestPause_AllowedStates_SetsPausedFlag(t *testing.T) {
 		})
 	}
 }
+
+// ---- 30. Open auction ----
+
+func withOpenAuction(pack *Pack) func(*Room) {
+	return func(r *Room) {
+		r.Options.OpenAuction = true
+		r.State = WaitingForStart
+		r.StartNextRegularRound(pack)
+		r.CurrentPlayer = ptr("p1")
+	}
+}
+
+func TestOpenAuction_SelectQuestion_StartsWithSelector(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withOpenAuction(pack))
+	err := r.SelectQuestion("p1", pack, "Geography", 1, noopAttachmentUrl)
+	assert.NoError(t, err)
+	assert.Equal(t, Betting, r.State)
+	assert.NotNil(t, r.CurrentQuestion.Auction)
+	assert.Equal(t, "p1", *r.CurrentPlayer)
+	assert.Equal(t, "p1", r.CurrentQuestion.Auction.SelectedBy)
+}
+
+func TestOpenAuction_PlaceBet_Conflict(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withOpenAuction(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 1, noopAttachmentUrl))
+	err := r.PlaceBet("p1", 300)
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, err, &ce)
+}
+
+func TestOpenAuction_RaiseBet_Guards(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withOpenAuction(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 1, noopAttachmentUrl))
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.RaiseBet("p2", 300), &fe)
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.RaiseBet("p1", 100), &ce, "below nominal value")
+	assert.ErrorAs(t, r.RaiseBet("p1", 5000), &ce, "above score")
+}
+
+func TestOpenAuction_RaiseThenPass_HighBidderAnswers(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withOpenAuction(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 1, noopAttachmentUrl))
+
+	assert.NoError(t, r.RaiseBet("p1", 300))
+	assert.Equal(t, "p2", *r.CurrentPlayer)
+	assert.NoError(t, r.RaiseBet("p2", 400))
+	assert.Equal(t, "p1", *r.CurrentPlayer)
+	assert.NoError(t, r.PassBet("p1"))
+
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+	assert.Equal(t, 400, *r.Players[1].BetAmount)
+}
+
+func TestOpenAuction_AllIn_OnlyHigherAllInOutbids(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withOpenAuction(pack), func(r *Room) {
+		r.Players[1].Score = 1500
+	})
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 1, noopAttachmentUrl))
+
+	assert.NoError(t, r.AllInBet("p1"))
+	assert.True(t, r.CurrentQuestion.Auction.AllIn)
+	assert.Equal(t, 1000, r.CurrentQuestion.Auction.Bid)
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.RaiseBet("p2", 1200), &ce)
+	assert.NoError(t, r.AllInBet("p2"))
+
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+	assert.Equal(t, 1500, *r.Players[1].BetAmount)
+}
+
+func TestOpenAuction_AllPass_EndsQuestion(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withOpenAuction(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 1, noopAttachmentUrl))
+
+	assert.NoError(t, r.PassBet("p1"))
+	r.PlaceBetsAuto()
+
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.Nil(t, r.CurrentQuestion)
+	assert.Equal(t, "p1", *r.CurrentPlayer)
+}
+
+// ---- 31. Game log ----
+
+func TestGameLog_SelectBuzzVerdict_RecordsEventsInOrder(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withRound1(pack))
+	r.DrainEvents()
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 0, noopAttachmentUrl))
+	r.State = ShowingQuestion
+	assert.NoError(t, r.SubmitAnswer("p2"))
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+
+	events := r.DrainEvents()
+	types := make([]GameEventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	assert.Equal(t, []GameEventType{QuestionSelectedEvent, BuzzEvent, VerdictEvent, ScoreChangedEvent, QuestionEndedEvent}, types)
+	assert.Equal(t, "Paris", events[0].Question.Answers[0])
+	assert.Equal(t, "p2", events[1].ActorId)
+	assert.Equal(t, "host1", events[2].ActorId)
+	assert.True(t, *events[2].IsCorrect)
+	assert.Equal(t, 100, *events[3].Amount)
+	assert.Equal(t, 1100, *events[3].Score)
+	assert.Empty(t, r.DrainEvents())
+}
+
+func TestGameLog_ValidateAnswerBySystem_RecordsConfidence(t *testing.T) {
+	r := buildRoom(withAnsweringP1(200))
+
+	assert.NoError(t, r.ValidateAnswerBySystem(false, 0.8))
+
+	events := r.DrainEvents()
+	assert.Equal(t, VerdictEvent, events[0].Type)
+	assert.Equal(t, SYSTEM, events[0].ActorId)
+	assert.False(t, *events[0].IsCorrect)
+	assert.Equal(t, 0.8, *events[0].Confidence)
+}
+
+func TestGameLog_ChangeScore_RecordsDelta(t *testing.T) {
+	r := buildRoom()
+
+	assert.NoError(t, r.ChangeScore("host1", "p1", 700))
+
+	events := r.DrainEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, ScoreChangedEvent, events[0].Type)
+	assert.Equal(t, -300, *events[0].Amount)
+	assert.Equal(t, 700, *events[0].Score)
+}
+
+// ---- 32. Replay ----
+
+func playRecordedGame(t *testing.T) (Room, []GameEvent) {
+	t.Helper()
+	pack := buildPack()
+	r := buildRoom(func(r *Room) { r.State = WaitingForStart })
+	r.StartGame(pack)
+	r.CurrentPlayer = ptr("p1")
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 0, noopAttachmentUrl))
+	r.State = ShowingQuestion
+	assert.NoError(t, r.SubmitAnswer("p1"))
+	assert.NoError(t, r.ValidateAnswer("host1", false))
+	r.State = ShowingQuestion
+	assert.NoError(t, r.SubmitAnswer("p2"))
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+	return r, r.DrainEvents()
+}
+
+func TestReplay_BuildsRoundsQuestionsAndScores(t *testing.T) {
+	r, events := playRecordedGame(t)
+
+	replay := NewReplay(&r, events)
+
+	assert.Len(t, replay.Steps, len(events))
+	assert.Len(t, replay.Rounds, 1)
+	round := replay.Rounds[0]
+	assert.Equal(t, "Round 1", round.Name)
+	assert.True(t, round.Board[0].Questions[0].HasBeenPlayed)
+	assert.False(t, round.Board[0].Questions[1].HasBeenPlayed)
+
+	assert.Len(t, round.Questions, 1)
+	question := round.Questions[0]
+	assert.Equal(t, "p1", question.SelectedBy)
+	assert.Equal(t, []string{"p1", "p2"}, question.Buzzes)
+	assert.Len(t, question.Verdicts, 2)
+	assert.False(t, question.Verdicts[0].IsCorrect)
+	assert.True(t, question.Verdicts[1].IsCorrect)
+
+	last := replay.Steps[len(replay.Steps)-1]
+	assert.Equal(t, map[string]int{"p1": 900, "p2": 1100}, last.Scores)
+}
+
+func TestReplayState_ReconstructsMidQuestion(t *testing.T) {
+	_, events := playRecordedGame(t)
+	step := slices.IndexFunc(events, func(e GameEvent) bool {
+		return e.Type == ScoreChangedEvent
+	})
+
+	state, err := NewReplayState(events, step)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Round 1", *state.Round)
+	assert.Equal(t, []string{"p1"}, state.Question.Buzzes)
+	assert.Equal(t, 900, state.Scores["p1"])
+	assert.Equal(t, 0, state.Scores["p2"])
+}
+
+func TestReplayState_StepOutOfRange(t *testing.T) {
+	_, events := playRecordedGame(t)
+
+	_, err := NewReplayState(events, len(events))
+
+	var ne custerr.NotFoundErr
+	assert.ErrorAs(t, err, &ne)
+}
+
+// ---- 33. User stats ----
+
+func TestGameStats_CountsAnswersBuzzesAndWinner(t *testing.T) {
+	r, events := playRecordedGame(t)
+
+	stats := NewGameStats(&r, events)
+
+	assert.Len(t, stats, 2)
+	p1, p2 := stats[0], stats[1]
+	assert.Equal(t, 1, p1.GamesPlayed)
+	assert.Equal(t, 0, p1.GamesWon)
+	assert.Equal(t, 1, p1.IncorrectAnswers)
+	assert.Equal(t, 1, p1.Buzzes)
+	assert.Equal(t, 1, p1.TimedBuzzes)
+	assert.Equal(t, 1, p2.GamesWon)
+	assert.Equal(t, 1100, p2.TotalScore)
+	assert.Equal(t, 1, p2.CorrectAnswers)
+}
+
+func TestGameStats_SkipsGuests(t *testing.T) {
+	r, events := playRecordedGame(t)
+	r.Players[0].IsGuest = true
+
+	stats := NewGameStats(&r, events)
+
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "p2", stats[0].UserId)
+}
+
+func TestGameStats_AuctionAndFinalRoundResults(t *testing.T) {
+	r := buildRoom()
+	events := []GameEvent{
+		{Type: QuestionSelectedEvent, Question: &GameEventQuestion{Type: Auction}},
+		{Type: VerdictEvent, PlayerId: ptr("p1"), IsCorrect: ptr(true)},
+		{Type: QuestionEndedEvent},
+		{Type: FinalRoundStartedEvent},
+		{Type: VerdictEvent, PlayerId: ptr("p1"), IsCorrect: ptr(false)},
+	}
+
+	p1 := NewGameStats(&r, events)[0].View()
+
+	assert.Equal(t, 1, p1.AuctionsPlayed)
+	assert.Equal(t, 1, p1.AuctionsWon)
+	assert.Equal(t, 1, p1.FinalRoundsPlayed)
+	assert.Equal(t, 0.0, *p1.FinalRoundAccuracy)
+	assert.Nil(t, p1.AverageBuzzReactionMs)
+}
+
+func TestGameStats_TieBreakerAndQuestionsForEveryoneAreNotFinalRound(t *testing.T) {
+	r := buildRoom()
+	events := []GameEvent{
+		{Type: FinalRoundStartedEvent},
+		{Type: VerdictEvent, PlayerId: ptr("p1"), IsCorrect: ptr(true)},
+		{Type: TieBreakerStartedEvent},
+		{Type: VerdictEvent, PlayerId: ptr("p1"), IsCorrect: ptr(false)},
+	}
+	forEveryone := []GameEvent{
+		{Type: QuestionSelectedEvent, Question: &GameEventQuestion{Type: ForEveryone}},
+		{Type: VerdictEvent, PlayerId: ptr("p1"), IsCorrect: ptr(true)},
+		{Type: QuestionEndedEvent},
+		{Type: QuestionSelectedEvent, Question: &GameEventQuestion{Type: Numeric}},
+		{Type: VerdictEvent, PlayerId: ptr("p1"), IsCorrect: ptr(false)},
+		{Type: QuestionEndedEvent},
+	}
+
+	p1 := NewGameStats(&r, events)[0]
+	assert.Equal(t, 1, p1.FinalRoundsPlayed)
+	assert.Equal(t, 1, p1.FinalRoundsCorrect)
+	assert.Equal(t, 1, p1.IncorrectAnswers)
+
+	p1 = NewGameStats(&r, forEveryone)[0]
+	assert.Equal(t, 0, p1.FinalRoundsPlayed)
+	assert.Equal(t, 1, p1.CorrectAnswers)
+	assert.Equal(t, 1, p1.IncorrectAnswers)
+}
+
+// ---- 34. Ratings ----
+
+func TestRatingChanges_WinnerGainsWhatLoserLoses(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Players[0].Score = 1500
+		r.Players[1].Score = 300
+	})
+
+	changes := NewRatingChanges(&r, map[string]float64{}, time.Now())
+
+	assert.Len(t, changes, 2)
+	assert.Equal(t, InitialRating, changes[0].Before)
+	assert.InDelta(t, InitialRating+RatingK/2, changes[0].After, 0.001)
+	assert.InDelta(t, InitialRating-RatingK/2, changes[1].After, 0.001)
+	assert.Equal(t, ModeratedPool, changes[0].Pool)
+}
+
+func TestRatingChanges_UpsetMovesMoreThanExpectedWin(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Players[0].Score = 1500
+		r.Players[1].Score = 300
+	})
+
+	upset := NewRatingChanges(&r, map[string]float64{"p1": 1400, "p2": 1600}, time.Now())
+	expected := NewRatingChanges(&r, map[string]float64{"p1": 1600, "p2": 1400}, time.Now())
+
+	assert.Greater(t, upset[0].After-upset[0].Before, expected[0].After-expected[0].Before)
+}
+
+func TestRatingChanges_UnratedGuestsAndAIHost(t *testing.T) {
+	unrated := buildRoom(func(r *Room) { r.Options.Unrated = true })
+	assert.Nil(t, NewRatingChanges(&unrated, nil, time.Now()))
+
+	withGuest := buildRoom(func(r *Room) { r.Players[1].IsGuest = true })
+	assert.Nil(t, NewRatingChanges(&withGuest, nil, time.Now()), "one registered player is not a match")
+
+	aiHost := buildRoom(func(r *Room) { r.Options.AIHost = true })
+	assert.Equal(t, AIHostPool, NewRatingChanges(&aiHost, nil, time.Now())[0].Pool)
+}
+
+// ---- 35. Team mode ----
+
+func buildTeamRoom(opts ...func(*Room)) Room {
+	return buildRoom(func(r *Room) {
+		r.Options.TeamMode = true
+		r.Players = append(r.Players,
+			Player{User: User{Id: "p3"}, Score: 0, IsConnected: true},
+			Player{User: User{Id: "p4"}, Score: 0, IsConnected: true},
+		)
+		for i := range r.Players {
+			r.Players[i].Score = 0
+		}
+		r.Teams = []Team{
+			{Id: "team-1", Name: "Team 1", Captain: ptr("p1"), Members: []string{"p1", "p2"}},
+			{Id: "team-2", Name: "Team 2", Captain: ptr("p3"), Members: []string{"p3", "p4"}},
+		}
+		for _, o := range opts {
+			o(r)
+		}
+	})
+}
+
+func TestJoinTeam_FirstMemberBecomesCaptain(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Options.TeamMode = true
+		r.Teams = NewTeams(2)
+		r.State = WaitingForStart
+	})
+
+	assert.NoError(t, r.JoinTeam("p1", "team-1"))
+	assert.NoError(t, r.JoinTeam("p2", "team-1"))
+	assert.Equal(t, ptr("p1"), r.Teams[0].Captain)
+
+	assert.NoError(t, r.JoinTeam("p1", "team-2"))
+	assert.Equal(t, []string{"p2"}, r.Teams[0].Members)
+	assert.Equal(t, ptr("p2"), r.Teams[0].Captain, "captaincy passes on when the captain leaves")
+	assert.Equal(t, ptr("p1"), r.Teams[1].Captain)
+}
+
+func TestJoinTeam_OnlyBeforeStart(t *testing.T) {
+	r := buildTeamRoom()
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.JoinTeam("p2", "team-2"), &ce)
+
+	notTeamMode := buildRoom(func(r *Room) { r.State = WaitingForStart })
+	ce = custerr.ConflictErr{}
+	assert.ErrorAs(t, notTeamMode.JoinTeam("p1", "team-1"), &ce)
+}
+
+func TestPrepareTeams_AssignsUnassignedAndDropsEmpty(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Options.TeamMode = true
+		r.Teams = NewTeams(3)
+		r.State = WaitingForStart
+	})
+	assert.NoError(t, r.JoinTeam("p1", "team-1"))
+
+	assert.NoError(t, r.PrepareTeams())
+	assert.Len(t, r.Teams, 2)
+	assert.Equal(t, []string{"p2"}, r.Teams[1].Members)
+}
+
+func TestPrepareTeams_NeedsTwoTeams(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Options.TeamMode = true
+		r.Teams = NewTeams(2)
+		r.State = WaitingForStart
+	})
+	assert.NoError(t, r.JoinTeam("p1", "team-1"))
+	assert.NoError(t, r.JoinTeam("p2", "team-1"))
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.PrepareTeams(), &ce)
+}
+
+func TestTeamMode_BuzzLocksOutWholeTeam(t *testing.T) {
+	pack := buildPack()
+	r := buildTeamRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 0, noopAttachmentUrl))
+	assert.ElementsMatch(t, []string{"p1", "p2", "p3", "p4"}, r.AllowedToAnswer)
+	r.StartRegularQuestion()
+
+	assert.NoError(t, r.SubmitAnswer("p2"))
+	assert.ElementsMatch(t, []string{"p3", "p4"}, r.AllowedToAnswer)
+}
+
+func TestTeamMode_CaptainAnswers(t *testing.T) {
+	pack := buildPack()
+	r := buildTeamRoom(withRound1(pack), func(r *Room) { r.Options.TeamAnswering = CaptainAnswers })
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 0, noopAttachmentUrl))
+	assert.ElementsMatch(t, []string{"p1", "p3"}, r.AllowedToAnswer)
+}
+
+func TestTeamMode_VerdictScoresTeam(t *testing.T) {
+	pack := buildPack()
+	r := buildTeamRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 0, noopAttachmentUrl))
+	r.StartRegularQuestion()
+	assert.NoError(t, r.SubmitAnswer("p2"))
+
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+	assert.Equal(t, 100, r.Teams[0].Score)
+	assert.Equal(t, 100, r.Players[1].Score, "player keeps their own contribution")
+	assert.Equal(t, 100, r.ScoreOf("p1"))
+	assert.Equal(t, 0, r.ScoreOf("p3"))
+
+	events := r.DrainEvents()
+	scoreChanged := events[len(events)-2]
+	assert.Equal(t, ScoreChangedEvent, scoreChanged.Type)
+	assert.Equal(t, ptr("team-1"), scoreChanged.TeamId)
+	assert.Equal(t, ptr(100), scoreChanged.TeamScore)
+}
+
+func TestTeamMode_ChangeTeamScore(t *testing.T) {
+	r := buildTeamRoom()
+	assert.NoError(t, r.ChangeTeamScore("host1", "team-2", 700))
+	assert.Equal(t, 700, r.ScoreOf("p4"))
+
+	var nfe custerr.NotFoundErr
+	assert.ErrorAs(t, r.ChangeScore("host1", "team-2", 500), &nfe, "a team id is not a player id")
+	assert.ErrorAs(t, r.ChangeTeamScore("host1", "p4", 500), &nfe)
+
+	var ce custerr.ConflictErr
+	solo := buildRoom()
+	assert.ErrorAs(t, solo.ChangeTeamScore("host1", "team-1", 500), &ce)
+}
+
+func TestTeamMode_CatInBagPassesToOtherCaptain(t *testing.T) {
+	pack := buildPack()
+	r := buildTeamRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 2, noopAttachmentUrl))
+	assert.Equal(t, Answering, r.State, "the only other captain gets the question")
+	assert.Equal(t, "p3", r.AnsweringPlayer.Id)
+}
+
+func TestTeamMode_FinalRoundIsPlayedByCaptains(t *testing.T) {
+	r := buildTeamRoom(func(r *Room) {
+		r.Teams[0].Score = 500
+		r.Teams[1].Score = 300
+	})
+	ok, err := r.StartFinalRound(buildPackSingleFinalCat(), noopAttachmentUrl)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.ElementsMatch(t, []string{"p1", "p3"}, r.FinalRoundState.Players)
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.PlaceFinalRoundBet("p2", 100), &fe)
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.PlaceFinalRoundBet("p3", 400), &ce)
+	assert.NoError(t, r.PlaceFinalRoundBet("p1", 500))
+}
+
+func TestTeamMode_RatingsSkipTeammates(t *testing.T) {
+	r := buildTeamRoom(func(r *Room) {
+		r.Teams[0].Score = 500
+		r.Players[0].Score = 500
+	})
+
+	changes := NewRatingChanges(&r, map[string]float64{}, time.Now())
+
+	assert.Len(t, changes, 4)
+	assert.InDelta(t, InitialRating+RatingK/2, changes[1].After, 0.001, "p2 wins with the team despite scoring nothing")
+	assert.InDelta(t, InitialRating-RatingK/2, changes[3].After, 0.001)
+}
+
+// ---- 36. Multiple choice ----
+
+func buildMultipleChoicePack() *Pack {
+	pack := buildPack()
+	text := "Capital of Spain?"
+	pack.Rounds[0].Categories = append(pack.Rounds[0].Categories, Category{
+		Name: "Choice",
+		Questions: []Question{
+			{
+				HiddenQuestion: HiddenQuestion{Round: "Round 1", Category: "Choice", Index: 0, Value: 100},
+				Type:           MultipleChoice,
+				Text:           &text,
+				Options:        []string{"Lisbon", "Madrid", "Porto"},
+				Answers:        []string{"Madrid"},
+			},
+		},
+	})
+	return pack
+}
+
+func withMultipleChoiceAnswering(t *testing.T) func(*Room) {
+	return func(r *Room) {
+		pack := buildMultipleChoicePack()
+		withRound1(pack)(r)
+		assert.NoError(t, r.SelectQuestion("p1", pack, "Choice", 0, noopAttachmentUrl))
+		r.StartRegularQuestion()
+		assert.NoError(t, r.SubmitAnswer("p1"))
+	}
+}
+
+func TestMultipleChoice_PlaysLikeRegularQuestion(t *testing.T) {
+	pack := buildMultipleChoicePack()
+	r := buildRoom(withRound1(pack))
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Choice", 0, noopAttachmentUrl))
+	assert.Equal(t, RevealingQuestion, r.State)
+	assert.ElementsMatch(t, []string{"p1", "p2"}, r.AllowedToAnswer)
+	assert.Equal(t, []string{"Lisbon", "Madrid", "Porto"}, NewPlayerRoom(&r, 0, nil).CurrentQuestion.Options)
+}
+
+func TestChooseOption_CorrectOptionScores(t *testing.T) {
+	r := buildRoom(withMultipleChoiceAnswering(t))
+
+	assert.NoError(t, r.ChooseOption("p1", 1))
+	assert.Equal(t, 1100, r.Players[0].Score)
+	assert.Equal(t, SelectingQuestion, r.State)
+}
+
+func TestChooseOption_WrongOptionPassesToOthers(t *testing.T) {
+	r := buildRoom(withMultipleChoiceAnswering(t))
+
+	assert.NoError(t, r.ChooseOption("p1", 0))
+	assert.Equal(t, 900, r.Players[0].Score)
+	assert.Equal(t, []string{"p2"}, r.AllowedToAnswer)
+
+	events := r.DrainEvents()
+	verdict := events[len(events)-2]
+	assert.Equal(t, VerdictEvent, verdict.Type)
+	assert.Equal(t, SYSTEM, verdict.ActorId)
+	assert.Equal(t, ptr("Lisbon"), verdict.Answer)
+}
+
+func TestChooseOption_Rejections(t *testing.T) {
+	r := buildRoom(withMultipleChoiceAnswering(t))
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.ChooseOption("p2", 1), &fe)
+	var be custerr.BadRequestErr
+	assert.ErrorAs(t, r.ChooseOption("p1", 3), &be)
+	fe = custerr.ForbiddenErr{}
+	assert.ErrorAs(t, r.ValidateAnswer("host1", true), &fe, "moderator does not grade multiple choice")
+	assert.Equal(t, Answering, r.State)
+}
+
+func TestChooseOption_NotMultipleChoice(t *testing.T) {
+	r := buildRoom(withAnsweringP1(200))
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.ChooseOption("p1", 0), &ce)
+}
+
+// ---- 37. For everyone ----
+
+func buildForEveryonePack() *Pack {
+	pack := buildPack()
+	text := "Name a planet"
+	pack.Rounds[0].Categories = append(pack.Rounds[0].Categories, Category{
+		Name: "Everyone",
+		Questions: []Question{
+			{
+				HiddenQuestion: HiddenQuestion{Round: "Round 1", Category: "Everyone", Index: 0, Value: 300},
+				Type:           ForEveryone,
+				Text:           &text,
+				Answers:        []string{"Mars"},
+			},
+		},
+	})
+	return pack
+}
+
+func TestForEveryone_SelectStartsStakes(t *testing.T) {
+	pack := buildForEveryonePack()
+	r := buildRoom(withRound1(pack), func(r *Room) { r.Players[1].Score = 0 })
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Everyone", 0, noopAttachmentUrl))
+	assert.Equal(t, FinalRoundBetting, r.State)
+	assert.True(t, r.InForEveryoneQuestion())
+	assert.Equal(t, []string{"p1"}, r.FinalRoundState.Players)
+	assert.Equal(t, []string{"Mars"}, r.FinalRoundState.Question.Answers)
+	assert.Equal(t, ptr("p1"), r.FinalRoundState.SelectedBy)
+}
+
+func TestForEveryone_NobodyCanStake_PlaysAsRegular(t *testing.T) {
+	pack := buildForEveryonePack()
+	r := buildRoom(withRound1(pack), func(r *Room) {
+		r.Players[0].Score = 0
+		r.Players[1].Score = 0
+	})
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Everyone", 0, noopAttachmentUrl))
+	assert.Equal(t, RevealingQuestion, r.State)
+	assert.Nil(t, r.FinalRoundState)
+}
+
+func TestForEveryone_FullFlowReturnsToBoard(t *testing.T) {
+	pack := buildForEveryonePack()
+	r := buildRoom(withRound1(pack), func(r *Room) { r.CurrentPlayer = ptr("p2") })
+	assert.NoError(t, r.SelectQuestion("p2", pack, "Everyone", 0, noopAttachmentUrl))
+
+	assert.NoError(t, r.PlaceFinalRoundBet("p1", 200))
+	assert.NoError(t, r.PlaceFinalRoundBet("p2", 500))
+	assert.Equal(t, ShowingFinalRoundQuestion, r.State)
+
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "Mars"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "Pluto"))
+	assert.Equal(t, ValidatingFinalRoundAnswers, r.State)
+
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", true))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", false))
+
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.Equal(t, 1200, r.Players[0].Score)
+	assert.Equal(t, 500, r.Players[1].Score)
+	assert.Nil(t, r.FinalRoundState)
+	assert.Nil(t, r.CurrentQuestion)
+	assert.Equal(t, ptr("p2"), r.CurrentPlayer, "selector keeps the turn")
+	assert.Nil(t, r.Players[0].BetAmount)
+}
+
+func TestForEveryone_AllZeroStakesEndsQuestion(t *testing.T) {
+	pack := buildForEveryonePack()
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Everyone", 0, noopAttachmentUrl))
+
+	r.PlaceFinalRoundBetsAuto()
+
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.Equal(t, ptr("p1"), r.CurrentPlayer)
+}
+
+func TestForEveryone_Skip(t *testing.T) {
+	pack := buildForEveryonePack()
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Everyone", 0, noopAttachmentUrl))
+
+	assert.NoError(t, r.SkipQuestion("host1"))
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.Nil(t, r.FinalRoundState)
+}
+
+// ---- 38. No risk ----
+
+func buildNoRiskPack() *Pack {
+	pack := buildPack()
+	text := "Largest ocean?"
+	pack.Rounds[0].Categories = append(pack.Rounds[0].Categories, Category{
+		Name: "Sponsored",
+		Questions: []Question{
+			{
+				HiddenQuestion: HiddenQuestion{Round: "Round 1", Category: "Sponsored", Index: 0, Value: 200},
+				Type:           NoRisk,
+				Text:           &text,
+				Answers:        []string{"Pacific"},
+			},
+		},
+	})
+	return pack
+}
+
+func TestNoRisk_SelectorAnswersAlone(t *testing.T) {
+	pack := buildNoRiskPack()
+	r := buildRoom(withRound1(pack), func(r *Room) { r.CurrentPlayer = ptr("p2") })
+
+	assert.NoError(t, r.SelectQuestion("host1", pack, "Sponsored", 0, noopAttachmentUrl))
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+	assert.Empty(t, r.AllowedToAnswer)
+}
+
+func TestNoRisk_CorrectPaysMultiple(t *testing.T) {
+	pack := buildNoRiskPack()
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Sponsored", 0, noopAttachmentUrl))
+
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+	assert.Equal(t, 1400, r.Players[0].Score)
+	assert.Equal(t, SelectingQuestion, r.State)
+}
+
+func TestNoRisk_ConfiguredMultiplier(t *testing.T) {
+	pack := buildNoRiskPack()
+	r := buildRoom(withRound1(pack), func(r *Room) { r.Options.NoRiskMultiplier = 3 })
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Sponsored", 0, noopAttachmentUrl))
+
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+	assert.Equal(t, 1600, r.Players[0].Score)
+}
+
+func TestNoRisk_WrongCostsNothing(t *testing.T) {
+	pack := buildNoRiskPack()
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Sponsored", 0, noopAttachmentUrl))
+	r.DrainEvents()
+
+	assert.NoError(t, r.ValidateAnswer("host1", false))
+	assert.Equal(t, 1000, r.Players[0].Score)
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.False(t, slices.ContainsFunc(r.DrainEvents(), func(e GameEvent) bool {
+		return e.Type == ScoreChangedEvent
+	}))
+}
+
+// ---- 39. Cat in bag price ----
+
+func withCatInBagParams(pack *Pack, params *CatInBagParams) *Pack {
+	pack.Rounds[0].Categories[0].Questions[2].CatInBagParams = params
+	return pack
+}
+
+func TestCatInBag_FixedPriceAndThemeOverride(t *testing.T) {
+	pack := withCatInBagParams(buildPack(), &CatInBagParams{Theme: ptr("Music"), MinPrice: 700, MaxPrice: 700})
+	r := buildRoom(withRound1(pack))
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 2, noopAttachmentUrl))
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+	assert.Equal(t, 700, r.CurrentQuestion.Value)
+	assert.Equal(t, "Music", *r.CurrentQuestion.Theme)
+}
+
+func TestCatInBag_PriceRangeStartsChoosingPrice(t *testing.T) {
+	pack := withCatInBagParams(buildPack(), &CatInBagParams{MinPrice: 100, MaxPrice: 500, Step: 100})
+	r := buildRoom(withRound1(pack))
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 2, noopAttachmentUrl))
+	assert.Equal(t, ChoosingPrice, r.State)
+	assert.Equal(t, "p2", *r.CurrentPlayer)
+	assert.True(t, r.CurrentQuestion.PriceEndsAt.After(time.Now()))
+	assert.Equal(t, &PriceRange{Min: 100, Max: 500, Step: 100}, NewPlayerRoom(&r, 0, nil).CurrentQuestion.PriceRange)
+}
+
+func TestCatInBag_ChoosePrice(t *testing.T) {
+	pack := withCatInBagParams(buildPack(), &CatInBagParams{MinPrice: 100, MaxPrice: 500, Step: 100})
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 2, noopAttachmentUrl))
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.ChoosePrice("p1", 300), &fe)
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.ChoosePrice("p2", 250), &ce)
+	assert.ErrorAs(t, r.ChoosePrice("p2", 600), &ce)
+
+	r.DrainEvents()
+	assert.NoError(t, r.ChoosePrice("p2", 300))
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+	assert.Equal(t, 300, r.CurrentQuestion.Value)
+	assert.True(t, slices.ContainsFunc(r.DrainEvents(), func(e GameEvent) bool {
+		return e.Type == PriceChosenEvent && *e.Amount == 300
+	}))
+
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+	assert.Equal(t, 1300, r.Players[1].Score)
+}
+
+func TestCatInBag_ChoosePriceAutoPicksMinimum(t *testing.T) {
+	pack := withCatInBagParams(buildPack(), &CatInBagParams{MinPrice: 100, MaxPrice: 500})
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 2, noopAttachmentUrl))
+
+	r.ChoosePriceAuto()
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, 100, r.CurrentQuestion.Value)
+}
+
+// ---- 40. Buzzer fairness window ----
+
+func TestBuzz_ZeroWindow_GrantsImmediately(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	opened, err := r.Buzz(NewBuzz("p1", time.Now(), 0, 0), 0)
+	assert.NoError(t, err)
+	assert.False(t, opened)
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p1", r.AnsweringPlayer.Id)
+}
+
+func TestBuzz_FirstPressOpensWindow(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	now := time.Now()
+
+	opened, err := r.Buzz(NewBuzz("p1", now, 20*time.Millisecond, 150*time.Millisecond), 150*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, opened)
+	assert.Equal(t, ShowingQuestion, r.State)
+	assert.Equal(t, now.Add(150*time.Millisecond), r.CurrentQuestion.BuzzWindowEndsAt)
+
+	opened, err = r.Buzz(NewBuzz("p2", now.Add(10*time.Millisecond), 20*time.Millisecond, 150*time.Millisecond), 150*time.Millisecond)
+	assert.NoError(t, err)
+	assert.False(t, opened)
+	assert.Len(t, r.CurrentQuestion.Buzzes, 2)
+
+	_, err = r.Buzz(NewBuzz("p1", now.Add(20*time.Millisecond), 20*time.Millisecond, 150*time.Millisecond), 150*time.Millisecond)
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, err, &ce)
+}
+
+func TestResolveBuzzes_EarliestCompensatedPressWins(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	now := time.Now()
+	// p2 arrives later but over a much slower link, so it pressed first
+	_, _ = r.Buzz(NewBuzz("p1", now, 10*time.Millisecond, 150*time.Millisecond), 150*time.Millisecond)
+	_, _ = r.Buzz(NewBuzz("p2", now.Add(40*time.Millisecond), 140*time.Millisecond, 150*time.Millisecond), 150*time.Millisecond)
+	r.DrainEvents()
+
+	winner, buzzes := r.ResolveBuzzes()
+	assert.Equal(t, "p2", winner.PlayerId)
+	assert.Equal(t, []string{"p2", "p1"}, []string{buzzes[0].PlayerId, buzzes[1].PlayerId})
+	assert.Equal(t, now.Add(-30*time.Millisecond), buzzes[0].PressedAt)
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+	assert.False(t, r.HasPendingBuzzes())
+
+	events := r.DrainEvents()
+	idx := slices.IndexFunc(events, func(e GameEvent) bool { return e.Type == BuzzResolvedEvent })
+	assert.NotEqual(t, -1, idx)
+	assert.Equal(t, "p2", *events[idx].PlayerId)
+	assert.Len(t, events[idx].Buzzes, 2)
+}
+
+func TestResolveBuzzes_CompensationCappedAtWindow(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	now := time.Now()
+	window := 150 * time.Millisecond
+	// p2 claims a 2s round trip by holding back its pongs
+	_, _ = r.Buzz(NewBuzz("p1", now, 0, window), window)
+	_, _ = r.Buzz(NewBuzz("p2", now.Add(100*time.Millisecond), 2*time.Second, window), window)
+	r.DrainEvents()
+
+	winner, buzzes := r.ResolveBuzzes()
+	assert.Equal(t, "p1", winner.PlayerId)
+	assert.Equal(t, now.Add(25*time.Millisecond), buzzes[1].PressedAt)
+
+	events := r.DrainEvents()
+	idx := slices.IndexFunc(events, func(e GameEvent) bool { return e.Type == BuzzResolvedEvent })
+	assert.Equal(t, int64(2000), events[idx].Buzzes[1].RTTMs)
+	assert.Equal(t, MaxBuzzCompensation.Milliseconds(), events[idx].Buzzes[1].CompensationMs)
+}
+
+func TestResolveBuzzes_SkipsPlayersNoLongerAllowed(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	now := time.Now()
+	_, _ = r.Buzz(NewBuzz("p1", now, 0, 150*time.Millisecond), 150*time.Millisecond)
+	_, _ = r.Buzz(NewBuzz("p2", now.Add(10*time.Millisecond), 0, 150*time.Millisecond), 150*time.Millisecond)
+	r.AllowedToAnswer = []string{"p2"}
+
+	winner, _ := r.ResolveBuzzes()
+	assert.Equal(t, "p2", winner.PlayerId)
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+}
+
+func TestResolveBuzzes_NoEligibleWinner_KeepsShowingQuestion(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	_, _ = r.Buzz(NewBuzz("p1", time.Now(), 0, 150*time.Millisecond), 150*time.Millisecond)
+	r.AllowedToAnswer = []string{"p2"}
+
+	winner, buzzes := r.ResolveBuzzes()
+	assert.Nil(t, winner)
+	assert.Len(t, buzzes, 1)
+	assert.Equal(t, ShowingQuestion, r.State)
+	assert.Nil(t, r.AnsweringPlayer)
+}
+
+// ---- 41. False start lockout ----
+
+func withRevealingNoFalseStart(lockout int) func(*Room) {
+	return func(r *Room) {
+		r.State = RevealingQuestion
+		r.Options.FalseStartAllowed = false
+		r.Options.FalseStartLockout = lockout
+		r.CurrentQuestion = &CurrentQuestion{
+			Question:      Question{HiddenQuestion: HiddenQuestion{Value: 200}},
+			TimerStartsAt: time.Now().Add(3 * time.Second),
+			TimerEndsAt:   time.Now().Add(13 * time.Second),
+		}
+		r.AllowedToAnswer = []string{"p1", "p2"}
+	}
+}
+
+func TestFalseStart_LocksPlayerOutForInterval(t *testing.T) {
+	r := buildRoom(withRevealingNoFalseStart(1))
+
+	assert.NoError(t, r.SubmitAnswer("p1"))
+	assert.Equal(t, RevealingQuestion, r.State)
+	assert.Nil(t, r.AnsweringPlayer)
+	lockout := r.CurrentQuestion.Lockouts["p1"]
+	assert.NotNil(t, lockout.Until)
+	assert.WithinDuration(t, time.Now().Add(time.Second), *lockout.Until, 100*time.Millisecond)
+	assert.True(t, slices.ContainsFunc(r.DrainEvents(), func(e GameEvent) bool {
+		return e.Type == FalseStartEvent && e.ActorId == "p1"
+	}))
+
+	r.State = ShowingQuestion
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.SubmitAnswer("p1"), &ce)
+	assert.NoError(t, r.SubmitAnswer("p2"))
+	assert.Equal(t, "p2", r.AnsweringPlayer.Id)
+}
+
+func TestFalseStart_LockoutExpires(t *testing.T) {
+	r := buildRoom(withRevealingNoFalseStart(1))
+	assert.NoError(t, r.SubmitAnswer("p1"))
+	r.CurrentQuestion.Lockouts["p1"] = Lockout{Until: ptr(time.Now().Add(-time.Millisecond))}
+
+	r.State = ShowingQuestion
+	assert.NoError(t, r.SubmitAnswer("p1"))
+	assert.Equal(t, "p1", r.AnsweringPlayer.Id)
+}
+
+func TestFalseStart_WholeQuestionLockout(t *testing.T) {
+	r := buildRoom(withRevealingNoFalseStart(LockoutWholeQuestion))
+	assert.NoError(t, r.SubmitAnswer("p1"))
+	assert.Nil(t, r.CurrentQuestion.Lockouts["p1"].Until)
+	assert.NotNil(t, NewPlayerRoom(&r, 0, nil).CurrentQuestion.Lockouts)
+
+	r.State = ShowingQuestion
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.SubmitAnswer("p1"), &ce)
+}
+
+func TestFalseStart_LockoutShiftsOnPause(t *testing.T) {
+	r := buildRoom(withRevealingNoFalseStart(1))
+	assert.NoError(t, r.SubmitAnswer("p1"))
+	until := *r.CurrentQuestion.Lockouts["p1"].Until
+
+	r.shiftTimers(5 * time.Second)
+	assert.Equal(t, until.Add(5*time.Second), *r.CurrentQuestion.Lockouts["p1"].Until)
+}
+
+// ---- 42. Tie-breaker ----
+
+func withTieBreakers(count int) func(*Room) {
+	return func(r *Room) {
+		text := "Tie-breaker question"
+		for range count {
+			r.TieBreakers = append(r.TieBreakers, FinalRoundQuestion{
+				HiddenFinalRoundQuestion: HiddenFinalRoundQuestion{Category: TieBreakerCategory, Text: &text},
+				Answers:                  []string{"42"},
+			})
+		}
+	}
+}
+
+func TestPrepareTieBreakers_CopiesAttachments(t *testing.T) {
+	pack := buildPack()
+	pack.TieBreakers = []FinalRoundQuestion{{HiddenFinalRoundQuestion: HiddenFinalRoundQuestion{Attachment: &Attachment{Key: "k1"}}}}
+	r := buildRoom()
+
+	err := r.PrepareTieBreakers(pack, func(key string) (string, error) { return "signed/" + key, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "signed/k1", r.TieBreakers[0].Attachment.URL)
+	assert.Empty(t, pack.TieBreakers[0].Attachment.URL)
+}
+
+func TestEndGame_Draw_StartsTieBreaker(t *testing.T) {
+	r := buildRoom(withTieBreakers(1), func(r *Room) {
+		r.Players = append(r.Players, Player{User: User{Id: "p3"}, Score: 500, IsConnected: true})
+	})
+
+	r.EndGame()
+	assert.Equal(t, TieBreaker, r.State)
+	assert.True(t, r.InTieBreaker())
+	assert.Equal(t, []string{"p1", "p2"}, r.FinalRoundState.Players)
+	assert.Equal(t, []string{"p1", "p2"}, r.AllowedToAnswer)
+	assert.NotNil(t, r.FinalRoundState.TimerEndsAt)
+	assert.Empty(t, r.TieBreakers)
+	assert.True(t, slices.ContainsFunc(r.DrainEvents(), func(e GameEvent) bool {
+		return e.Type == TieBreakerStartedEvent
+	}))
+}
+
+func TestEndGame_NoDrawOrNoTieBreakers_EndsGame(t *testing.T) {
+	r := buildRoom(withTieBreakers(1), func(r *Room) { r.Players[0].Score = 1200 })
+	r.EndGame()
+	assert.Equal(t, GameOver, r.State)
+	assert.Len(t, r.TieBreakers, 1)
+
+	r = buildRoom()
+	r.EndGame()
+	assert.Equal(t, GameOver, r.State)
+}
+
+func TestTieBreaker_CorrectAnswerWins(t *testing.T) {
+	r := buildRoom(withTieBreakers(1))
+	r.EndGame()
+
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "42"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "7"))
+	assert.Equal(t, ValidatingFinalRoundAnswers, r.State)
+
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", true))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", false))
+	assert.Equal(t, GameOver, r.State)
+	assert.Equal(t, 1000, r.Players[0].Score, "the tie-breaker does not add to the score")
+	assert.Equal(t, 1000, r.Players[1].Score)
+	assert.Equal(t, map[string]int{"p1": 1}, r.TieBreakerWins)
+	assert.Equal(t, 1, r.CompareStanding("p1", "p2"))
+}
+
+func TestTieBreaker_WinnerRankedInStatsAndRatings(t *testing.T) {
+	r := buildRoom(withTieBreakers(1))
+	r.EndGame()
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "7"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "42"))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", false))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", true))
+
+	stats := NewGameStats(&r, r.DrainEvents())
+	assert.Equal(t, 0, stats[0].GamesWon)
+	assert.Equal(t, 1, stats[1].GamesWon)
+	assert.Equal(t, 1000, stats[1].TotalScore)
+
+	changes := NewRatingChanges(&r, map[string]float64{}, time.Now())
+	assert.Less(t, changes[0].After, changes[0].Before)
+	assert.Greater(t, changes[1].After, changes[1].Before)
+	assert.Equal(t, 1000, changes[1].Score)
+}
+
+func TestTieBreaker_StillDrawn_PlaysNextQuestion(t *testing.T) {
+	r := buildRoom(withTieBreakers(2))
+	r.EndGame()
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "1"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "2"))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", false))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", false))
+
+	assert.Equal(t, TieBreaker, r.State)
+	assert.Empty(t, r.TieBreakers)
+	assert.Empty(t, r.FinalRoundState.PlayersAnswers)
+}
+
+func TestTieBreaker_NotPlayerCannotAnswer(t *testing.T) {
+	r := buildRoom(withTieBreakers(1), func(r *Room) {
+		r.Players = append(r.Players, Player{User: User{Id: "p3"}, Score: 500, IsConnected: true})
+	})
+	r.EndGame()
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.SubmitFinalRoundAnswer("p3", "42"), &ce)
+}
+
+func TestTieBreaker_ReplayListsTieBreakers(t *testing.T) {
+	r := buildRoom(withTieBreakers(1))
+	r.EndGame()
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "42"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "7"))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", true))
+	assert.NoError(t, r.ValidateFinalRoundAnswer("host1", false))
+
+	replay := NewReplay(&r, r.DrainEvents())
+	assert.Len(t, replay.TieBreakers, 1)
+	assert.Equal(t, []string{"p1", "p2"}, replay.TieBreakers[0].Players)
+	assert.Len(t, replay.TieBreakers[0].Verdicts, 2)
+	assert.Nil(t, replay.FinalRound)
+}
+
+// ---- 43. Numeric ----
+
+func buildNumericPack(numeric NumericAnswer) *Pack {
+	pack := buildPack()
+	text := "In what year did Apollo 11 land?"
+	pack.Rounds[0].Categories = append(pack.Rounds[0].Categories, Category{
+		Name: "Numbers",
+		Questions: []Question{
+			{
+				HiddenQuestion: HiddenQuestion{Round: "Round 1", Category: "Numbers", Index: 0, Value: 300},
+				Type:           Numeric,
+				Text:           &text,
+				Answers:        []string{"1969"},
+				Numeric:        &numeric,
+			},
+		},
+	})
+	return pack
+}
+
+func TestParseNumericAnswer(t *testing.T) {
+	v, err := ParseNumericAnswer(" 1 969,5 ")
+	assert.NoError(t, err)
+	assert.Equal(t, 1969.5, v)
+
+	_, err = ParseNumericAnswer("nineteen")
+	var be custerr.BadRequestErr
+	assert.ErrorAs(t, err, &be)
+}
+
+func TestNumeric_SelectLetsEveryoneAnswer(t *testing.T) {
+	pack := buildNumericPack(NumericAnswer{Value: 1969})
+	r := buildRoom(withRound1(pack), func(r *Room) { r.Players[1].Score = 0 })
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Numbers", 0, noopAttachmentUrl))
+	assert.Equal(t, ShowingFinalRoundQuestion, r.State)
+	assert.True(t, r.InNumericQuestion())
+	assert.Equal(t, []string{"p1", "p2"}, r.AllowedToAnswer)
+	assert.NotNil(t, r.FinalRoundState.TimerEndsAt)
+	assert.True(t, NewPlayerRoom(&r, 0, nil).FinalRoundState.Numeric)
+}
+
+func TestNumeric_RejectsNonNumbers(t *testing.T) {
+	pack := buildNumericPack(NumericAnswer{Value: 1969})
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Numbers", 0, noopAttachmentUrl))
+
+	var be custerr.BadRequestErr
+	assert.ErrorAs(t, r.SubmitFinalRoundAnswer("p1", "late sixties"), &be)
+	assert.Contains(t, r.AllowedToAnswer, "p1")
+}
+
+func TestNumeric_ClosestWinsAndQuestionEnds(t *testing.T) {
+	pack := buildNumericPack(NumericAnswer{Value: 1969})
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Numbers", 0, noopAttachmentUrl))
+
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "1965"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "1970"))
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.Nil(t, r.FinalRoundState)
+	assert.Nil(t, r.CurrentQuestion)
+	assert.Equal(t, ptr("p1"), r.CurrentPlayer)
+	assert.Equal(t, 1000, r.Players[0].Score)
+	assert.Equal(t, 1300, r.Players[1].Score)
+}
+
+func TestNumeric_ExactHitsWinWithBonus(t *testing.T) {
+	pack := buildNumericPack(NumericAnswer{Value: 1969, Tolerance: 1, ExactBonus: 50})
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Numbers", 0, noopAttachmentUrl))
+
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "1969"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "1970"))
+	assert.Equal(t, 1350, r.Players[0].Score)
+	assert.Equal(t, 1350, r.Players[1].Score)
+}
+
+func TestNumeric_NoAnswerScoresNothing(t *testing.T) {
+	pack := buildNumericPack(NumericAnswer{Value: 1969})
+	r := buildRoom(withRound1(pack))
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Numbers", 0, noopAttachmentUrl))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "2000"))
+
+	r.EndFinalRoundQuestion()
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.Equal(t, 1300, r.Players[0].Score)
+	assert.Equal(t, 1000, r.Players[1].Score)
+}
+
+func TestNumeric_FinalRoundClosestTakesBetOthersLose(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.State = ShowingFinalRoundQuestion
+		r.Players[0].BetAmount = ptr(200)
+		r.Players[1].BetAmount = ptr(300)
+		r.FinalRoundState = &FinalRoundState{
+			Question:       &FinalRoundQuestion{Numeric: &NumericAnswer{Value: 100}},
+			Players:        []string{"p1", "p2"},
+			PlayersAnswers: make(map[string]string),
+		}
+		r.AllowedToAnswer = []string{"p1", "p2"}
+	})
+
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "90"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "120"))
+	assert.Equal(t, GameOver, r.State)
+	assert.Equal(t, 1200, r.Players[0].Score)
+	assert.Equal(t, 700, r.Players[1].Score)
+}
+
+func TestNumeric_TieBreakerScoredAutomatically(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.TieBreakers = []FinalRoundQuestion{{Numeric: &NumericAnswer{Value: 42}}}
+	})
+	r.EndGame()
+	assert.Equal(t, TieBreaker, r.State)
+
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p1", "40"))
+	assert.NoError(t, r.SubmitFinalRoundAnswer("p2", "45"))
+	assert.Equal(t, GameOver, r.State)
+	assert.Equal(t, 1000, r.Players[0].Score)
+	assert.Equal(t, 1000, r.Players[1].Score)
+	assert.Equal(t, map[string]int{"p1": 1}, r.TieBreakerWins)
+}
+
+// ---- 44. Undo ----
+
+// takeSnapshot round-trips the snapshot through JSON like the room cache does,
+// so later mutations of the room don't leak into it.
+func takeSnapshot(t *testing.T, r Room) RoomSnapshot {
+	data, err := json.Marshal(NewRoomSnapshot(r, 0))
+	assert.NoError(t, err)
+	var snapshot RoomSnapshot
+	assert.NoError(t, json.Unmarshal(data, &snapshot))
+	return snapshot
+}
+
+func TestUndo_RestoresBoardTurnAndScores(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withRound1(pack))
+	snapshot := takeSnapshot(t, r)
+
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 0, noopAttachmentUrl))
+	assert.NoError(t, r.ChangeScore("host1", "p2", 1500))
+	r.Players[0].IsConnected = false
+	r.DrainEvents()
+
+	assert.NoError(t, r.Undo("host1", snapshot))
+	assert.Equal(t, SelectingQuestion, r.State)
+	assert.Equal(t, ptr("p1"), r.CurrentPlayer)
+	assert.Nil(t, r.CurrentQuestion)
+	assert.False(t, r.CurrentRoundQuestions.findCategory("Geography").Questions[0].HasBeenPlayed)
+	assert.Equal(t, 1000, r.Players[1].Score)
+	assert.False(t, r.Players[0].IsConnected, "connections are kept as they are now")
+
+	events := r.DrainEvents()
+	assert.Equal(t, UndoneEvent, events[0].Type)
+	assert.True(t, slices.ContainsFunc(events, func(e GameEvent) bool {
+		return e.Type == ScoreChangedEvent && *e.PlayerId == "p2" && *e.Score == 1000
+	}))
+}
+
+func TestUndo_ResumesTimersWithTimeLeft(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	snapshot := takeSnapshot(t, r)
+	snapshot.TakenAt = snapshot.TakenAt.Add(-3 * time.Second)
+	timerEndsAt := r.CurrentQuestion.TimerEndsAt
+
+	assert.NoError(t, r.Undo("host1", snapshot))
+	assert.WithinDuration(t, timerEndsAt.Add(3*time.Second), r.CurrentQuestion.TimerEndsAt, 100*time.Millisecond)
+}
+
+func TestUndo_KeepsCurrentPause(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200))
+	snapshot := takeSnapshot(t, r)
+	assert.NoError(t, r.Pause("host1"))
+	pausedAt := *r.PausedState.PausedAt
+
+	assert.NoError(t, r.Undo("host1", snapshot))
+	assert.True(t, r.PausedState.Paused)
+	assert.Equal(t, pausedAt, *r.PausedState.PausedAt)
+}
+
+func TestUndo_KeepsBansAndNewPlayers(t *testing.T) {
+	r := buildRoom()
+	snapshot := takeSnapshot(t, r)
+	assert.NoError(t, r.BanPlayer("host1", "p2"))
+	r.Players = append(r.Players, Player{User: User{Id: "p3"}, IsConnected: true})
+
+	assert.NoError(t, r.Undo("host1", snapshot))
+	assert.Equal(t, -1, r.UsersPlayerIndex("p2"))
+	assert.NotEqual(t, -1, r.UsersPlayerIndex("p3"))
+	assert.True(t, r.IsUserBanned("p2"))
+}
+
+func TestUndo_Rejected(t *testing.T) {
+	r := buildRoom()
+	snapshot := takeSnapshot(t, r)
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.Undo("p1", snapshot), &fe)
+
+	r.State = GameOver
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.Undo("host1", snapshot), &ce)
+}
+
+func TestUndo_RejectedInAIHostRoom(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200), func(r *Room) {
+		r.Options.AIHost = true
+		r.Players = append(r.Players, Player{User: User{Id: "host1"}, IsConnected: true})
+	})
+	snapshot := takeSnapshot(t, r)
+	assert.NoError(t, r.ChangeScore("host1", "host1", -200))
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.Undo("host1", snapshot), &ce)
+	assert.Equal(t, -200, r.Players[2].Score, "the host can not take back their own penalty")
+}
+
+func TestUndo_VerdictLeftOutOfReplayAndStats(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(func(r *Room) { r.State = WaitingForStart })
+	r.StartGame(pack)
+	r.CurrentPlayer = ptr("p1")
+	assert.NoError(t, r.SelectQuestion("p1", pack, "Geography", 0, noopAttachmentUrl))
+	r.State = ShowingQuestion
+	assert.NoError(t, r.SubmitAnswer("p1"))
+	log := r.DrainEvents()
+
+	snapshot := takeSnapshot(t, r)
+	snapshot.LogLength = len(log)
+	assert.NoError(t, r.ValidateAnswer("host1", false))
+	log = append(log, r.DrainEvents()...)
+	assert.NoError(t, r.Undo("host1", snapshot))
+	log = append(log, r.DrainEvents()...)
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+	log = append(log, r.DrainEvents()...)
+
+	replay := NewReplay(&r, log)
+	verdicts := replay.Rounds[0].Questions[0].Verdicts
+	assert.Len(t, verdicts, 1)
+	assert.True(t, verdicts[0].IsCorrect)
+	assert.Equal(t, 1100, replay.Steps[len(replay.Steps)-1].Scores["p1"])
+
+	state, err := NewReplayState(log, len(replay.Steps)-1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1100, state.Scores["p1"])
+
+	p1 := NewGameStats(&r, log)[0]
+	assert.Equal(t, 1, p1.CorrectAnswers)
+	assert.Equal(t, 0, p1.IncorrectAnswers)
+	assert.Equal(t, 1, p1.Buzzes)
+}
+
+// ---- 45. Moderator transfer ----
+
+func TestTransferModerator_ToPlayer_VacatesSlot(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.CurrentPlayer = ptr("p1") })
+
+	assert.NoError(t, r.TransferModerator("host1", User{Id: "p1"}))
+	assert.True(t, r.IsUserModerator("p1"))
+	assert.True(t, r.Moderator.IsConnected)
+	assert.Equal(t, -1, r.UsersPlayerIndex("p1"))
+	assert.False(t, r.IsUserIn("host1"), "the previous moderator becomes a spectator")
+	assert.Equal(t, ptr("p2"), r.CurrentPlayer, "the board goes to another player")
+
+	events := r.DrainEvents()
+	assert.Equal(t, ModeratorChangedEvent, events[len(events)-1].Type)
+	assert.Equal(t, ptr("p1"), events[len(events)-1].PlayerId)
+}
+
+func TestTransferModerator_ToSpectator(t *testing.T) {
+	r := buildRoom()
+
+	assert.NoError(t, r.TransferModerator("host1", User{Id: "s1", Name: "Spectator"}))
+	assert.Equal(t, "Spectator", r.Moderator.Name)
+	assert.Len(t, r.Players, 2)
+}
+
+func TestTransferModerator_AIHostKeepsEveryonePlaying(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Options.AIHost = true
+		r.Options.MaxPlayers = 4
+		r.Players = append(r.Players, Player{User: User{Id: "host1"}, IsConnected: true})
+	})
+
+	assert.NoError(t, r.TransferModerator("host1", User{Id: "s1"}))
+	assert.True(t, r.IsUserModerator("s1"))
+	assert.NotEqual(t, -1, r.UsersPlayerIndex("s1"), "the AI mode moderator plays too")
+	assert.NotEqual(t, -1, r.UsersPlayerIndex("host1"))
+}
+
+func TestTransferModerator_Rejected(t *testing.T) {
+	var fe custerr.ForbiddenErr
+	var ce custerr.ConflictErr
+	var be custerr.BadRequestErr
+
+	r := buildRoom()
+	assert.ErrorAs(t, r.TransferModerator("p1", User{Id: "p2"}), &fe)
+	assert.ErrorAs(t, r.TransferModerator("host1", User{Id: "host1"}), &be)
+
+	r.Players[0].IsConnected = false
+	assert.ErrorAs(t, r.TransferModerator("host1", User{Id: "p1"}), &ce)
+
+	r = buildRoom(withAnsweringP1(200))
+	assert.ErrorAs(t, r.TransferModerator("host1", User{Id: "p1"}), &ce)
+	assert.True(t, r.IsUserModerator("host1"))
+
+	r = buildRoom(func(r *Room) { r.Options.AIHost = true; r.Options.MaxPlayers = 2 })
+	assert.ErrorAs(t, r.TransferModerator("host1", User{Id: "s1"}), &ce)
+}
+
+func TestFallBackModerator_PromotesConnectedPlayer(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.Moderator.IsConnected = false; r.Players[0].IsConnected = false })
+
+	assert.NoError(t, r.FallBackModerator(false))
+	assert.True(t, r.IsUserModerator("p2"))
+	assert.Equal(t, -1, r.UsersPlayerIndex("p2"))
+	assert.False(t, r.Options.AIHost)
+}
+
+func TestFallBackModerator_SwitchesToAIHost(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.Moderator.IsConnected = false })
+
+	assert.NoError(t, r.FallBackModerator(true))
+	assert.True(t, r.Options.AIHost)
+	assert.True(t, r.IsUserModerator("p1"))
+	assert.Len(t, r.Players, 2, "players keep playing under the AI host")
+}
+
+func TestFallBackModerator_Rejected(t *testing.T) {
+	var ce custerr.ConflictErr
+
+	r := buildRoom()
+	assert.ErrorAs(t, r.FallBackModerator(true), &ce)
+
+	r = buildRoom(func(r *Room) {
+		r.Moderator.IsConnected = false
+		r.Players[0].IsConnected = false
+		r.Players[1].IsConnected = false
+	})
+	assert.ErrorAs(t, r.FallBackModerator(false), &ce)
+}
+
+func TestUndo_KeepsTransferredModerator(t *testing.T) {
+	r := buildRoom()
+	snapshot := takeSnapshot(t, r)
+	assert.NoError(t, r.TransferModerator("host1", User{Id: "p1"}))
+
+	assert.NoError(t, r.Undo("p1", snapshot))
+	assert.True(t, r.IsUserModerator("p1"))
+	assert.Equal(t, -1, r.UsersPlayerIndex("p1"))
+}
+
+// ---- 46. Disconnect policies and selection timeout ----
+
+func withPauseOnDisconnect(gracePeriod int) func(*Room) {
+	return func(r *Room) {
+		r.Options.PauseOnDisconnect = true
+		r.Options.DisconnectGracePeriod = gracePeriod
+	}
+}
+
+func TestPauseForDisconnect_PausesForActivePlayerOrModerator(t *testing.T) {
+	r := buildRoom(withPauseOnDisconnect(0), func(r *Room) { r.CurrentPlayer = ptr("p1") })
+	assert.False(t, r.PauseForDisconnect("p2"), "p2 is not holding the game up")
+	assert.False(t, r.PausedState.Paused)
+
+	assert.True(t, r.PauseForDisconnect("p1"))
+	assert.True(t, r.PausedState.Paused)
+	assert.Equal(t, ptr("p1"), r.PausedState.AwaitingUserId)
+
+	r = buildRoom(withPauseOnDisconnect(0), func(r *Room) { r.CurrentPlayer = ptr("p1") })
+	assert.True(t, r.PauseForDisconnect("host1"))
+
+	r = buildRoom(withAnsweringP1(200), withPauseOnDisconnect(0))
+	assert.True(t, r.PauseForDisconnect("p1"))
+}
+
+func TestPauseForDisconnect_OffByDefault(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.CurrentPlayer = ptr("p1") })
+	assert.False(t, r.PauseForDisconnect("p1"))
+	assert.False(t, r.PausedState.Paused)
+}
+
+func TestResumeOnReconnect(t *testing.T) {
+	r := buildRoom(withShowingQuestion(200), withPauseOnDisconnect(0), func(r *Room) { r.Players[0].IsConnected = false })
+	assert.True(t, r.PauseForDisconnect("host1"))
+	timerEndsAt := r.CurrentQuestion.TimerEndsAt
+
+	assert.False(t, r.ResumeOnReconnect("p1"))
+	assert.True(t, r.ResumeOnReconnect("host1"))
+	assert.False(t, r.PausedState.Paused)
+	assert.Nil(t, r.PausedState.AwaitingUserId)
+	assert.False(t, r.CurrentQuestion.TimerEndsAt.Before(timerEndsAt))
+}
+
+func TestHandOverTurn_PassesBoardToConnectedPlayer(t *testing.T) {
+	r := buildRoom(withPauseOnDisconnect(30), func(r *Room) {
+		r.CurrentPlayer = ptr("p1")
+		r.Players[0].IsConnected = false
+		r.Players = append(r.Players, Player{User: User{Id: "p3"}})
+	})
+	assert.True(t, r.PauseForDisconnect("p1"))
+	r.DrainEvents()
+
+	r.HandOverTurn()
+	assert.False(t, r.PausedState.Paused)
+	assert.Equal(t, ptr("p2"), r.CurrentPlayer, "only connected players take over")
+	events := r.DrainEvents()
+	assert.Equal(t, TurnHandedOverEvent, events[len(events)-1].Type)
+}
+
+func TestHandOverTurn_OtherTurnsRunOutOnTheirOwn(t *testing.T) {
+	r := buildRoom(withAnsweringP1(200), withPauseOnDisconnect(30))
+	assert.True(t, r.PauseForDisconnect("p1"))
+
+	r.HandOverTurn()
+	assert.False(t, r.PausedState.Paused)
+	assert.Equal(t, Answering, r.State)
+	assert.Equal(t, "p1", r.AnsweringPlayer.Id)
+}
+
+func TestSelectionTimeout_StartsWithSelectionAndStopsOnSelect(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withRound1(pack), func(r *Room) { r.Options.SelectionTimeout = 15 })
+	r.EndQuestion()
+	assert.NotNil(t, r.SelectionEndsAt)
+	assert.WithinDuration(t, time.Now().Add(15*time.Second), *r.SelectionEndsAt, time.Second)
+
+	assert.NoError(t, r.HoldSelection("p1", "Geography", 0))
+	assert.Nil(t, r.SelectionEndsAt)
+
+	r = buildRoom(withRound1(pack))
+	r.EndQuestion()
+	assert.Nil(t, r.SelectionEndsAt, "no timeout unless the room sets one")
+}
+
+func TestHoldSelection_Rejected(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withRound1(pack), func(r *Room) { r.Options.SelectionTimeout = 15 })
+	r.startSelectionTimer()
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.HoldSelection("p2", "Geography", 0), &fe)
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.HoldSelection("p1", "Geography", 7), &ce)
+	assert.NotNil(t, r.SelectionEndsAt)
+}
+
+func TestHoldRandomQuestion_PicksUnplayedQuestion(t *testing.T) {
+	pack := buildPack()
+	r := buildRoom(withRound1(pack))
+	for i := range r.CurrentRoundQuestions {
+		for j := range r.CurrentRoundQuestions[i].Questions {
+			r.CurrentRoundQuestions[i].Questions[j].HasBeenPlayed = true
+		}
+	}
+	r.CurrentRoundQuestions.findCategory("Science").Questions[0].HasBeenPlayed = false
+
+	category, index, err := r.HoldRandomQuestion()
+	assert.NoError(t, err)
+	assert.Equal(t, "Science", category)
+	assert.Equal(t, 0, index)
+
+	assert.NoError(t, r.SelectQuestionAuto(pack, category, index, noopAttachmentUrl))
+	assert.Equal(t, SYSTEM, r.DrainEvents()[0].ActorId)
+}
+
+func TestSelectionTimeout_ShiftsOnPause(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.Options.SelectionTimeout = 15 })
+	r.startSelectionTimer()
+	selectionEndsAt := *r.SelectionEndsAt
+
+	r.shiftTimers(5 * time.Second)
+	assert.Equal(t, selectionEndsAt.Add(5*time.Second), *r.SelectionEndsAt)
+}
+
+// ---- 47. Late join and seat replacement ----
+
+func TestAddPlayer_LateJoinScore(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.Players[1].Score = 500 })
+	r.AddPlayer(User{Id: "p3"})
+	assert.Equal(t, 0, r.Players[2].Score)
+	events := r.DrainEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, PlayerJoinedEvent, events[0].Type)
+
+	r = buildRoom(func(r *Room) {
+		r.Players[1].Score = 500
+		r.Options.LateJoinScore = AverageScore
+	})
+	r.AddPlayer(User{Id: "p3"})
+	assert.Equal(t, 750, r.Players[2].Score)
+
+	r = buildRoom(func(r *Room) {
+		r.Players[1].Score = 500
+		r.Options.LateJoinScore = LowestScore
+	})
+	r.AddPlayer(User{Id: "p3"})
+	assert.Equal(t, 500, r.Players[2].Score)
+
+	r = buildRoom(func(r *Room) {
+		r.State = WaitingForStart
+		r.Options.LateJoinScore = AverageScore
+	})
+	r.AddPlayer(User{Id: "p3"})
+	assert.Equal(t, 0, r.Players[2].Score)
+	assert.Empty(t, r.DrainEvents(), "joining before the start is not logged")
+}
+
+func TestAddPlayer_TeamMode_JoinsSmallestTeam(t *testing.T) {
+	r := buildTeamRoom(func(r *Room) {
+		r.Options.LateJoinScore = AverageScore
+		r.Options.MaxPlayers = 6
+		r.Teams[0].Members = []string{"p1", "p2", "p4"}
+		r.Teams[0].Score = 600
+		r.Teams[1].Members = []string{"p3"}
+		r.Teams[1].Score = 300
+	})
+	r.AddPlayer(User{Id: "p5"})
+	assert.Equal(t, []string{"p3", "p5"}, r.Teams[1].Members)
+	assert.Equal(t, 0, r.Players[4].Score, "a late joiner's contribution starts from zero")
+	assert.Equal(t, 300, r.ScoreOf("p5"))
+	events := r.DrainEvents()
+	assert.Equal(t, ptr("team-2"), events[0].TeamId)
+
+	assert.NoError(t, r.PromoteSpectator("host1", User{Id: "s1"}))
+	assert.Equal(t, "team-2", r.TeamOf("s1").Id)
+
+	r.State = ShowingQuestion
+	r.CurrentQuestion = &CurrentQuestion{Question: Question{HiddenQuestion: HiddenQuestion{Value: 100}}}
+	r.AllowedToAnswer = r.buzzers()
+	assert.NoError(t, r.SubmitAnswer("p5"))
+	assert.NoError(t, r.ValidateAnswer("host1", true))
+	assert.Equal(t, 400, r.Teams[1].Score)
+}
+
+func TestClaimSeat_Rejected(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Players[0].IsConnected = false
+		r.BanList = []string{"banned"}
+	})
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.ClaimSeat(User{Id: "p2"}, "p1"), &ce)
+	assert.ErrorAs(t, r.ClaimSeat(User{Id: "s1"}, "p2"), &ce, "p2 is still connected")
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.ClaimSeat(User{Id: "banned"}, "p1"), &fe)
+	var nfe custerr.NotFoundErr
+	assert.ErrorAs(t, r.ClaimSeat(User{Id: "s1"}, "nobody"), &nfe)
+
+	assert.NoError(t, r.ClaimSeat(User{Id: "s1"}, "p1"))
+	assert.ErrorAs(t, r.ClaimSeat(User{Id: "s1"}, "p1"), &ce)
+
+	r = buildRoom(func(r *Room) {
+		r.State = WaitingForStart
+		r.Players[0].IsConnected = false
+	})
+	assert.ErrorAs(t, r.ClaimSeat(User{Id: "s1"}, "p1"), &ce)
+}
+
+func TestResolveSeatClaim_ApproveHandsOverSeat(t *testing.T) {
+	r := buildRoom(withAnsweringP1(200), func(r *Room) {
+		r.Players[0].IsConnected = false
+		r.Players[0].Score = 1300
+		r.CurrentPlayer = ptr("p1")
+		r.AllowedToAnswer = []string{"p1", "p2"}
+	})
+	assert.NoError(t, r.ClaimSeat(User{Id: "s1", Name: "Sam"}, "p1"))
+	assert.NoError(t, r.ClaimSeat(User{Id: "s2"}, "p1"))
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.ResolveSeatClaim("p2", "s1", true), &fe)
+
+	assert.NoError(t, r.ResolveSeatClaim("host1", "s1", true))
+	assert.Equal(t, -1, r.UsersPlayerIndex("p1"))
+	idx := r.UsersPlayerIndex("s1")
+	assert.Equal(t, 0, idx)
+	assert.Equal(t, 1300, r.Players[idx].Score)
+	assert.True(t, r.Players[idx].IsConnected)
+	assert.Equal(t, "Sam", r.Players[idx].Name)
+	assert.Equal(t, ptr("s1"), r.CurrentPlayer)
+	assert.Equal(t, "s1", r.AnsweringPlayer.Id)
+	assert.Equal(t, []string{"s1", "p2"}, r.AllowedToAnswer)
+	assert.Empty(t, r.SeatClaims, "other claims for the seat are dropped")
+
+	events := r.DrainEvents()
+	assert.Equal(t, SeatClaimedEvent, events[len(events)-1].Type)
+	assert.Equal(t, ptr("p1"), events[len(events)-1].PreviousPlayerId)
+}
+
+func TestResolveSeatClaim_Reject(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.Players[0].IsConnected = false })
+	assert.NoError(t, r.ClaimSeat(User{Id: "s1"}, "p1"))
+
+	assert.NoError(t, r.ResolveSeatClaim("host1", "s1", false))
+	assert.Empty(t, r.SeatClaims)
+	assert.Equal(t, 0, r.UsersPlayerIndex("p1"))
+
+	var nfe custerr.NotFoundErr
+	assert.ErrorAs(t, r.ResolveSeatClaim("host1", "s1", true), &nfe)
+}
+
+func TestResolveSeatClaim_SeatTakenBack(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.Players[0].IsConnected = false })
+	assert.NoError(t, r.ClaimSeat(User{Id: "s1"}, "p1"))
+	r.Players[0].IsConnected = true
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.ResolveSeatClaim("host1", "s1", true), &ce)
+}
+
+func TestResolveSeatClaim_ClaimantJoinedMeanwhile(t *testing.T) {
+	r := buildRoom(func(r *Room) { r.Players[0].IsConnected = false })
+	assert.NoError(t, r.ClaimSeat(User{Id: "s1"}, "p1"))
+
+	r.AddPlayer(User{Id: "s1"})
+	assert.Empty(t, r.SeatClaims, "joining drops the claim")
+
+	r.SeatClaims = []SeatClaim{{User: User{Id: "s1"}, PlayerId: "p1"}}
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.ResolveSeatClaim("host1", "s1", true), &ce)
+	assert.Equal(t, 0, r.UsersPlayerIndex("p1"))
+	assert.Len(t, r.Players, 3)
+}
+
+func TestResolveSeatClaim_ResumesPausedGame(t *testing.T) {
+	r := buildRoom(withPauseOnDisconnect(0), func(r *Room) { r.CurrentPlayer = ptr("p1") })
+	r.Players[0].IsConnected = false
+	assert.True(t, r.PauseForDisconnect("p1"))
+	assert.NoError(t, r.ClaimSeat(User{Id: "s1"}, "p1"))
+
+	assert.NoError(t, r.ResolveSeatClaim("host1", "s1", true))
+	assert.False(t, r.PausedState.Paused)
+}
+
+func TestReplay_SeatClaimMovesScore(t *testing.T) {
+	events := []GameEvent{
+		{Type: PlayerJoinedEvent, PlayerId: ptr("p1"), Score: ptr(400)},
+		{Type: SeatClaimedEvent, PlayerId: ptr("s1"), PreviousPlayerId: ptr("p1")},
+	}
+	state, err := NewReplayState(events, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"s1": 400}, state.Scores)
+}
+
+// ---- 48. Kick, timed ban and unban ----
+
+func TestKickPlayer_RemovesWithoutBan(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.CurrentPlayer = ptr("p2")
+		r.AllowedToAnswer = []string{"p1", "p2"}
+	})
+	assert.NoError(t, r.KickPlayer("host1", "p2"))
+	assert.Equal(t, -1, r.UsersPlayerIndex("p2"))
+	assert.False(t, r.IsUserBanned("p2"))
+	assert.Equal(t, []string{"p1"}, r.AllowedToAnswer)
+	assert.Equal(t, ptr("p1"), r.CurrentPlayer)
+	assert.Equal(t, PlayerKickedEvent, r.DrainEvents()[0].Type)
+}
+
+func TestKickPlayer_Rejected(t *testing.T) {
+	r := buildRoom()
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.KickPlayer("p1", "p2"), &fe)
+	var bre custerr.BadRequestErr
+	assert.ErrorAs(t, r.KickPlayer("host1", "host1"), &bre)
+	var nfe custerr.NotFoundErr
+	assert.ErrorAs(t, r.KickPlayer("host1", "spectator"), &nfe)
+}
+
+func TestBanPlayerUntil_Expires(t *testing.T) {
+	r := buildRoom()
+	assert.NoError(t, r.BanPlayerUntil("host1", "p2", time.Now().Add(time.Minute)))
+	assert.Equal(t, -1, r.UsersPlayerIndex("p2"))
+	assert.True(t, r.IsUserBanned("p2"))
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.BanPlayer("host1", "p2"), &ce)
+
+	r.BanExpiresAt["p2"] = time.Now().Add(-time.Second)
+	assert.False(t, r.IsUserBanned("p2"))
+	assert.NoError(t, r.BanPlayer("host1", "p2"), "an expired ban can be replaced")
+	assert.True(t, r.IsUserBanned("p2"))
+	assert.Equal(t, []string{"p2"}, r.BanList)
+	assert.NotContains(t, r.BanExpiresAt, "p2")
+
+	var bre custerr.BadRequestErr
+	assert.ErrorAs(t, r.BanPlayerUntil("host1", "p1", time.Now().Add(-time.Minute)), &bre)
+}
+
+func TestBanPlayer_Spectator(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Players[0].IsConnected = false
+		r.SeatClaims = []SeatClaim{{User: User{Id: "s1"}, PlayerId: "p1"}}
+	})
+	assert.NoError(t, r.BanPlayer("host1", "s1"))
+	assert.True(t, r.IsUserBanned("s1"))
+	assert.Empty(t, r.SeatClaims)
+	assert.Len(t, r.Players, 2)
+}
+
+func TestUnbanPlayer(t *testing.T) {
+	r := buildRoom()
+	assert.NoError(t, r.BanPlayerUntil("host1", "p2", time.Now().Add(time.Minute)))
+
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.UnbanPlayer("p1", "p2"), &fe)
+	assert.NoError(t, r.UnbanPlayer("host1", "p2"))
+	assert.False(t, r.IsUserBanned("p2"))
+	assert.Empty(t, r.BanList)
+	assert.Empty(t, r.BanExpiresAt)
+
+	var nfe custerr.NotFoundErr
+	assert.ErrorAs(t, r.UnbanPlayer("host1", "p2"), &nfe)
+}
+
+// ---- 49. Spectator promotion ----
+
+func TestPromoteSpectator_TakesFreeSlot(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Options.MaxPlayers = 3
+		r.Options.LateJoinScore = LowestScore
+		r.Players[1].Score = 400
+		r.SeatClaims = []SeatClaim{{User: User{Id: "s1"}, PlayerId: "p2"}}
+	})
+	assert.NoError(t, r.PromoteSpectator("host1", User{Id: "s1"}))
+	idx := r.UsersPlayerIndex("s1")
+	assert.Equal(t, 2, idx)
+	assert.True(t, r.Players[idx].IsConnected)
+	assert.Equal(t, 400, r.Players[idx].Score)
+	assert.Empty(t, r.SeatClaims)
+}
+
+func TestPromoteSpectator_Rejected(t *testing.T) {
+	r := buildRoom(func(r *Room) {
+		r.Options.MaxPlayers = 3
+		r.BanList = []string{"banned"}
+	})
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.PromoteSpectator("p1", User{Id: "s1"}), &fe)
+	assert.ErrorAs(t, r.PromoteSpectator("host1", User{Id: "banned"}), &fe)
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.PromoteSpectator("host1", User{Id: "p1"}), &ce)
+
+	r.Options.MaxPlayers = 2
+	assert.ErrorAs(t, r.PromoteSpectator("host1", User{Id: "s1"}), &ce)
+	assert.Len(t, r.Players, 2)
+}
+
+func TestGetProjection_ListsSpectators(t *testing.T) {
+	r := buildRoom()
+	spectators := []User{{Id: "s1", Name: "Sam"}}
+	assert.Equal(t, spectators, r.GetProjection("p1", 1, spectators).(RoomPlayer).Spectators)
+	assert.Equal(t, spectators, r.GetProjection("host1", 1, spectators).(RoomModerator).Spectators)
+}
+
+// ---- 50. Chat mute ----
+
+func TestMutePlayer(t *testing.T) {
+	r := buildRoom()
+	assert.NoError(t, r.MutePlayer("host1", "p1"))
+	assert.True(t, r.IsUserMuted("p1"))
+	assert.False(t, r.IsUserMuted("p2"))
+
+	var ce custerr.ConflictErr
+	assert.ErrorAs(t, r.MutePlayer("host1", "p1"), &ce)
+
+	assert.NoError(t, r.UnmutePlayer("host1", "p1"))
+	assert.False(t, r.IsUserMuted("p1"))
+	var nfe custerr.NotFoundErr
+	assert.ErrorAs(t, r.UnmutePlayer("host1", "p1"), &nfe)
+}
+
+func TestMutePlayer_Rejected(t *testing.T) {
+	r := buildRoom()
+	var fe custerr.ForbiddenErr
+	assert.ErrorAs(t, r.MutePlayer("p1", "p2"), &fe)
+	assert.ErrorAs(t, r.UnmutePlayer("p1", "p2"), &fe)
+	var bre custerr.BadRequestErr
+	assert.ErrorAs(t, r.MutePlayer("host1", "host1"), &bre)
+	assert.Empty(t, r.MutedUsers)
+}
+
+// ---- 51. Chat history ----
+
+func TestChatVisibleTo_HidesSpectatorChatForThoseWhoHideIt(t *testing.T) {
+	r := buildRoom()
+	chat := []ChatMessage{
+		{Event: Chat, Text: "hi"},
+		{Event: SpectatorChat, Text: "go p1!"},
+	}
+	r.SetSpectatorChatHidden("p1", true)
+
+	assert.Len(t, r.ChatVisibleTo("p1", chat), 1)
+	assert.Len(t, r.ChatVisibleTo("p2", chat), 2)
+	assert.Len(t, chat, 2, "the history itself is left as it is")
+
+	r.SetSpectatorChatHidden("p1", true)
+	assert.Equal(t, []string{"p1"}, r.SpectatorChatHiddenBy)
+	r.SetSpectatorChatHidden("p1", false)
+	assert.Len(t, r.ChatVisibleTo("p1", chat), 2)
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"slices"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+type LateJoinScore string
+
+const (
+	ZeroScore    LateJoinScore = "zero"
+	AverageScore LateJoinScore = "average"
+	LowestScore  LateJoinScore = "lowest"
+)
+
+// SeatClaim is a spectator's request to take over a disconnected player's
+// seat, waiting for the moderator to approve it.
+type SeatClaim struct {
+	User     `bson:"inline"`
+	PlayerId string `json:"playerId" bson:"playerId"`
+}
+
+// AddPlayer seats a user who joins the room, dropping any seat they claimed
+// as a spectator. Joining a running game starts
+// them with the score the room's late join policy gives; in team mode they
+// join the smallest team instead and play with its score.
+func (r *Room) AddPlayer(user User) {
+	r.SeatClaims = slices.DeleteFunc(r.SeatClaims, func(c SeatClaim) bool { return c.Id == user.Id })
+	player := Player{User: user}
+	if r.State == WaitingForStart || r.State == GameOver {
+		r.Players = append(r.Players, player)
+		return
+	}
+	event := GameEvent{Type: PlayerJoinedEvent, ActorId: user.Id, PlayerId: &user.Id}
+	if r.IsTeamMode() {
+		team := r.addToTeam(user.Id, r.smallestTeamIndex())
+		event.TeamId = &team.Id
+	} else {
+		player.Score = r.lateJoinScore()
+	}
+	r.Players = append(r.Players, player)
+	event.Score = &player.Score
+	r.record(event)
+}
+
+func (r *Room) lateJoinScore() int {
+	if len(r.Players) == 0 {
+		return 0
+	}
+	switch r.Options.LateJoinScore {
+	case AverageScore:
+		total := 0
+		for _, p := range r.Players {
+			total += p.Score
+		}
+		return total / len(r.Players)
+	case LowestScore:
+		return slices.MinFunc(r.Players, func(a, b Player) int {
+			return a.Score - b.Score
+		}).Score
+	}
+	return 0
+}
+
+// PromoteSpectator lets the moderator give a free player slot to a spectator.
+// A spectator promoted mid-game starts with the late join score.
+func (r *Room) PromoteSpectator(userId string, spectator User) error {
+	if !r.IsUserModerator(userId) {
+		return custerr.NewForbiddenErr("only moderator can promote spectators")
+	}
+	if r.IsUserIn(spectator.Id) {
+		return custerr.NewConflictErr("user already takes part in the game")
+	}
+	if r.IsUserBanned(spectator.Id) {
+		return custerr.NewForbiddenErr("user is banned from this room")
+	}
+	if r.State == GameOver {
+		return custerr.NewConflictErr("the game is over")
+	}
+	if len(r.Players) >= r.Options.MaxPlayers {
+		return custerr.NewConflictErr("the room is already full")
+	}
+	r.AddPlayer(spectator)
+	r.Players[len(r.Players)-1].IsConnected = true
+	return nil
+}
+
+func (r *Room) ClaimSeat(user User, playerId string) error {
+	if r.IsUserIn(user.Id) {
+		return custerr.NewConflictErr("you already take part in the game")
+	}
+	if r.IsUserBanned(user.Id) {
+		return custerr.NewForbiddenErr("you were banned from this room")
+	}
+	if r.State == WaitingForStart || r.State == GameOver {
+		return custerr.NewConflictErr("seats can only be claimed in a running game")
+	}
+	playerIndex := r.UsersPlayerIndex(playerId)
+	if playerIndex == -1 {
+		return custerr.NewNotFoundErr("player not found")
+	}
+	if r.Players[playerIndex].IsConnected {
+		return custerr.NewConflictErr("player is still connected")
+	}
+	if slices.ContainsFunc(r.SeatClaims, func(c SeatClaim) bool { return c.Id == user.Id }) {
+		return custerr.NewConflictErr("you have already claimed a seat")
+	}
+	r.SeatClaims = append(r.SeatClaims, SeatClaim{User: user, PlayerId: playerId})
+	return nil
+}
+
+// ResolveSeatClaim approves or rejects a seat claim. An approved claimant
+// inherits the seat with its score, team and whatever turn it holds.
+func (r *Room) ResolveSeatClaim(userId, claimantId string, approve bool) error {
+	if !r.IsUserModerator(userId) {
+		return custerr.NewForbiddenErr("only moderator can resolve seat claims")
+	}
+	claimIndex := slices.IndexFunc(r.SeatClaims, func(c SeatClaim) bool { return c.Id == claimantId })
+	if claimIndex == -1 {
+		return custerr.NewNotFoundErr("seat claim not found")
+	}
+	claim := r.SeatClaims[claimIndex]
+	r.SeatClaims = slices.Delete(r.SeatClaims, claimIndex, claimIndex+1)
+	if !approve {
+		return nil
+	}
+	if r.IsUserIn(claim.Id) {
+		return custerr.NewConflictErr("the claimant already takes part in the game")
+	}
+
+	playerIndex := r.UsersPlayerIndex(claim.PlayerId)
+	if playerIndex == -1 || r.Players[playerIndex].IsConnected {
+		return custerr.NewConflictErr("the seat is no longer free")
+	}
+	r.replacePlayerId(claim.PlayerId, claim.Id)
+	r.Players[playerIndex].User = claim.User
+	r.Players[playerIndex].IsConnected = true
+	r.SeatClaims = slices.DeleteFunc(r.SeatClaims, func(c SeatClaim) bool { return c.PlayerId == claim.PlayerId })
+	r.record(GameEvent{Type: SeatClaimedEvent, ActorId: userId, PlayerId: &claim.Id, PreviousPlayerId: &claim.PlayerId})
+	r.ResumeOnReconnect(claim.Id)
+	return nil
+}
+
+// replacePlayerId hands everything the game keeps for a player over to
+// another user.
+func (r *Room) replacePlayerId(oldId, newId string) {
+	replace := func(id *string) {
+		if id != nil && *id == oldId {
+			*id = newId
+		}
+	}
+	replaceAll := func(ids []string) {
+		for i := range ids {
+			replace(&ids[i])
+		}
+	}
+
+	replace(r.CurrentPlayer)
+	replaceAll(r.AllowedToAnswer)
+	replace(r.PausedState.AwaitingUserId)
+	if r.AnsweringPlayer != nil {
+		replace(&r.AnsweringPlayer.Id)
+	}
+	for i := range r.Teams {
+		replace(r.Teams[i].Captain)
+		replaceAll(r.Teams[i].Members)
+	}
+	if q := r.CurrentQuestion; q != nil {
+		for i := range q.Buzzes {
+			replace(&q.Buzzes[i].PlayerId)
+		}
+		if lockout, ok := q.Lockouts[oldId]; ok {
+			delete(q.Lockouts, oldId)
+			q.Lockouts[newId] = lockout
+		}
+		if q.Auction != nil {
+			replace(q.Auction.Bidder)
+			replace(&q.Auction.SelectedBy)
+			replaceAll(q.Auction.Passed)
+		}
+	}
+	if s := r.FinalRoundState; s != nil {
+		replaceAll(s.Players)
+		replace(s.SelectedBy)
+		if answer, ok := s.PlayersAnswers[oldId]; ok {
+			delete(s.PlayersAnswers, oldId)
+			s.PlayersAnswers[newId] = answer
+		}
+		if verdict, ok := s.SystemVerdicts[oldId]; ok {
+			delete(s.SystemVerdicts, oldId)
+			s.SystemVerdicts[newId] = verdict
+		}
+	}
+}
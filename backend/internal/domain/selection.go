@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// startSelectionTimer gives the selecting side SelectionTimeout seconds to
+// pick a question before one is picked for them.
+func (r *Room) startSelectionTimer() {
+	r.SelectionEndsAt = nil
+	if r.Options.SelectionTimeout > 0 {
+		selectionEndsAt := time.Now().Add(time.Duration(r.Options.SelectionTimeout) * time.Second)
+		r.SelectionEndsAt = &selectionEndsAt
+	}
+}
+
+// HoldSelection stops the selection timer once a question is picked, so it
+// can't pick another one while the picked question is being shown.
+func (r *Room) HoldSelection(userId string, category string, index int) error {
+	if err := r.canSelectQuestion(userId); err != nil {
+		return err
+	}
+	catQuestions := r.CurrentRoundQuestions.findCategory(category)
+	if catQuestions == nil || index < 0 || index >= len(catQuestions.Questions) || catQuestions.Questions[index].HasBeenPlayed {
+		return custerr.NewConflictErr("question has already been played")
+	}
+	r.SelectionEndsAt = nil
+	return nil
+}
+
+// HoldRandomQuestion picks one of the questions left on the board for a side
+// that ran out of time to select.
+func (r *Room) HoldRandomQuestion() (string, int, error) {
+	if err := r.canSelectQuestion(SYSTEM); err != nil {
+		return "", 0, err
+	}
+	type boardQuestion struct {
+		category string
+		index    int
+	}
+	available := make([]boardQuestion, 0)
+	for _, cq := range r.CurrentRoundQuestions {
+		for i, q := range cq.Questions {
+			if !q.HasBeenPlayed {
+				available = append(available, boardQuestion{category: cq.Category, index: i})
+			}
+		}
+	}
+	if len(available) == 0 {
+		return "", 0, custerr.NewConflictErr("no questions left")
+	}
+	picked := available[rand.Intn(len(available))]
+	r.SelectionEndsAt = nil
+	return picked.category, picked.index, nil
+}
+
+func (r *Room) SelectQuestionAuto(pack *Pack, category string, index int, getAttachmentUrl func(key string) (string, error)) error {
+	return r.SelectQuestion(SYSTEM, pack, category, index, getAttachmentUrl)
+}
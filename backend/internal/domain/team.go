@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+const (
+	MinTeams = 2
+	MaxTeams = 5
+)
+
+type TeamAnswering string
+
+const (
+	CaptainAnswers   TeamAnswering = "captain"
+	AnyMemberAnswers TeamAnswering = "anyMember"
+)
+
+// Team shares one score between its members. The captain acts for the team
+// wherever a single player is expected: bets, cat-in-bag passes and the final
+// round.
+type Team struct {
+	Id      string   `json:"id" bson:"id"`
+	Name    string   `json:"name" bson:"name"`
+	Captain *string  `json:"captain" bson:"captain"`
+	Members []string `json:"members" bson:"members"`
+	Score   int      `json:"score" bson:"score"`
+}
+
+func NewTeams(count int) []Team {
+	teams := make([]Team, count)
+	for i := range teams {
+		teams[i] = Team{
+			Id:      fmt.Sprintf("team-%d", i+1),
+			Name:    fmt.Sprintf("Team %d", i+1),
+			Members: make([]string, 0),
+		}
+	}
+	return teams
+}
+
+func (r *Room) IsTeamMode() bool {
+	return r.Options.TeamMode
+}
+
+func (r *Room) teamIndex(teamId string) int {
+	return slices.IndexFunc(r.Teams, func(t Team) bool {
+		return t.Id == teamId
+	})
+}
+
+func (r *Room) playersTeamIndex(playerId string) int {
+	return slices.IndexFunc(r.Teams, func(t Team) bool {
+		return slices.Contains(t.Members, playerId)
+	})
+}
+
+func (r *Room) FindTeam(teamId string) *Team {
+	teamIndex := r.teamIndex(teamId)
+	if teamIndex == -1 {
+		return nil
+	}
+	return &r.Teams[teamIndex]
+}
+
+// TeamOf returns the team of the player, or nil outside team mode.
+func (r *Room) TeamOf(playerId string) *Team {
+	if !r.IsTeamMode() {
+		return nil
+	}
+	teamIndex := r.playersTeamIndex(playerId)
+	if teamIndex == -1 {
+		return nil
+	}
+	return &r.Teams[teamIndex]
+}
+
+func (r *Room) isCaptain(playerId string) bool {
+	team := r.TeamOf(playerId)
+	return team != nil && team.Captain != nil && *team.Captain == playerId
+}
+
+func (r *Room) isContender(playerId string) bool {
+	return !r.IsTeamMode() || r.isCaptain(playerId)
+}
+
+func (r *Room) sameSide(playerId, otherId string) bool {
+	if playerId == otherId {
+		return true
+	}
+	team := r.TeamOf(playerId)
+	return team != nil && slices.Contains(team.Members, otherId)
+}
+
+// ScoreOf is the score the player plays with: their own, or their team's in
+// team mode.
+func (r *Room) ScoreOf(playerId string) int {
+	if team := r.TeamOf(playerId); team != nil {
+		return team.Score
+	}
+	playerIndex := r.UsersPlayerIndex(playerId)
+	if playerIndex == -1 {
+		return 0
+	}
+	return r.Players[playerIndex].Score
+}
+
+// addScore credits the player and, in team mode, their team. The player's own
+// score then tracks their contribution to the team.
+func (r *Room) addScore(actorId string, playerIndex int, delta int) {
+	r.Players[playerIndex].Score += delta
+	if team := r.TeamOf(r.Players[playerIndex].Id); team != nil {
+		team.Score += delta
+	}
+	r.recordScoreChange(actorId, playerIndex, delta)
+}
+
+// contenders are the players that act for a side: every player, or only the
+// team captains in team mode.
+func (r *Room) contenders() []Player {
+	if !r.IsTeamMode() {
+		return r.Players
+	}
+	captains := make([]Player, 0, len(r.Teams))
+	for _, team := range r.Teams {
+		if team.Captain == nil {
+			continue
+		}
+		if playerIndex := r.UsersPlayerIndex(*team.Captain); playerIndex != -1 {
+			captains = append(captains, r.Players[playerIndex])
+		}
+	}
+	return captains
+}
+
+// buzzers are the players allowed to buzz in on a regular question.
+func (r *Room) buzzers() []string {
+	buzzers := make([]string, 0, len(r.Players))
+	if r.IsTeamMode() && r.Options.TeamAnswering == CaptainAnswers {
+		for _, p := range r.contenders() {
+			buzzers = append(buzzers, p.Id)
+		}
+		return buzzers
+	}
+	for _, p := range r.Players {
+		buzzers = append(buzzers, p.Id)
+	}
+	return buzzers
+}
+
+func (r *Room) JoinTeam(userId, teamId string) error {
+	if r.UsersPlayerIndex(userId) == -1 {
+		return custerr.NewForbiddenErr("only players can join teams")
+	}
+	return r.assignTeam(userId, teamId)
+}
+
+func (r *Room) AssignTeam(userId, playerId, teamId string) error {
+	if !r.IsUserModerator(userId) {
+		return custerr.NewForbiddenErr("not allowed to assign teams")
+	}
+	if r.UsersPlayerIndex(playerId) == -1 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no player with id \"%s\" in room", playerId))
+	}
+	return r.assignTeam(playerId, teamId)
+}
+
+func (r *Room) assignTeam(playerId, teamId string) error {
+	if !r.IsTeamMode() {
+		return custerr.NewConflictErr("room is not in team mode")
+	}
+	if r.State != WaitingForStart {
+		return custerr.NewConflictErr("teams can only be changed before the game starts")
+	}
+	teamIndex := r.teamIndex(teamId)
+	if teamIndex == -1 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no team with id \"%s\"", teamId))
+	}
+	r.addToTeam(playerId, teamIndex)
+	return nil
+}
+
+// addToTeam moves the player to the team, making them its captain if it has
+// none.
+func (r *Room) addToTeam(playerId string, teamIndex int) *Team {
+	r.RemoveFromTeam(playerId)
+	team := &r.Teams[teamIndex]
+	team.Members = append(team.Members, playerId)
+	if team.Captain == nil {
+		team.Captain = &playerId
+	}
+	return team
+}
+
+func (r *Room) smallestTeamIndex() int {
+	smallest := 0
+	for i, team := range r.Teams {
+		if len(team.Members) < len(r.Teams[smallest].Members) {
+			smallest = i
+		}
+	}
+	return smallest
+}
+
+func (r *Room) SetCaptain(userId, teamId, playerId string) error {
+	if !r.IsTeamMode() {
+		return custerr.NewConflictErr("room is not in team mode")
+	}
+	teamIndex := r.teamIndex(teamId)
+	if teamIndex == -1 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no team with id \"%s\"", teamId))
+	}
+	team := &r.Teams[teamIndex]
+	isCaptain := team.Captain != nil && *team.Captain == userId
+	if !r.IsUserModerator(userId) && !isCaptain {
+		return custerr.NewForbiddenErr("not allowed to change captain")
+	}
+	if !slices.Contains(team.Members, playerId) {
+		return custerr.NewConflictErr("captain must be a member of the team")
+	}
+	if r.State != WaitingForStart && r.State != SelectingQuestion && r.State != GameOver {
+		return custerr.NewConflictErr("can not change captain now")
+	}
+	team.Captain = &playerId
+	return nil
+}
+
+// RemoveFromTeam drops the player from their team, handing the captaincy to
+// the next member when needed.
+func (r *Room) RemoveFromTeam(playerId string) {
+	teamIndex := r.playersTeamIndex(playerId)
+	if teamIndex == -1 {
+		return
+	}
+	team := &r.Teams[teamIndex]
+	team.Members = slices.DeleteFunc(team.Members, func(id string) bool {
+		return id == playerId
+	})
+	if team.Captain != nil && *team.Captain == playerId {
+		team.Captain = nil
+		if len(team.Members) > 0 {
+			nextCaptain := team.Members[0]
+			team.Captain = &nextCaptain
+		}
+	}
+}
+
+// PrepareTeams readies the teams for the start of the game: unassigned players
+// join the smallest teams and teams left without players are dropped.
+func (r *Room) PrepareTeams() error {
+	if !r.IsTeamMode() {
+		return nil
+	}
+	for _, p := range r.Players {
+		if r.playersTeamIndex(p.Id) != -1 {
+			continue
+		}
+		if err := r.assignTeam(p.Id, r.Teams[r.smallestTeamIndex()].Id); err != nil {
+			return err
+		}
+	}
+	teamsWithMembers := 0
+	for _, team := range r.Teams {
+		if len(team.Members) > 0 {
+			teamsWithMembers++
+		}
+	}
+	if teamsWithMembers < MinTeams {
+		return custerr.NewConflictErr(fmt.Sprintf("at least %d teams need players", MinTeams))
+	}
+	r.Teams = slices.DeleteFunc(r.Teams, func(t Team) bool {
+		return len(t.Members) == 0
+	})
+	return nil
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// PrepareTieBreakers copies the pack's tie-breaker questions into the room so
+// they can be played once the pack's rounds are over. Attachments are copied as
+// well, since their URLs are signed for this room.
+func (r *Room) PrepareTieBreakers(pack *Pack, getAttachmentUrl func(key string) (string, error)) error {
+	r.TieBreakers = make([]FinalRoundQuestion, len(pack.TieBreakers))
+	for i, question := range pack.TieBreakers {
+		if question.Attachment != nil {
+			attachment := *question.Attachment
+			u, err := getAttachmentUrl(attachment.Key)
+			if err != nil {
+				return err
+			}
+			attachment.URL = u
+			question.Attachment = &attachment
+		}
+		r.TieBreakers[i] = question
+	}
+	return nil
+}
+
+func (r *Room) InTieBreaker() bool {
+	return r.FinalRoundState != nil && r.FinalRoundState.TieBreaker
+}
+
+// sideId is who a result counts for: the player, or their team in team mode.
+func (r *Room) sideId(playerId string) string {
+	if team := r.TeamOf(playerId); team != nil {
+		return team.Id
+	}
+	return playerId
+}
+
+// CompareStanding orders two players by the score they play with and, between
+// equal scores, by the tie-breakers their sides won. Tie-breakers never change
+// the scores themselves.
+func (r *Room) CompareStanding(playerId, otherId string) int {
+	if c := cmp.Compare(r.ScoreOf(playerId), r.ScoreOf(otherId)); c != 0 {
+		return c
+	}
+	return cmp.Compare(r.TieBreakerWins[r.sideId(playerId)], r.TieBreakerWins[r.sideId(otherId)])
+}
+
+// winTieBreaker credits a correct tie-breaker answer to the player's side.
+// A wrong one costs nothing, so only a strictly better answer breaks the draw.
+func (r *Room) winTieBreaker(playerId string) {
+	if r.TieBreakerWins == nil {
+		r.TieBreakerWins = make(map[string]int)
+	}
+	r.TieBreakerWins[r.sideId(playerId)]++
+}
+
+// tiedLeaders returns the contenders sharing the top standing, or nil when
+// there is a single leader.
+func (r *Room) tiedLeaders() []string {
+	var leaders []string
+	for _, player := range r.contenders() {
+		switch {
+		case len(leaders) == 0 || r.CompareStanding(player.Id, leaders[0]) > 0:
+			leaders = []string{player.Id}
+		case r.CompareStanding(player.Id, leaders[0]) == 0:
+			leaders = append(leaders, player.Id)
+		}
+	}
+	if len(leaders) < 2 {
+		return nil
+	}
+	return leaders
+}
+
+// startTieBreaker plays the next tie-breaker question, if any is left, when the
+// game would end in a draw. Only the tied leaders take part. It reports whether
+// the tie-breaker has started.
+func (r *Room) startTieBreaker() bool {
+	if len(r.TieBreakers) == 0 {
+		return false
+	}
+	leaders := r.tiedLeaders()
+	if leaders == nil {
+		return false
+	}
+
+	question := r.TieBreakers[0]
+	r.TieBreakers = r.TieBreakers[1:]
+
+	timerEndsAt := time.Now().Add(time.Duration(r.Options.QuestionThinkingTimeFinal) * time.Second)
+	r.CurrentQuestion = nil
+	r.AnsweringPlayer = nil
+	r.CurrentPlayer = nil
+	r.FinalRoundState = &FinalRoundState{
+		Question:       &question,
+		Players:        leaders,
+		PlayersAnswers: make(map[string]string),
+		TimerEndsAt:    &timerEndsAt,
+		TieBreaker:     true,
+	}
+	r.AllowedToAnswer = slices.Clone(leaders)
+	r.State = TieBreaker
+	r.record(GameEvent{
+		Type:    TieBreakerStartedEvent,
+		ActorId: SYSTEM,
+		Players: leaders,
+		Question: &GameEventQuestion{
+			Category: question.Category,
+			Text:     question.Text,
+			Answers:  question.Answers,
+		},
+	})
+	return true
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// RoomSnapshot is the room as it was right before a moderator's action, kept
+// so the action can be undone. LogLength is how many game log entries the room
+// had then; an undo rolls back every entry logged after them.
+type RoomSnapshot struct {
+	Room      Room      `json:"room"`
+	TakenAt   time.Time `json:"takenAt"`
+	LogLength int       `json:"logLength"`
+}
+
+func NewRoomSnapshot(room Room, logLength int) RoomSnapshot {
+	return RoomSnapshot{Room: room, TakenAt: time.Now(), LogLength: logLength}
+}
+
+// frozenAt is the moment the room's timers stood still at: when it was paused
+// or, for a running game, when the snapshot was taken.
+func (s RoomSnapshot) frozenAt() time.Time {
+	if s.Room.PausedState.Paused {
+		return *s.Room.PausedState.PausedAt
+	}
+	return s.TakenAt
+}
+
+// Undo rolls the game back to the snapshot. Who is connected, who is banned,
+// who moderates, the room options and whether the game is paused are live
+// state and are kept as they are now; timers resume with the time they had
+// left when the snapshot was taken. AI-hosted rooms can not undo.
+func (r *Room) Undo(userId string, snapshot RoomSnapshot) error {
+	if !r.IsUserModerator(userId) {
+		return custerr.NewForbiddenErr("not allowed to undo")
+	}
+	if r.Options.AIHost {
+		// the moderator of an AI-hosted room plays too and could take back
+		// their own answers
+		return custerr.NewConflictErr("can not undo in an AI-hosted room")
+	}
+	if r.State == GameOver {
+		return custerr.NewConflictErr("can not undo after the game is over")
+	}
+
+	restored := snapshot.Room
+	restored.pendingEvents = nil
+	restored.Players = slices.DeleteFunc(restored.Players, func(p Player) bool {
+		return r.IsUserBanned(p.Id)
+	})
+	for i := range restored.Players {
+		playerIndex := r.UsersPlayerIndex(restored.Players[i].Id)
+		restored.Players[i].IsConnected = playerIndex != -1 && r.Players[playerIndex].IsConnected
+	}
+	for _, p := range r.Players {
+		if restored.UsersPlayerIndex(p.Id) != -1 {
+			continue
+		}
+		restored.Players = append(restored.Players, p)
+		if team := r.TeamOf(p.Id); team != nil {
+			if teamIndex := restored.teamIndex(team.Id); teamIndex != -1 {
+				restored.addToTeam(p.Id, teamIndex)
+			}
+		}
+	}
+	restored.Moderator = r.Moderator
+	restored.Options = r.Options
+	restored.BanList = r.BanList
+	restored.BanExpiresAt = r.BanExpiresAt
+	restored.MutedUsers = r.MutedUsers
+	restored.SpectatorChatHiddenBy = r.SpectatorChatHiddenBy
+	restored.SeatClaims = r.SeatClaims
+	if r.Moderator != nil && !r.Options.AIHost {
+		restored.vacatePlayerSlot(r.Moderator.Id)
+	}
+
+	now := time.Now()
+	if r.PausedState.Paused {
+		now = *r.PausedState.PausedAt
+	}
+	restored.shiftTimers(now.Sub(snapshot.frozenAt()))
+	restored.PausedState = r.PausedState
+	if restored.PausedState.Paused {
+		restored.captureTimerProgress(now)
+	}
+
+	current := *r
+	*r = restored
+	r.record(GameEvent{Type: UndoneEvent, ActorId: userId, RevertedFrom: &snapshot.LogLength})
+	for i, p := range r.Players {
+		if playerIndex := current.UsersPlayerIndex(p.Id); playerIndex != -1 && current.Players[playerIndex].Score != p.Score {
+			r.recordScoreChange(userId, i, p.Score-current.Players[playerIndex].Score)
+		}
+	}
+	for i, team := range r.Teams {
+		if old := current.FindTeam(team.Id); old != nil && old.Score != team.Score {
+			r.recordTeamScoreChange(userId, i, team.Score-old.Score)
+		}
+	}
+	return nil
+}
@@ -13,6 +13,8 @@ type DbUser struct {
 	User     `bson:"inline"`
 	Login    string `json:"login" bson:"login"`
 	Password string `json:"password" bson:"password"`
+	// BlockList holds the ids of users this user does not want to play with.
+	BlockList []string `json:"-" bson:"blockList"`
 }
 
 type Moderator struct {
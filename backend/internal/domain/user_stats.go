@@ -0,0 +1,133 @@
+package domain
+
+import "slices"
+
+// UserStats are lifetime aggregates of a registered user's games. Only sums
+// and counters are stored so a finished game can be merged with $inc.
+type UserStats struct {
+	UserId              string `json:"userId" bson:"_id"`
+	GamesPlayed         int    `json:"gamesPlayed" bson:"gamesPlayed"`
+	GamesWon            int    `json:"gamesWon" bson:"gamesWon"`
+	TotalScore          int    `json:"totalScore" bson:"totalScore"`
+	CorrectAnswers      int    `json:"correctAnswers" bson:"correctAnswers"`
+	IncorrectAnswers    int    `json:"incorrectAnswers" bson:"incorrectAnswers"`
+	Buzzes              int    `json:"buzzes" bson:"buzzes"`
+	TimedBuzzes         int    `json:"-" bson:"timedBuzzes"`
+	TotalBuzzReactionMs int64  `json:"-" bson:"totalBuzzReactionMs"`
+	AuctionsPlayed      int    `json:"auctionsPlayed" bson:"auctionsPlayed"`
+	AuctionsWon         int    `json:"auctionsWon" bson:"auctionsWon"`
+	CatInBagPlayed      int    `json:"catInBagPlayed" bson:"catInBagPlayed"`
+	CatInBagWon         int    `json:"catInBagWon" bson:"catInBagWon"`
+	FinalRoundsPlayed   int    `json:"finalRoundsPlayed" bson:"finalRoundsPlayed"`
+	FinalRoundsCorrect  int    `json:"finalRoundsCorrect" bson:"finalRoundsCorrect"`
+}
+
+type UserStatsView struct {
+	UserStats
+	AverageBuzzReactionMs *float64 `json:"averageBuzzReactionMs"`
+	FinalRoundAccuracy    *float64 `json:"finalRoundAccuracy"`
+}
+
+func (s UserStats) View() UserStatsView {
+	view := UserStatsView{UserStats: s}
+	if s.TimedBuzzes > 0 {
+		average := float64(s.TotalBuzzReactionMs) / float64(s.TimedBuzzes)
+		view.AverageBuzzReactionMs = &average
+	}
+	if s.FinalRoundsPlayed > 0 {
+		accuracy := float64(s.FinalRoundsCorrect) / float64(s.FinalRoundsPlayed)
+		view.FinalRoundAccuracy = &accuracy
+	}
+	return view
+}
+
+// NewGameStats folds a finished game's log into one stats delta per registered
+// player. Guests have no account to attach stats to and are left out. In team
+// mode the whole winning team is credited with the win. Undone events are not
+// counted.
+func NewGameStats(room *Room, events []GameEvent) []UserStats {
+	events = withoutUndone(events)
+	isWinner := func(playerId string) bool {
+		if room.ScoreOf(playerId) <= 0 {
+			return false
+		}
+		return !slices.ContainsFunc(room.Players, func(other Player) bool {
+			return room.CompareStanding(other.Id, playerId) > 0
+		})
+	}
+	stats := make(map[string]*UserStats, len(room.Players))
+	for _, player := range room.Players {
+		if player.IsGuest {
+			continue
+		}
+		s := &UserStats{
+			UserId:      player.Id,
+			GamesPlayed: 1,
+			TotalScore:  player.Score,
+		}
+		if isWinner(player.Id) {
+			s.GamesWon = 1
+		}
+		stats[player.Id] = s
+	}
+
+	var question *GameEventQuestion
+	inFinalRound := false
+	for _, event := range events {
+		switch event.Type {
+		case QuestionSelectedEvent:
+			question = event.Question
+		case QuestionEndedEvent:
+			question = nil
+		case FinalRoundStartedEvent:
+			question = nil
+			inFinalRound = true
+		case TieBreakerStartedEvent:
+			question = nil
+			inFinalRound = false
+		case BuzzEvent:
+			s, ok := stats[event.ActorId]
+			if !ok {
+				continue
+			}
+			s.Buzzes++
+			if event.ReactionMs != nil {
+				s.TimedBuzzes++
+				s.TotalBuzzReactionMs += *event.ReactionMs
+			}
+		case VerdictEvent:
+			s, ok := stats[*event.PlayerId]
+			if !ok {
+				continue
+			}
+			correct := 0
+			if *event.IsCorrect {
+				s.CorrectAnswers++
+				correct = 1
+			} else {
+				s.IncorrectAnswers++
+			}
+			// for everyone and numeric questions reuse the final round states
+			// but are regular questions, as are tie-breakers
+			switch {
+			case question == nil && inFinalRound:
+				s.FinalRoundsPlayed++
+				s.FinalRoundsCorrect += correct
+			case question != nil && question.Type == Auction:
+				s.AuctionsPlayed++
+				s.AuctionsWon += correct
+			case question != nil && question.Type == CatInBag:
+				s.CatInBagPlayed++
+				s.CatInBagWon += correct
+			}
+		}
+	}
+
+	result := make([]UserStats, 0, len(stats))
+	for _, player := range room.Players {
+		if s, ok := stats[player.Id]; ok {
+			result = append(result, *s)
+		}
+	}
+	return result
+}
@@ -3,10 +3,11 @@ package dto
 import "github.com/holdennekt/sgame/backend/internal/domain"
 
 type CreatePackRequest struct {
-	Name       string                  `json:"name" binding:"min=1,max=50"`
-	Type       domain.PrivacyType      `json:"type" binding:"oneof=public private"`
-	Rounds     []CreateRoundRequest    `json:"rounds" binding:"min=1,max=10,unique=Name,dive"`
-	FinalRound CreateFinalRoundRequest `json:"finalRound"`
+	Name        string                            `json:"name" binding:"min=1,max=50"`
+	Type        domain.PrivacyType                `json:"type" binding:"oneof=public private"`
+	Rounds      []CreateRoundRequest              `json:"rounds" binding:"min=1,max=10,unique=Name,dive"`
+	FinalRound  CreateFinalRoundRequest           `json:"finalRound"`
+	TieBreakers []CreateFinalRoundQuestionRequest `json:"tieBreakers,omitempty" binding:"max=5,dive"`
 }
 
 type AttachmentKeyer interface {
@@ -35,6 +36,14 @@ func (cpr CreatePackRequest) AttachmentKeys() map[string]struct{} {
 			set[cat.Question.Comment.Attachment.Key] = struct{}{}
 		}
 	}
+	for _, q := range cpr.TieBreakers {
+		if q.Attachment != nil {
+			set[q.Attachment.Key] = struct{}{}
+		}
+		if q.Comment != nil && q.Comment.Attachment != nil {
+			set[q.Comment.Attachment.Key] = struct{}{}
+		}
+	}
 	return set
 }
 
@@ -51,11 +60,27 @@ type CreateCategoryRequest struct {
 
 type CreateQuestionRequest struct {
 	Value      int                      `json:"value" binding:"max=10000"`
-	Type       domain.QuestionType      `json:"type" binding:"oneof=regular catInBag auction"`
+	Type       domain.QuestionType      `json:"type" binding:"oneof=regular catInBag auction multipleChoice forEveryone noRisk numeric"`
 	Text       *string                  `json:"text,omitempty" binding:"required_without=Attachment,omitnil,min=1,max=1000"`
 	Attachment *CreateAttachmentRequest `json:"attachment,omitempty" binding:"required_without=Text"`
-	Answers    []string                 `json:"answers" binding:"min=1,max=10,dive,min=1,max=500"`
+	Options    []string                 `json:"options,omitempty" binding:"required_if=Type multipleChoice,excluded_unless=Type multipleChoice,omitempty,min=2,max=10,unique,dive,min=1,max=500"`
+	Answers    []string                 `json:"answers" binding:"min=1,max=10,subset_of=Options,dive,min=1,max=500"`
 	Comment    *CreateCommentRequest    `json:"comment,omitempty" binding:"omitnil"`
+	CatInBag   *CreateCatInBagRequest   `json:"catInBag,omitempty" binding:"excluded_unless=Type catInBag,omitnil"`
+	Numeric    *CreateNumericRequest    `json:"numeric,omitempty" binding:"required_if=Type numeric,excluded_unless=Type numeric,omitnil"`
+}
+
+type CreateCatInBagRequest struct {
+	Theme    *string `json:"theme,omitempty" binding:"omitnil,min=1,max=50"`
+	MinPrice int     `json:"minPrice" binding:"min=0,max=10000"`
+	MaxPrice int     `json:"maxPrice" binding:"gtefield=MinPrice,max=10000"`
+	Step     int     `json:"step" binding:"min=0,max=10000"`
+}
+
+type CreateNumericRequest struct {
+	Value      float64 `json:"value"`
+	Tolerance  float64 `json:"tolerance" binding:"min=0"`
+	ExactBonus int     `json:"exactBonus" binding:"min=0,max=10000"`
 }
 
 type CreateAttachmentRequest struct {
@@ -82,6 +107,7 @@ type CreateFinalRoundQuestionRequest struct {
 	Attachment *CreateAttachmentRequest `json:"attachment,omitempty" binding:"required_without=Text"`
 	Answers    []string                 `json:"answers" binding:"required,min=1,max=10,dive,min=1,max=500"`
 	Comment    *CreateCommentRequest    `json:"comment,omitempty" binding:"omitnil"`
+	Numeric    *CreateNumericRequest    `json:"numeric,omitempty" binding:"omitnil"`
 }
 
 type CreatePackResponse struct {
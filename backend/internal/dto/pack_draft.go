@@ -7,10 +7,11 @@ type CreatePackDraftRequest struct {
 }
 
 type UpdatePackDraftRequest struct {
-	Name       string                       `json:"name" binding:"max=200"`
-	Type       domain.PrivacyType           `json:"type" binding:"oneof=public private"`
-	Rounds     []UpdateRoundDraftRequest    `json:"rounds" binding:"max=20,unique=Name,dive"`
-	FinalRound UpdateFinalRoundDraftRequest `json:"finalRound"`
+	Name        string                                 `json:"name" binding:"max=200"`
+	Type        domain.PrivacyType                     `json:"type" binding:"oneof=public private"`
+	Rounds      []UpdateRoundDraftRequest              `json:"rounds" binding:"max=20,unique=Name,dive"`
+	FinalRound  UpdateFinalRoundDraftRequest           `json:"finalRound"`
+	TieBreakers []UpdateFinalRoundQuestionDraftRequest `json:"tieBreakers,omitempty" binding:"max=5,dive"`
 }
 
 func (cpr UpdatePackDraftRequest) AttachmentKeys() map[string]struct{} {
@@ -35,6 +36,14 @@ func (cpr UpdatePackDraftRequest) AttachmentKeys() map[string]struct{} {
 			set[cat.Question.Comment.Attachment.Key] = struct{}{}
 		}
 	}
+	for _, q := range cpr.TieBreakers {
+		if q.Attachment != nil {
+			set[q.Attachment.Key] = struct{}{}
+		}
+		if q.Comment != nil && q.Comment.Attachment != nil {
+			set[q.Comment.Attachment.Key] = struct{}{}
+		}
+	}
 	return set
 }
 
@@ -51,11 +60,14 @@ type UpdateCategoryDraftRequest struct {
 
 type UpdateQuestionDraftRequest struct {
 	Value      int                        `json:"value"`
-	Type       domain.QuestionType        `json:"type" binding:"oneof=regular catInBag auction"`
+	Type       domain.QuestionType        `json:"type" binding:"oneof=regular catInBag auction multipleChoice forEveryone noRisk numeric"`
 	Text       *string                    `json:"text,omitempty" binding:"omitnil,max=2000"`
 	Attachment *CreateAttachmentRequest   `json:"attachment,omitempty"`
+	Options    []string                   `json:"options,omitempty" binding:"max=10,dive,max=1000"`
 	Answers    []string                   `json:"answers" binding:"max=50,dive,min=1,max=1000"`
 	Comment    *UpdateCommentDraftRequest `json:"comment,omitempty" binding:"omitnil"`
+	CatInBag   *CreateCatInBagRequest     `json:"catInBag,omitempty" binding:"omitnil"`
+	Numeric    *CreateNumericRequest      `json:"numeric,omitempty" binding:"omitnil"`
 }
 
 type UpdateFinalRoundDraftRequest struct {
@@ -72,6 +84,7 @@ type UpdateFinalRoundQuestionDraftRequest struct {
 	Attachment *CreateAttachmentRequest   `json:"attachment,omitempty"`
 	Answers    []string                   `json:"answers" binding:"max=50,dive,min=1,max=1000"`
 	Comment    *UpdateCommentDraftRequest `json:"comment,omitempty" binding:"omitnil"`
+	Numeric    *CreateNumericRequest      `json:"numeric,omitempty" binding:"omitnil"`
 }
 
 type UpdateCommentDraftRequest struct {
@@ -3,10 +3,13 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
 	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
 	"github.com/holdennekt/sgame/backend/internal/message"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
 )
 
 type chatPayload struct {
@@ -23,14 +26,18 @@ func NewChatMessage(user domain.User, msg message.Message) (message.Message, err
 	if err := json.Unmarshal(msg.Payload, &incomingPayload); err != nil {
 		return message.Message{}, err
 	}
+	return newChatMessage(domain.Chat, user, incomingPayload.Text), nil
+}
+
+func newChatMessage(event domain.Event, user domain.User, text string) message.Message {
 	payload, _ := json.Marshal(chatPayload{
-		incomingChatPayload: incomingPayload,
+		incomingChatPayload: incomingChatPayload{Text: text},
 		From:                user,
 	})
 	return message.Message{
-		Event:   domain.Chat,
+		Event:   event,
 		Payload: payload,
-	}, nil
+	}
 }
 
 func NewSystemChatMessage(text string) message.Message {
@@ -43,12 +50,73 @@ func NewSystemChatMessage(text string) message.Message {
 	}
 }
 
-func HandleClientChatMessage(ctx context.Context, server realtime.Channel, user domain.User, msg message.Message) error {
-	chatMessage, err := NewChatMessage(user, msg)
+// ParseChatMessage turns a sent chat message into an entry of the chat
+// history.
+func ParseChatMessage(msg message.Message) (domain.ChatMessage, error) {
+	var payload chatPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return domain.ChatMessage{}, err
+	}
+	return domain.ChatMessage{Event: msg.Event, From: payload.From, Text: payload.Text, At: time.Now()}, nil
+}
+
+type chatHistoryPayload struct {
+	Messages []domain.ChatMessage `json:"messages"`
+}
+
+func NewChatHistoryMessage(chat []domain.ChatMessage) message.Message {
+	payload, _ := json.Marshal(chatHistoryPayload{Messages: chat})
+	return message.Message{
+		Event:   domain.ChatHistory,
+		Payload: payload,
+	}
+}
+
+func HandleClientChatMessage(ctx context.Context, server realtime.Channel, guard *ChatGuard, user domain.User, msg message.Message) error {
+	var incomingPayload incomingChatPayload
+	if err := json.Unmarshal(msg.Payload, &incomingPayload); err != nil {
+		return err
+	}
+	text, err := guard.Check(ctx, user.Id, incomingPayload.Text)
+	if err != nil {
+		return err
+	}
+	return server.Send(ctx, newChatMessage(msg.Event, user, text))
+}
+
+// HandleClientRoomChatMessage sends a message to the room's chat or, for a
+// spectator_chat message, to the spectators' one. Muted users can write to
+// neither.
+func HandleClientRoomChatMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, guard *ChatGuard, roomId string, user domain.User, msg message.Message) error {
+	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
-	return server.Send(ctx, chatMessage)
+	if room.IsUserMuted(user.Id) {
+		return custerr.NewForbiddenErr("you are muted in this room")
+	}
+	return HandleClientChatMessage(ctx, server, guard, user, msg)
+}
+
+type toggleSpectatorChatPayload struct {
+	Visible bool `json:"visible"`
+}
+
+// HandleToggleSpectatorChatMessage saves whether the player wants to see the
+// spectators' chat from now on and returns whether it is hidden.
+func HandleToggleSpectatorChatMessage(ctx context.Context, roomCache cache.Room, roomId string, user domain.User, msg message.Message) (bool, error) {
+	var tscp toggleSpectatorChatPayload
+	if err := json.Unmarshal(msg.Payload, &tscp); err != nil {
+		return false, err
+	}
+	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		room.SetSpectatorChatHidden(user.Id, !tscp.Visible)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return !tscp.Visible, nil
 }
 
 func HandleServerChatMessage(ctx context.Context, client realtime.Channel, msg message.Message) error {
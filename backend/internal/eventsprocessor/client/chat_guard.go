@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/holdennekt/sgame/backend/internal/config"
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	ivalidator "github.com/holdennekt/sgame/backend/internal/interface/validator"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// ChatGuard holds the safeguards a chat message passes before it is sent:
+// a length limit, a per-user rate limit shared by all chats, and the filter.
+type ChatGuard struct {
+	rateLimiter cache.RateLimiter
+	filter      ivalidator.ChatFilter
+	maxLength   int
+	rateLimit   int
+	rateWindow  time.Duration
+}
+
+func NewChatGuard(rateLimiter cache.RateLimiter, filter ivalidator.ChatFilter, cfg *config.Config) *ChatGuard {
+	return &ChatGuard{
+		rateLimiter: rateLimiter,
+		filter:      filter,
+		maxLength:   cfg.ChatMaxLength,
+		rateLimit:   cfg.ChatRateLimit,
+		rateWindow:  time.Duration(cfg.ChatRateWindow) * time.Second,
+	}
+}
+
+// Check returns the text to send, or an error when the message is rejected.
+func (g *ChatGuard) Check(ctx context.Context, userId, text string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", custerr.NewBadRequestErr("message is empty")
+	}
+	if utf8.RuneCountInString(text) > g.maxLength {
+		return "", custerr.NewBadRequestErr(fmt.Sprintf("message is longer than %d characters", g.maxLength))
+	}
+	allowed, err := g.rateLimiter.Allow(ctx, domain.CHAT_RATE_PREFIX+userId, g.rateLimit, g.rateWindow)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return "", custerr.NewConflictErr("you are sending messages too fast")
+	}
+	return g.filter.Filter(text)
+}
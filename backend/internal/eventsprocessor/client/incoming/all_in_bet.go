@@ -0,0 +1,33 @@
+package incoming
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+func HandleAllInBetMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var question domain.Question
+	var amount int
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if room.CurrentQuestion != nil {
+			question = room.CurrentQuestion.Question
+		}
+		if err := room.AllInBet(user.Id); err != nil {
+			return err
+		}
+		amount = room.ScoreOf(user.Id)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	chatMessage := clientevent.NewSystemChatMessage(fmt.Sprintf("%s went all in with %d", user.Name, amount))
+	return sendAuctionUpdate(ctx, server, internalServer, roomId, newRoom, question, chatMessage)
+}
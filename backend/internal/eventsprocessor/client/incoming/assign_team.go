@@ -0,0 +1,46 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type AssignTeamPayload struct {
+	PlayerId string `json:"playerId"`
+	TeamId   string `json:"teamId"`
+}
+
+func HandleAssignTeamMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var atp AssignTeamPayload
+	if err := json.Unmarshal(msg.Payload, &atp); err != nil {
+		return err
+	}
+
+	var targetName, teamName string
+	_, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if err := room.AssignTeam(user.Id, atp.PlayerId, atp.TeamId); err != nil {
+			return err
+		}
+		targetName = room.Players[room.UsersPlayerIndex(atp.PlayerId)].Name
+		teamName = room.FindTeam(atp.TeamId).Name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s moved %s to %s", user.Name, targetName, teamName))
+	return server.Send(ctx, chatMsg)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
 	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
@@ -11,34 +12,68 @@ import (
 	"github.com/holdennekt/sgame/backend/internal/interface/cache"
 	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
 	"github.com/holdennekt/sgame/backend/internal/message"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
 )
 
 type BanPlayerPayload struct {
 	PlayerId string `json:"playerId"`
+	// Duration of the ban in seconds, zero bans for good.
+	Duration int `json:"duration"`
 }
 
-func HandleBanPlayerMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+func HandleBanPlayerMessage(ctx context.Context, lobbyServer realtime.Channel, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
 	var bpp BanPlayerPayload
 	if err := json.Unmarshal(msg.Payload, &bpp); err != nil {
 		return err
 	}
+	if bpp.Duration < 0 {
+		return custerr.NewBadRequestErr("ban duration can not be negative")
+	}
 
-	var targetName string
+	targetName := lookUpUserName(ctx, roomCache, roomId, bpp.PlayerId)
 	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
-		playerIdx := room.UsersPlayerIndex(bpp.PlayerId)
-		if playerIdx != -1 {
-			targetName = room.Players[playerIdx].Name
+		if bpp.Duration == 0 {
+			return room.BanPlayer(user.Id, bpp.PlayerId)
 		}
-		return room.BanPlayer(user.Id, bpp.PlayerId)
+		return room.BanPlayerUntil(user.Id, bpp.PlayerId, time.Now().Add(time.Duration(bpp.Duration)*time.Second))
 	})
 	if err != nil {
 		return err
 	}
 
-	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+	if err := sendUserRemoved(ctx, lobbyServer, server, roomId, bpp.PlayerId, true); err != nil {
 		return err
 	}
 
 	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s banned %s from the room", user.Name, targetName))
+	if bpp.Duration != 0 {
+		chatMsg = clientevent.NewSystemChatMessage(fmt.Sprintf("%s banned %s from the room for %s", user.Name, targetName, time.Duration(bpp.Duration)*time.Second))
+	}
 	return server.Send(ctx, chatMsg)
 }
+
+// lookUpUserName looks the user up among the players and then among the
+// spectators, so the chat can name them.
+func lookUpUserName(ctx context.Context, roomCache cache.Room, roomId, userId string) string {
+	room, err := roomCache.GetById(ctx, roomId)
+	if err == nil {
+		if playerIdx := room.UsersPlayerIndex(userId); playerIdx != -1 {
+			return room.Players[playerIdx].Name
+		}
+	}
+	if spectator, err := roomCache.GetSpectator(ctx, roomId, userId); err == nil {
+		return spectator.Name
+	}
+	return userId
+}
+
+func sendUserRemoved(ctx context.Context, lobbyServer realtime.Channel, server realtime.Channel, roomId, userId string, banned bool) error {
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := lobbyServer.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	return server.Send(ctx, outgoing.NewUserRemovedMessage(userId, banned))
+}
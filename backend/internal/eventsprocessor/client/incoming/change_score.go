@@ -13,8 +13,11 @@ import (
 	"github.com/holdennekt/sgame/backend/internal/message"
 )
 
+// ChangeScorePayload addresses either a player or, in team mode, a team when
+// TeamId is set.
 type ChangeScorePayload struct {
 	PlayerId string `json:"playerId"`
+	TeamId   string `json:"teamId"`
 	Score    int    `json:"score"`
 }
 
@@ -25,9 +28,14 @@ func HandleChangeScoreMessage(ctx context.Context, server realtime.Channel, room
 	}
 
 	var targetName string
-	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
-		playerIdx := room.UsersPlayerIndex(csp.PlayerId)
-		if playerIdx != -1 {
+	_, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if csp.TeamId != "" {
+			if team := room.FindTeam(csp.TeamId); team != nil {
+				targetName = team.Name
+			}
+			return room.ChangeTeamScore(user.Id, csp.TeamId, csp.Score)
+		}
+		if playerIdx := room.UsersPlayerIndex(csp.PlayerId); playerIdx != -1 {
 			targetName = room.Players[playerIdx].Name
 		}
 		return room.ChangeScore(user.Id, csp.PlayerId, csp.Score)
@@ -41,7 +49,7 @@ func HandleChangeScoreMessage(ctx context.Context, server realtime.Channel, room
 	}
 
 	var chatText string
-	if csp.PlayerId == user.Id {
+	if csp.TeamId == "" && csp.PlayerId == user.Id {
 		chatText = fmt.Sprintf("%s corrected their own score to %d", user.Name, csp.Score)
 	} else {
 		chatText = fmt.Sprintf("%s set %s's score to %d", user.Name, targetName, csp.Score)
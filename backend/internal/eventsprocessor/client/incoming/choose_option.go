@@ -0,0 +1,41 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+type ChooseOptionPayload struct {
+	Option int `json:"option"`
+}
+
+func HandleChooseOptionMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var cop ChooseOptionPayload
+	if err := json.Unmarshal(msg.Payload, &cop); err != nil {
+		return err
+	}
+	var question domain.Question
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if room.CurrentQuestion == nil {
+			return custerr.NewConflictErr("cannot choose option now")
+		}
+		question = room.CurrentQuestion.Question
+		return room.ChooseOption(user.Id, cop.Option)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+
+	return handlePostValidate(ctx, internalServer, newRoom, question)
+}
@@ -0,0 +1,41 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	serverevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type ChoosePricePayload struct {
+	Price int `json:"price"`
+}
+
+func HandleChoosePriceMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var cpp ChoosePricePayload
+	if err := json.Unmarshal(msg.Payload, &cpp); err != nil {
+		return err
+	}
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		return room.ChoosePrice(user.Id, cpp.Price)
+	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	answerStartedMessage := serverevent.NewAnswerStartedMessage(
+		newRoom.CurrentQuestion.Question,
+		newRoom.AnsweringPlayer.Id,
+	)
+	return internalServer.Send(ctx, answerStartedMessage)
+}
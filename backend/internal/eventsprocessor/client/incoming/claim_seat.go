@@ -0,0 +1,43 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type ClaimSeatPayload struct {
+	PlayerId string `json:"playerId"`
+}
+
+func HandleClaimSeatMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var csp ClaimSeatPayload
+	if err := json.Unmarshal(msg.Payload, &csp); err != nil {
+		return err
+	}
+
+	var seatName string
+	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		if playerIdx := room.UsersPlayerIndex(csp.PlayerId); playerIdx != -1 {
+			seatName = room.Players[playerIdx].Name
+		}
+		return room.ClaimSeat(user, csp.PlayerId)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s asks to take over %s's seat", user.Name, seatName))
+	return server.Send(ctx, chatMsg)
+}
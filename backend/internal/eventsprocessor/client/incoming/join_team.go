@@ -0,0 +1,44 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type JoinTeamPayload struct {
+	TeamId string `json:"teamId"`
+}
+
+func HandleJoinTeamMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var jtp JoinTeamPayload
+	if err := json.Unmarshal(msg.Payload, &jtp); err != nil {
+		return err
+	}
+
+	var teamName string
+	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		if err := room.JoinTeam(user.Id, jtp.TeamId); err != nil {
+			return err
+		}
+		teamName = room.FindTeam(jtp.TeamId).Name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s joined %s", user.Name, teamName))
+	return server.Send(ctx, chatMsg)
+}
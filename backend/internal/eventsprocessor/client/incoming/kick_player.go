@@ -0,0 +1,39 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type KickPlayerPayload struct {
+	PlayerId string `json:"playerId"`
+}
+
+func HandleKickPlayerMessage(ctx context.Context, lobbyServer realtime.Channel, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var kpp KickPlayerPayload
+	if err := json.Unmarshal(msg.Payload, &kpp); err != nil {
+		return err
+	}
+
+	targetName := lookUpUserName(ctx, roomCache, roomId, kpp.PlayerId)
+	_, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		return room.KickPlayer(user.Id, kpp.PlayerId)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := sendUserRemoved(ctx, lobbyServer, server, roomId, kpp.PlayerId, false); err != nil {
+		return err
+	}
+
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s kicked %s from the game", user.Name, targetName))
+	return server.Send(ctx, chatMsg)
+}
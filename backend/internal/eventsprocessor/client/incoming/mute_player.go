@@ -0,0 +1,40 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type MutePlayerPayload struct {
+	UserId string `json:"userId"`
+}
+
+func HandleMutePlayerMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var mpp MutePlayerPayload
+	if err := json.Unmarshal(msg.Payload, &mpp); err != nil {
+		return err
+	}
+
+	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		return room.MutePlayer(user.Id, mpp.UserId)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+
+	targetName := lookUpUserName(ctx, roomCache, roomId, mpp.UserId)
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s muted %s", user.Name, targetName))
+	return server.Send(ctx, chatMsg)
+}
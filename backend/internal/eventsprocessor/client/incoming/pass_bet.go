@@ -0,0 +1,28 @@
+package incoming
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+func HandlePassBetMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var question domain.Question
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if room.CurrentQuestion != nil {
+			question = room.CurrentQuestion.Question
+		}
+		return room.PassBet(user.Id)
+	})
+	if err != nil {
+		return err
+	}
+
+	chatMessage := clientevent.NewSystemChatMessage(fmt.Sprintf("%s passed", user.Name))
+	return sendAuctionUpdate(ctx, server, internalServer, roomId, newRoom, question, chatMessage)
+}
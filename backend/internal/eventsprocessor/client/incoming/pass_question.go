@@ -21,7 +21,7 @@ func HandlePassQuestionMessage(ctx context.Context, server realtime.Channel, int
 	if err := json.Unmarshal(msg.Payload, &pqp); err != nil {
 		return err
 	}
-	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
 		return room.PassQuestion(user.Id, pqp.PassTo)
 	})
 	if err != nil {
@@ -33,11 +33,8 @@ func HandlePassQuestionMessage(ctx context.Context, server realtime.Channel, int
 		return err
 	}
 
-	answerStartedMessage := serverevent.NewAnswerStartedMessage(
-		newRoom.CurrentQuestion.Question,
-		newRoom.AnsweringPlayer.Id,
-	)
-	if err := internalServer.Send(ctx, answerStartedMessage); err != nil {
+	catInBagHandedMessage := serverevent.NewCatInBagHandedMessage(newRoom)
+	if err := internalServer.Send(ctx, catInBagHandedMessage); err != nil {
 		return err
 	}
 	return nil
@@ -58,29 +58,9 @@ func HandlePauseMessage(ctx context.Context, server realtime.Channel, internalSe
 			return
 		}
 
-		if err := sendRescheduleMessage(ctx, internalServer, newRoom); err != nil {
+		if err := serverevent.Reschedule(ctx, internalServer, newRoom); err != nil {
 			slog.Error("error", "err", err)
 		}
 	})
 	return nil
 }
-
-func sendRescheduleMessage(ctx context.Context, internalServer realtime.Channel, room *domain.Room) error {
-	switch room.State {
-	case domain.RevealingQuestion:
-		return internalServer.Send(ctx, serverevent.NewRevealingStartedMessage(room.CurrentQuestion.Question))
-	case domain.ShowingQuestion:
-		return internalServer.Send(ctx, serverevent.NewQuestionStartedMessage(room.CurrentQuestion.Question))
-	case domain.Answering:
-		return internalServer.Send(ctx, serverevent.NewAnswerStartedMessage(room.CurrentQuestion.Question, room.AnsweringPlayer.Id))
-	case domain.Betting:
-		return internalServer.Send(ctx, serverevent.NewBettingStartedMessage(room.CurrentQuestion.Question))
-	case domain.Passing:
-		return internalServer.Send(ctx, serverevent.NewPassingStartedMessage(room.CurrentQuestion.Question))
-	case domain.FinalRoundBetting:
-		return internalServer.Send(ctx, serverevent.NewFinalRoundBettingStartedMessage())
-	case domain.ShowingFinalRoundQuestion:
-		return internalServer.Send(ctx, serverevent.NewFinalRoundQuestionStartedMessage())
-	}
-	return nil
-}
@@ -21,7 +21,7 @@ func HandlePlaceBetMessage(ctx context.Context, server realtime.Channel, interna
 	if err := json.Unmarshal(msg.Payload, &pbp); err != nil {
 		return err
 	}
-	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
 		return room.PlaceBet(user.Id, pbp.Amount)
 	})
 	if err != nil {
@@ -18,7 +18,11 @@ func HandlePlaceFinalRoundBetMessage(ctx context.Context, server realtime.Channe
 	if err := json.Unmarshal(msg.Payload, &pbp); err != nil {
 		return err
 	}
-	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	var question domain.Question
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if room.CurrentQuestion != nil {
+			question = room.CurrentQuestion.Question
+		}
 		return room.PlaceFinalRoundBet(user.Id, pbp.Amount)
 	})
 	if err != nil {
@@ -31,7 +35,7 @@ func HandlePlaceFinalRoundBetMessage(ctx context.Context, server realtime.Channe
 	}
 
 	switch newRoom.State {
-	case domain.ShowingFinalRoundQuestion:
+	case domain.ShowingFinalRoundQuestion, domain.TieBreaker:
 		finalRoundQuestionStartedMessage := serverevent.NewFinalRoundQuestionStartedMessage()
 		if err := internalServer.Send(ctx, finalRoundQuestionStartedMessage); err != nil {
 			slog.Error("error", "err", err)
@@ -41,6 +45,11 @@ func HandlePlaceFinalRoundBetMessage(ctx context.Context, server realtime.Channe
 		if err := internalServer.Send(ctx, gameEndedMessage); err != nil {
 			slog.Error("error", "err", err)
 		}
+	case domain.SelectingQuestion:
+		questionEndedMessage := serverevent.NewQuestionEndedMessage(question)
+		if err := internalServer.Send(ctx, questionEndedMessage); err != nil {
+			slog.Error("error", "err", err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,50 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type PromoteSpectatorPayload struct {
+	UserId string `json:"userId"`
+}
+
+func HandlePromoteSpectatorMessage(ctx context.Context, lobbyServer realtime.Channel, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var psp PromoteSpectatorPayload
+	if err := json.Unmarshal(msg.Payload, &psp); err != nil {
+		return err
+	}
+
+	spectator, err := roomCache.GetSpectator(ctx, roomId, psp.UserId)
+	if err != nil {
+		return err
+	}
+	_, err = roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		return room.PromoteSpectator(user.Id, *spectator)
+	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := lobbyServer.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := server.Send(ctx, outgoing.NewSpectatorPromotedMessage(spectator.Id)); err != nil {
+		return err
+	}
+
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s invited %s to play", user.Name, spectator.Name))
+	return server.Send(ctx, chatMsg)
+}
@@ -0,0 +1,68 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	serverevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type RaiseBetPayload struct {
+	Amount int `json:"amount"`
+}
+
+func HandleRaiseBetMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var rbp RaiseBetPayload
+	if err := json.Unmarshal(msg.Payload, &rbp); err != nil {
+		return err
+	}
+	var question domain.Question
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if room.CurrentQuestion != nil {
+			question = room.CurrentQuestion.Question
+		}
+		return room.RaiseBet(user.Id, rbp.Amount)
+	})
+	if err != nil {
+		return err
+	}
+
+	chatMessage := clientevent.NewSystemChatMessage(fmt.Sprintf("%s raised the bid to %d", user.Name, rbp.Amount))
+	return sendAuctionUpdate(ctx, server, internalServer, roomId, newRoom, question, chatMessage)
+}
+
+// sendAuctionUpdate notifies clients about an open auction move and schedules
+// whatever comes next: the next bidder's turn, the winner's answer or the end
+// of the question when nobody has bid.
+func sendAuctionUpdate(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomId string, newRoom *domain.Room, question domain.Question, chatMessage message.Message) error {
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := server.Send(ctx, chatMessage); err != nil {
+		return err
+	}
+
+	switch newRoom.State {
+	case domain.Betting:
+		bettingStartedMessage := serverevent.NewBettingStartedMessage(newRoom.CurrentQuestion.Question)
+		return internalServer.Send(ctx, bettingStartedMessage)
+	case domain.Answering:
+		answerStartedMessage := serverevent.NewAnswerStartedMessage(
+			newRoom.CurrentQuestion.Question,
+			newRoom.AnsweringPlayer.Id,
+		)
+		return internalServer.Send(ctx, answerStartedMessage)
+	case domain.SelectingQuestion:
+		questionEndedMessage := serverevent.NewQuestionEndedMessage(question)
+		return internalServer.Send(ctx, questionEndedMessage)
+	}
+	return nil
+}
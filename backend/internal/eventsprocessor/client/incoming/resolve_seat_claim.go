@@ -0,0 +1,78 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	serverevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type ResolveSeatClaimPayload struct {
+	UserId  string `json:"userId"`
+	Approve bool   `json:"approve"`
+}
+
+func HandleResolveSeatClaimMessage(ctx context.Context, lobbyServer realtime.Channel, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var rscp ResolveSeatClaimPayload
+	if err := json.Unmarshal(msg.Payload, &rscp); err != nil {
+		return err
+	}
+
+	if rscp.Approve {
+		// the claimant has to still be watching to take the seat
+		if _, err := roomCache.GetSpectator(ctx, roomId, rscp.UserId); err != nil {
+			return err
+		}
+	}
+
+	var claim domain.SeatClaim
+	var seatName string
+	var wasPaused bool
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		for _, c := range room.SeatClaims {
+			if c.Id == rscp.UserId {
+				claim = c
+			}
+		}
+		if playerIdx := room.UsersPlayerIndex(claim.PlayerId); playerIdx != -1 {
+			seatName = room.Players[playerIdx].Name
+		}
+		wasPaused = room.PausedState.Paused
+		return room.ResolveSeatClaim(user.Id, rscp.UserId, rscp.Approve)
+	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if !rscp.Approve {
+		chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s declined %s's request to take over %s's seat", user.Name, claim.Name, seatName))
+		return server.Send(ctx, chatMsg)
+	}
+
+	if err := lobbyServer.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := server.Send(ctx, outgoing.NewSeatClaimedMessage(claim.PlayerId, claim.Id)); err != nil {
+		return err
+	}
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s took over %s's seat", claim.Name, seatName))
+	if err := server.Send(ctx, chatMsg); err != nil {
+		return err
+	}
+
+	if wasPaused && !newRoom.PausedState.Paused {
+		return serverevent.Reschedule(ctx, internalServer, newRoom)
+	}
+	return nil
+}
@@ -25,7 +25,9 @@ func HandleSelectQuestionMessage(ctx context.Context, server realtime.Channel, i
 		return err
 	}
 
-	room, err := roomCache.GetById(ctx, roomId)
+	room, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		return room.HoldSelection(user.Id, qsp.Category, qsp.Index)
+	})
 	if err != nil {
 		return err
 	}
@@ -79,12 +81,30 @@ func HandleSelectQuestionMessage(ctx context.Context, server realtime.Channel, i
 				slog.Error("error", "err", err)
 				return
 			}
+		case domain.ChoosingPrice:
+			priceChoosingStartedMessage := serverevent.NewPriceChoosingStartedMessage(newRoom.CurrentQuestion.Question)
+			if err := internalServer.Send(ctx, priceChoosingStartedMessage); err != nil {
+				slog.Error("error", "err", err)
+				return
+			}
 		case domain.Betting:
 			bettingStartedMessage := serverevent.NewBettingStartedMessage(newRoom.CurrentQuestion.Question)
 			if err := internalServer.Send(ctx, bettingStartedMessage); err != nil {
 				slog.Error("error", "err", err)
 				return
 			}
+		case domain.FinalRoundBetting:
+			bettingStartedMessage := serverevent.NewFinalRoundBettingStartedMessage()
+			if err := internalServer.Send(ctx, bettingStartedMessage); err != nil {
+				slog.Error("error", "err", err)
+				return
+			}
+		case domain.ShowingFinalRoundQuestion:
+			finalRoundQuestionStartedMessage := serverevent.NewFinalRoundQuestionStartedMessage()
+			if err := internalServer.Send(ctx, finalRoundQuestionStartedMessage); err != nil {
+				slog.Error("error", "err", err)
+				return
+			}
 		}
 	})
 	return nil
@@ -0,0 +1,46 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type SetCaptainPayload struct {
+	TeamId   string `json:"teamId"`
+	PlayerId string `json:"playerId"`
+}
+
+func HandleSetCaptainMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var scp SetCaptainPayload
+	if err := json.Unmarshal(msg.Payload, &scp); err != nil {
+		return err
+	}
+
+	var captainName, teamName string
+	_, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if err := room.SetCaptain(user.Id, scp.TeamId, scp.PlayerId); err != nil {
+			return err
+		}
+		captainName = room.Players[room.UsersPlayerIndex(scp.PlayerId)].Name
+		teamName = room.FindTeam(scp.TeamId).Name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s is now the captain of %s", captainName, teamName))
+	return server.Send(ctx, chatMsg)
+}
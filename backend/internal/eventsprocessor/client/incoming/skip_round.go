@@ -14,7 +14,7 @@ import (
 
 func HandleSkipRoundMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, getAttachmentUrl func(string) (string, error), roomId string, user domain.User, pack *domain.Pack) error {
 	var nextRoundStarted bool
-	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
 		if err := room.SkipRound(user.Id); err != nil {
 			return err
 		}
@@ -46,11 +46,17 @@ func HandleSkipRoundMessage(ctx context.Context, server realtime.Channel, intern
 	case domain.SelectingQuestion:
 		if nextRoundStarted {
 			roundStartedMessage := serverevent.NewRoundStartedMessage()
-			return internalServer.Send(ctx, roundStartedMessage)
+			if err := internalServer.Send(ctx, roundStartedMessage); err != nil {
+				return err
+			}
 		}
+		return internalServer.Send(ctx, serverevent.NewSelectionStartedMessage())
 	case domain.FinalRoundBetting:
 		bettingStartedMessage := serverevent.NewFinalRoundBettingStartedMessage()
 		return internalServer.Send(ctx, bettingStartedMessage)
+	case domain.TieBreaker:
+		tieBreakerStartedMessage := serverevent.NewFinalRoundQuestionStartedMessage()
+		return internalServer.Send(ctx, tieBreakerStartedMessage)
 	case domain.GameOver:
 		gameEndedMessage := serverevent.NewGameEndedMessage()
 		return internalServer.Send(ctx, gameEndedMessage)
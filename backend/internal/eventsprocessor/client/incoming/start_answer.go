@@ -2,6 +2,7 @@ package incoming
 
 import (
 	"context"
+	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
@@ -11,9 +12,13 @@ import (
 	"github.com/holdennekt/sgame/backend/internal/message"
 )
 
-func HandleStartAnswerMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
-	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
-		return room.SubmitAnswer(user.Id)
+func HandleStartAnswerMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, rtt time.Duration, buzzWindow time.Duration, msg message.Message) error {
+	buzz := domain.NewBuzz(user.Id, time.Now(), rtt, buzzWindow)
+	var windowOpened bool
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		opened, err := room.Buzz(buzz, buzzWindow)
+		windowOpened = opened
+		return err
 	})
 	if err != nil {
 		return err
@@ -24,12 +29,16 @@ func HandleStartAnswerMessage(ctx context.Context, server realtime.Channel, inte
 		return err
 	}
 
+	if windowOpened {
+		buzzWindowStartedMessage := serverevent.NewBuzzWindowStartedMessage(newRoom.CurrentQuestion.Question)
+		return internalServer.Send(ctx, buzzWindowStartedMessage)
+	}
+	if newRoom.State != domain.Answering {
+		return nil
+	}
 	answerStartedMessage := serverevent.NewAnswerStartedMessage(
 		newRoom.CurrentQuestion.Question,
 		newRoom.AnsweringPlayer.Id,
 	)
-	if err := internalServer.Send(ctx, answerStartedMessage); err != nil {
-		return err
-	}
-	return nil
+	return internalServer.Send(ctx, answerStartedMessage)
 }
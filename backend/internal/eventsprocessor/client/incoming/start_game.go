@@ -14,14 +14,20 @@ import (
 	"github.com/holdennekt/sgame/backend/internal/message"
 )
 
-func HandleStartGameMessage(ctx context.Context, lobbyServer realtime.Channel, roomServer realtime.Channel, roomInternalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, pack *domain.Pack, msg message.Message) error {
-	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+func HandleStartGameMessage(ctx context.Context, lobbyServer realtime.Channel, roomServer realtime.Channel, roomInternalServer realtime.Channel, roomCache cache.Room, getAttachmentUrl func(key string) (string, error), roomId string, user domain.User, pack *domain.Pack, msg message.Message) error {
+	_, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
 		anyConnectedPlayer := slices.ContainsFunc(room.Players, func(p domain.Player) bool {
 			return p.IsConnected
 		})
 		if !room.IsUserModerator(user.Id) || !anyConnectedPlayer {
 			return errors.New("not allowed to start game")
 		}
+		if err := room.PrepareTeams(); err != nil {
+			return err
+		}
+		if err := room.PrepareTieBreakers(pack, getAttachmentUrl); err != nil {
+			return err
+		}
 		room.StartGame(pack)
 		return nil
 	})
@@ -38,5 +44,8 @@ func HandleStartGameMessage(ctx context.Context, lobbyServer realtime.Channel, r
 	}
 
 	roundStartedMessage := serverevent.NewRoundStartedMessage()
-	return roomInternalServer.Send(ctx, roundStartedMessage)
+	if err := roomInternalServer.Send(ctx, roundStartedMessage); err != nil {
+		return err
+	}
+	return roomInternalServer.Send(ctx, serverevent.NewSelectionStartedMessage())
 }
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
-	"strings"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/config"
@@ -30,7 +29,7 @@ func HandleSubmitAnswerMessage(ctx context.Context, server realtime.Channel, int
 
 	var capturedQuestion domain.Question
 	var capturedPlayerId string
-	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	_, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
 		if room.CurrentQuestion != nil {
 			capturedQuestion = room.CurrentQuestion.Question
 		}
@@ -52,17 +51,17 @@ func HandleSubmitAnswerMessage(ctx context.Context, server realtime.Channel, int
 		aiCtx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
-		iQuestion := buildValidatorQuestion(capturedQuestion, cfg.BucketName)
+		iQuestion := serverevent.BuildValidatorQuestion(capturedQuestion.Text, capturedQuestion.Attachment, capturedQuestion.Answers, cfg.BucketName)
 
-		isCorrect := false
-		if isExactMatch(iQuestion.CorrectAnswers, sap.Answer) {
-			isCorrect = true
+		var verdict ivalidator.ValidatorResponse
+		if serverevent.IsExactMatch(iQuestion.CorrectAnswers, sap.Answer) {
+			verdict = ivalidator.ValidatorResponse{IsCorrect: true, Confidence: 1}
 		} else {
 			result, err := validator.Validate(aiCtx, iQuestion, sap.Answer)
 			if err != nil {
 				slog.Error("AI validation failed, defaulting to wrong answer", "err", err, "room_id", roomId)
 			} else {
-				isCorrect = result.IsCorrect
+				verdict = result
 			}
 		}
 
@@ -77,7 +76,7 @@ func HandleSubmitAnswerMessage(ctx context.Context, server realtime.Channel, int
 			if room.CurrentQuestion != nil {
 				question = room.CurrentQuestion.Question
 			}
-			return room.ValidateAnswer(domain.SYSTEM, isCorrect)
+			return room.ValidateAnswerBySystem(verdict.IsCorrect, verdict.Confidence)
 		})
 		if err != nil {
 			if !errors.Is(err, serverevent.ErrDeferredFunctionCancelled) {
@@ -98,35 +97,3 @@ func HandleSubmitAnswerMessage(ctx context.Context, server realtime.Channel, int
 
 	return nil
 }
-
-func isExactMatch(correctAnswers []string, playerAnswer string) bool {
-	normalized := strings.ToLower(strings.TrimSpace(playerAnswer))
-	for _, correct := range correctAnswers {
-		if strings.ToLower(strings.TrimSpace(correct)) == normalized {
-			return true
-		}
-	}
-	return false
-}
-
-func buildValidatorQuestion(question domain.Question, bucketName string) ivalidator.Question {
-	q := ivalidator.Question{
-		CorrectAnswers: question.Answers,
-	}
-	if question.Text != nil {
-		q.Text = *question.Text
-	}
-	if question.Attachment != nil {
-		q.MediaURI = "gs://" + bucketName + "/" + question.Attachment.Key
-		q.MediaMIMEType = question.Attachment.MimeType
-		switch question.Attachment.Type {
-		case domain.Image:
-			q.MediaType = ivalidator.MediaTypeImage
-		case domain.Audio:
-			q.MediaType = ivalidator.MediaTypeAudio
-		case domain.Video:
-			q.MediaType = ivalidator.MediaTypeVideo
-		}
-	}
-	return q
-}
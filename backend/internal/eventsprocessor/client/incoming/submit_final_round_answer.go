@@ -6,6 +6,7 @@ import (
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	serverevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
 	"github.com/holdennekt/sgame/backend/internal/interface/cache"
 	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
 	"github.com/holdennekt/sgame/backend/internal/message"
@@ -15,12 +16,20 @@ type SubmitFinalRoundAnswerPayload struct {
 	Answer string `json:"answer"`
 }
 
-func HandleSubmitFinalRoundAnswerMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+func HandleSubmitFinalRoundAnswerMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, getAttachmentUrl func(key string) (string, error), roomId string, user domain.User, msg message.Message) error {
 	var afrap SubmitFinalRoundAnswerPayload
 	if err := json.Unmarshal(msg.Payload, &afrap); err != nil {
 		return err
 	}
-	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	var question domain.Question
+	var finalRoundQuestion *domain.FinalRoundQuestion
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if room.CurrentQuestion != nil {
+			question = room.CurrentQuestion.Question
+		}
+		if room.FinalRoundState != nil {
+			finalRoundQuestion = room.FinalRoundState.Question
+		}
 		return room.SubmitFinalRoundAnswer(user.Id, afrap.Answer)
 	})
 	if err != nil {
@@ -32,5 +41,15 @@ func HandleSubmitFinalRoundAnswerMessage(ctx context.Context, server realtime.Ch
 		return err
 	}
 
+	switch newRoom.State {
+	case domain.ValidatingFinalRoundAnswers:
+		finalRoundAnswersSubmittedMessage := serverevent.NewFinalRoundAnswersSubmittedMessage()
+		if err := internalServer.Send(ctx, finalRoundAnswersSubmittedMessage); err != nil {
+			return err
+		}
+	case domain.SelectingQuestion, domain.TieBreaker, domain.GameOver:
+		// numeric answers are scored as soon as the last one is in
+		return serverevent.SendNumericScored(ctx, server, internalServer, getAttachmentUrl, newRoom, question, finalRoundQuestion)
+	}
 	return nil
 }
@@ -0,0 +1,61 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type TransferModeratorPayload struct {
+	UserId string `json:"userId"`
+}
+
+func HandleTransferModeratorMessage(ctx context.Context, lobbyServer realtime.Channel, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var tmp TransferModeratorPayload
+	if err := json.Unmarshal(msg.Payload, &tmp); err != nil {
+		return err
+	}
+
+	room, err := roomCache.GetById(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	var target domain.User
+	if playerIdx := room.UsersPlayerIndex(tmp.UserId); playerIdx != -1 {
+		target = room.Players[playerIdx].User
+	} else {
+		spectator, err := roomCache.GetSpectator(ctx, roomId, tmp.UserId)
+		if err != nil {
+			return err
+		}
+		target = *spectator
+	}
+
+	_, err = roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		return room.TransferModerator(user.Id, target)
+	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := lobbyServer.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := server.Send(ctx, outgoing.NewModeratorChangedMessage(user.Id, target.Id)); err != nil {
+		return err
+	}
+
+	chatMsg := clientevent.NewSystemChatMessage(fmt.Sprintf("%s handed the moderator role to %s", user.Name, target.Name))
+	return server.Send(ctx, chatMsg)
+}
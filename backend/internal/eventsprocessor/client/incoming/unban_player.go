@@ -0,0 +1,32 @@
+package incoming
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type UnbanPlayerPayload struct {
+	UserId string `json:"userId"`
+}
+
+func HandleUnbanPlayerMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	var upp UnbanPlayerPayload
+	if err := json.Unmarshal(msg.Payload, &upp); err != nil {
+		return err
+	}
+
+	_, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		return room.UnbanPlayer(user.Id, upp.UserId)
+	})
+	if err != nil {
+		return err
+	}
+
+	return server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId))
+}
@@ -0,0 +1,36 @@
+package incoming
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	serverevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+func HandleUndoMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, user domain.User, msg message.Message) error {
+	newRoom, err := roomCache.Undo(ctx, roomId, func(room *domain.Room, snapshot domain.RoomSnapshot) error {
+		return room.Undo(user.Id, snapshot)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+	if err := server.Send(ctx, clientevent.NewSystemChatMessage(fmt.Sprintf("%s undid the last action", user.Name))); err != nil {
+		return err
+	}
+
+	// a paused game is re-armed once it is unpaused
+	if newRoom.PausedState.Paused {
+		return nil
+	}
+	return serverevent.Reschedule(ctx, internalServer, newRoom)
+}
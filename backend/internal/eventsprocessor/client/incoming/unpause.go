@@ -7,6 +7,7 @@ import (
 	"github.com/holdennekt/sgame/backend/internal/domain"
 	clientevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	serverevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
 	"github.com/holdennekt/sgame/backend/internal/interface/cache"
 	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
 	"github.com/holdennekt/sgame/backend/internal/message"
@@ -27,5 +28,5 @@ func HandleUnpauseMessage(ctx context.Context, server realtime.Channel, internal
 		return err
 	}
 
-	return sendRescheduleMessage(ctx, internalServer, newRoom)
+	return serverevent.Reschedule(ctx, internalServer, newRoom)
 }
@@ -23,7 +23,7 @@ func HandleValidateAnswerMessage(ctx context.Context, server realtime.Channel, i
 		return err
 	}
 	var question domain.Question
-	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
 		if room.CurrentQuestion == nil {
 			return custerr.NewConflictErr("can not validate answer now")
 		}
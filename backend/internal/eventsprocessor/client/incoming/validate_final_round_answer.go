@@ -18,7 +18,15 @@ func HandleValidateFinalRoundAnswerMessage(ctx context.Context, server realtime.
 	if err := json.Unmarshal(msg.Payload, &vap); err != nil {
 		return err
 	}
-	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	var question domain.Question
+	var finalRoundQuestion *domain.FinalRoundQuestion
+	newRoom, err := roomCache.SafeUpdateUndoable(ctx, roomId, user.Id, func(room *domain.Room) error {
+		if room.CurrentQuestion != nil {
+			question = room.CurrentQuestion.Question
+		}
+		if room.FinalRoundState != nil {
+			finalRoundQuestion = room.FinalRoundState.Question
+		}
 		return room.ValidateFinalRoundAnswer(user.Id, vap.IsCorrect)
 	})
 	if err != nil {
@@ -30,13 +38,20 @@ func HandleValidateFinalRoundAnswerMessage(ctx context.Context, server realtime.
 		return err
 	}
 
-	if newRoom.State == domain.GameOver {
-		gameEndedMessage := serverevent.NewGameEndedMessage()
-		if err := internalServer.Send(ctx, gameEndedMessage); err != nil {
+	if newRoom.State == domain.SelectingQuestion {
+		questionEndedMessage := serverevent.NewQuestionEndedMessage(question)
+		return internalServer.Send(ctx, questionEndedMessage)
+	}
+	if newRoom.State == domain.GameOver || newRoom.State == domain.TieBreaker {
+		nextMessage := serverevent.NewGameEndedMessage()
+		if newRoom.State == domain.TieBreaker {
+			nextMessage = serverevent.NewFinalRoundQuestionStartedMessage()
+		}
+		if err := internalServer.Send(ctx, nextMessage); err != nil {
 			slog.Error("error", "err", err)
 		}
 
-		correctAnswerDemoMessage := outgoing.NewCorrectAnswerDemoMessage(newRoom.FinalRoundState.Question, getAttachmentUrl)
+		correctAnswerDemoMessage := outgoing.NewCorrectAnswerDemoMessage(finalRoundQuestion, getAttachmentUrl)
 		if err := server.Send(ctx, correctAnswerDemoMessage); err != nil {
 			slog.Error("error", "err", err)
 		}
@@ -0,0 +1,31 @@
+package outgoing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type BuzzResolvedPayload struct {
+	Winner *string       `json:"winner"`
+	Buzzes []domain.Buzz `json:"buzzes"`
+}
+
+func NewBuzzResolvedMessage(winner *domain.Buzz, buzzes []domain.Buzz) message.Message {
+	var winnerId *string
+	if winner != nil {
+		winnerId = &winner.PlayerId
+	}
+	payload, _ := json.Marshal(BuzzResolvedPayload{Winner: winnerId, Buzzes: buzzes})
+	return message.Message{
+		Event:   domain.BuzzResolved,
+		Payload: payload,
+	}
+}
+
+func HandleBuzzResolvedMessage(ctx context.Context, client realtime.Channel, msg message.Message) error {
+	return client.Send(ctx, msg)
+}
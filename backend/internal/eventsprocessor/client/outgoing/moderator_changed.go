@@ -0,0 +1,65 @@
+package outgoing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type moderatorChangedPayload struct {
+	PreviousModeratorId string `json:"previousModeratorId"`
+	ModeratorId         string `json:"moderatorId"`
+}
+
+func NewModeratorChangedMessage(previousModeratorId, moderatorId string) message.Message {
+	payload, _ := json.Marshal(moderatorChangedPayload{PreviousModeratorId: previousModeratorId, ModeratorId: moderatorId})
+	return message.Message{Event: domain.ModeratorChanged, Payload: payload}
+}
+
+// HandleModeratorChangedMessage moves the connection of a promoted spectator
+// or a demoted moderator between the room's spectators and its members, and
+// returns whether the connection now belongs to a spectator.
+func HandleModeratorChangedMessage(ctx context.Context, roomCache cache.Room, server realtime.Channel, roomId string, user domain.User, isSpectator bool, msg message.Message) (bool, error) {
+	var mcp moderatorChangedPayload
+	if err := json.Unmarshal(msg.Payload, &mcp); err != nil {
+		return isSpectator, err
+	}
+	if user.Id != mcp.ModeratorId && user.Id != mcp.PreviousModeratorId {
+		return isSpectator, nil
+	}
+	return syncSpectator(ctx, roomCache, server, roomId, user, isSpectator)
+}
+
+// syncSpectator moves the connection between the room's spectators and its
+// members when the user's role in the room has changed, and returns whether
+// the connection now belongs to a spectator.
+func syncSpectator(ctx context.Context, roomCache cache.Room, server realtime.Channel, roomId string, user domain.User, isSpectator bool) (bool, error) {
+	room, err := roomCache.GetById(ctx, roomId)
+	if err != nil {
+		return isSpectator, err
+	}
+	nowSpectator := !room.IsUserIn(user.Id)
+	if nowSpectator == isSpectator {
+		return isSpectator, nil
+	}
+	if nowSpectator {
+		if err := roomCache.AddSpectator(ctx, roomId, user); err != nil {
+			return isSpectator, err
+		}
+		if _, err := roomCache.IncrSpectators(ctx, roomId); err != nil {
+			return isSpectator, err
+		}
+	} else {
+		if err := roomCache.RemoveSpectator(ctx, roomId, user.Id); err != nil {
+			return isSpectator, err
+		}
+		if _, err := roomCache.DecrSpectators(ctx, roomId); err != nil {
+			return isSpectator, err
+		}
+	}
+	return nowSpectator, server.Send(ctx, NewRoomUpdatedMessage(roomId))
+}
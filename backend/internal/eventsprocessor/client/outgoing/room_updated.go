@@ -26,7 +26,8 @@ func HandleRoomUpdatedMessage(ctx context.Context, roomCache cache.Room, client
 	}
 	room, _ := roomCache.GetById(ctx, roomUpdatedPayload.Id)
 	spectatorCount, _ := roomCache.GetSpectatorCount(ctx, roomUpdatedPayload.Id)
-	payload, _ := json.Marshal(room.GetProjection(user.Id, spectatorCount))
+	spectators, _ := roomCache.GetSpectators(ctx, roomUpdatedPayload.Id)
+	payload, _ := json.Marshal(room.GetProjection(user.Id, spectatorCount, spectators))
 	return client.Send(ctx, message.Message{Event: msg.Event, Payload: payload})
 }
 
@@ -0,0 +1,35 @@
+package outgoing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type seatClaimedPayload struct {
+	PreviousPlayerId string `json:"previousPlayerId"`
+	PlayerId         string `json:"playerId"`
+}
+
+func NewSeatClaimedMessage(previousPlayerId, playerId string) message.Message {
+	payload, _ := json.Marshal(seatClaimedPayload{PreviousPlayerId: previousPlayerId, PlayerId: playerId})
+	return message.Message{Event: domain.SeatClaimed, Payload: payload}
+}
+
+// HandleSeatClaimedMessage turns the spectator who took over a seat into a
+// member of the room, and returns whether the connection still belongs to a
+// spectator.
+func HandleSeatClaimedMessage(ctx context.Context, roomCache cache.Room, server realtime.Channel, roomId string, user domain.User, isSpectator bool, msg message.Message) (bool, error) {
+	var scp seatClaimedPayload
+	if err := json.Unmarshal(msg.Payload, &scp); err != nil {
+		return isSpectator, err
+	}
+	if user.Id != scp.PlayerId {
+		return isSpectator, nil
+	}
+	return syncSpectator(ctx, roomCache, server, roomId, user, isSpectator)
+}
@@ -0,0 +1,34 @@
+package outgoing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type spectatorPromotedPayload struct {
+	UserId string `json:"userId"`
+}
+
+func NewSpectatorPromotedMessage(userId string) message.Message {
+	payload, _ := json.Marshal(spectatorPromotedPayload{UserId: userId})
+	return message.Message{Event: domain.SpectatorPromoted, Payload: payload}
+}
+
+// HandleSpectatorPromotedMessage turns the promoted spectator's connection
+// into a player's one, and returns whether the connection still belongs to a
+// spectator.
+func HandleSpectatorPromotedMessage(ctx context.Context, roomCache cache.Room, server realtime.Channel, roomId string, user domain.User, isSpectator bool, msg message.Message) (bool, error) {
+	var spp spectatorPromotedPayload
+	if err := json.Unmarshal(msg.Payload, &spp); err != nil {
+		return isSpectator, err
+	}
+	if user.Id != spp.UserId {
+		return isSpectator, nil
+	}
+	return syncSpectator(ctx, roomCache, server, roomId, user, isSpectator)
+}
@@ -0,0 +1,44 @@
+package outgoing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type userRemovedPayload struct {
+	UserId string `json:"userId"`
+	Banned bool   `json:"banned"`
+}
+
+func NewUserRemovedMessage(userId string, banned bool) message.Message {
+	payload, _ := json.Marshal(userRemovedPayload{UserId: userId, Banned: banned})
+	return message.Message{Event: domain.UserRemoved, Payload: payload}
+}
+
+// HandleUserRemovedMessage tells a kicked or banned user they were removed and
+// closes their connection. The connection is moved to the spectators first,
+// so closing it does not report a player disconnect. It returns whether the
+// connection belongs to a spectator.
+func HandleUserRemovedMessage(ctx context.Context, roomCache cache.Room, server realtime.Channel, client realtime.Channel, roomId string, user domain.User, isSpectator bool, msg message.Message) (bool, error) {
+	var urp userRemovedPayload
+	if err := json.Unmarshal(msg.Payload, &urp); err != nil {
+		return isSpectator, err
+	}
+	if user.Id != urp.UserId {
+		return isSpectator, nil
+	}
+
+	isSpectator, err := syncSpectator(ctx, roomCache, server, roomId, user, isSpectator)
+	if err != nil {
+		return isSpectator, err
+	}
+	if err := client.Send(ctx, msg); err != nil {
+		return isSpectator, err
+	}
+	return isSpectator, client.Close()
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+// timeSyncPayload is one NTP-style exchange. The client sends ClientSendTime
+// from its own clock and, on receiving the reply at t3, estimates its offset as
+// ((ServerReceiveTime - ClientSendTime) + (ServerSendTime - t3)) / 2 and the
+// round trip as (t3 - ClientSendTime) - (ServerSendTime - ServerReceiveTime).
+type timeSyncPayload struct {
+	ClientSendTime    int64 `json:"clientSendTime"`
+	ServerReceiveTime int64 `json:"serverReceiveTime"`
+	ServerSendTime    int64 `json:"serverSendTime"`
+}
+
+func HandleClientTimeSyncMessage(ctx context.Context, client realtime.Channel, msg message.Message) error {
+	serverReceiveTime := message.ServerTime()
+	var tsp timeSyncPayload
+	if err := json.Unmarshal(msg.Payload, &tsp); err != nil {
+		return err
+	}
+	tsp.ServerReceiveTime = serverReceiveTime
+	tsp.ServerSendTime = message.ServerTime()
+	payload, _ := json.Marshal(tsp)
+	return client.Send(ctx, message.Message{
+		Id:      msg.Id,
+		Event:   domain.TimeSync,
+		Payload: payload,
+	})
+}
@@ -17,14 +17,15 @@ type LobbyEventsProcessor struct {
 	client    realtime.Channel
 	server    realtime.Channel
 	roomCache cache.Room
+	chatGuard *client.ChatGuard
 	user      domain.User
 }
 
 type LobbyEventsProcessorGetter func(client realtime.Channel, user domain.User) *LobbyEventsProcessor
 
-func NewLobbyEventsProcessorGetter(lobbyChannelGetter realtime.ChannelGetter, roomCache cache.Room) LobbyEventsProcessorGetter {
+func NewLobbyEventsProcessorGetter(lobbyChannelGetter realtime.ChannelGetter, roomCache cache.Room, chatGuard *client.ChatGuard) LobbyEventsProcessorGetter {
 	return func(client realtime.Channel, user domain.User) *LobbyEventsProcessor {
-		return &LobbyEventsProcessor{client, lobbyChannelGetter.Get(domain.LOBBY), roomCache, user}
+		return &LobbyEventsProcessor{client, lobbyChannelGetter.Get(domain.LOBBY), roomCache, chatGuard, user}
 	}
 }
 
@@ -68,9 +69,7 @@ func (p *LobbyEventsProcessor) Listen(ctx context.Context) {
 func (p *LobbyEventsProcessor) handleClientMessage(ctx context.Context, msg message.Message) error {
 	switch msg.Event {
 	case domain.Chat:
-		if err := client.HandleClientChatMessage(ctx, p.server, p.user, msg); err != nil {
-			slog.Error("error handling client chat message", "err", err)
-		}
+		return client.HandleClientChatMessage(ctx, p.server, p.chatGuard, p.user, msg)
 	}
 	return nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/holdennekt/sgame/backend/internal/config"
 	"github.com/holdennekt/sgame/backend/internal/domain"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
@@ -13,10 +15,10 @@ import (
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
 	"github.com/holdennekt/sgame/backend/internal/interface/cache"
-	ivalidator "github.com/holdennekt/sgame/backend/internal/interface/validator"
 	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
 	"github.com/holdennekt/sgame/backend/internal/interface/repository"
 	"github.com/holdennekt/sgame/backend/internal/interface/storage"
+	ivalidator "github.com/holdennekt/sgame/backend/internal/interface/validator"
 	"github.com/holdennekt/sgame/backend/internal/message"
 )
 
@@ -24,6 +26,9 @@ const (
 	OWNER_TTL          = 10 * time.Second
 	OWNER_REFRESH_RATE = 5 * time.Second
 	GET_URL_TTL        = 3 * time.Hour
+	// TIMER_LEASE is how long a claimed timer is held by the instance handling
+	// it before another attempt can claim it.
+	TIMER_LEASE = 30 * time.Second
 )
 
 type RoomEventsProcessor struct {
@@ -39,13 +44,15 @@ type RoomEventsProcessor struct {
 	pack               *domain.Pack
 	cfg                *config.Config
 	validator          ivalidator.AnswerValidator
+	chatGuard          *client.ChatGuard
 	isSpectator        bool
+	hideSpectatorChat  bool
 	onDisconnect       func(ctx context.Context, userId, roomId string) (*domain.Room, error)
 }
 
 type RoomEventsProcessorGetter func(client realtime.Channel, id string, user domain.User, isSpectator bool) (*RoomEventsProcessor, error)
 
-func NewRoomEventsProcessorGetter(lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter realtime.ChannelGetter, roomCache cache.Room, roomRepository repository.Room, packRepository repository.Pack, storage storage.Storage, cfg *config.Config, answerValidator ivalidator.AnswerValidator, onDisconnect func(ctx context.Context, userId, roomId string) (*domain.Room, error)) RoomEventsProcessorGetter {
+func NewRoomEventsProcessorGetter(lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter realtime.ChannelGetter, roomCache cache.Room, roomRepository repository.Room, packRepository repository.Pack, storage storage.Storage, cfg *config.Config, answerValidator ivalidator.AnswerValidator, chatGuard *client.ChatGuard, onDisconnect func(ctx context.Context, userId, roomId string) (*domain.Room, error)) RoomEventsProcessorGetter {
 	return func(client realtime.Channel, id string, user domain.User, isSpectator bool) (*RoomEventsProcessor, error) {
 		room, err := roomCache.GetById(context.Background(), id)
 		if err != nil {
@@ -67,8 +74,10 @@ func NewRoomEventsProcessorGetter(lobbyChannelGetter, roomChannelGetter, roomInt
 			user:               user,
 			pack:               pack,
 			isSpectator:        isSpectator,
+			hideSpectatorChat:  room.HidesSpectatorChat(user.Id),
 			cfg:                cfg,
 			validator:          answerValidator,
+			chatGuard:          chatGuard,
 			onDisconnect:       onDisconnect,
 		}, nil
 	}
@@ -115,6 +124,17 @@ func (p *RoomEventsProcessor) Listen(ctx context.Context) {
 }
 
 func (p *RoomEventsProcessor) handleClientMessage(ctx context.Context, msg message.Message) error {
+	// spectators render the same countdowns, so they sync their clocks too
+	if msg.Event == domain.TimeSync {
+		return client.HandleClientTimeSyncMessage(ctx, p.client, msg)
+	}
+	// spectators have their own chat and can claim a free seat
+	switch msg.Event {
+	case domain.SpectatorChat:
+		return client.HandleClientRoomChatMessage(ctx, p.roomServer, p.roomCache, p.chatGuard, p.id, p.user, msg)
+	case domain.ClaimSeat:
+		return incoming.HandleClaimSeatMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+	}
 	if p.isSpectator {
 		return nil
 	}
@@ -123,27 +143,44 @@ func (p *RoomEventsProcessor) handleClientMessage(ctx context.Context, msg messa
 	}
 	switch msg.Event {
 	case domain.Chat:
-		return client.HandleClientChatMessage(ctx, p.roomServer, p.user, msg)
+		return client.HandleClientRoomChatMessage(ctx, p.roomServer, p.roomCache, p.chatGuard, p.id, p.user, msg)
+	case domain.ToggleSpectatorChat:
+		hidden, err := client.HandleToggleSpectatorChatMessage(ctx, p.roomCache, p.id, p.user, msg)
+		if err != nil {
+			return err
+		}
+		p.hideSpectatorChat = hidden
+		return nil
 	case domain.StartGame:
-		return incoming.HandleStartGameMessage(ctx, p.lobbyServer, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, p.pack, msg)
+		return incoming.HandleStartGameMessage(ctx, p.lobbyServer, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.user, p.pack, msg)
 	case domain.SelectQuestion:
 		return incoming.HandleSelectQuestionMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.user, p.pack, p.cfg.QuestionDemoDuration, msg)
 	case domain.StartAnswer:
-		return incoming.HandleStartAnswerMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
+		return incoming.HandleStartAnswerMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, p.clientRTT(), time.Duration(p.cfg.BuzzWindow)*time.Millisecond, msg)
 	case domain.SubmitAnswer:
 		return incoming.HandleSubmitAnswerMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, p.validator, p.cfg, msg)
+	case domain.ChooseOption:
+		return incoming.HandleChooseOptionMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
 	case domain.ValidateAnswer:
 		return incoming.HandleValidateAnswerMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
 	case domain.PassQuestion:
 		return incoming.HandlePassQuestionMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
+	case domain.ChoosePrice:
+		return incoming.HandleChoosePriceMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
 	case domain.PlaceBet:
 		return incoming.HandlePlaceBetMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
+	case domain.RaiseBet:
+		return incoming.HandleRaiseBetMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
+	case domain.PassBet:
+		return incoming.HandlePassBetMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
+	case domain.AllInBet:
+		return incoming.HandleAllInBetMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
 	case domain.RemoveFinalRoundCategory:
 		return incoming.HandleRemoveFinalRoundCategoryMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.user, p.pack, msg)
 	case domain.PlaceFinalRoundBet:
 		return incoming.HandlePlaceFinalRoundBetMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
 	case domain.SubmitFinalRoundAnswer:
-		return incoming.HandleSubmitFinalRoundAnswerMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+		return incoming.HandleSubmitFinalRoundAnswerMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.user, msg)
 	case domain.ValidateFinalRoundAnswer:
 		return incoming.HandleValidateFinalRoundAnswerMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.user, msg)
 	case domain.SkipQuestion:
@@ -152,16 +189,47 @@ func (p *RoomEventsProcessor) handleClientMessage(ctx context.Context, msg messa
 		return incoming.HandleSkipRoundMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.user, p.pack)
 	case domain.ChangeScore:
 		return incoming.HandleChangeScoreMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.Undo:
+		return incoming.HandleUndoMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
+	case domain.TransferModerator:
+		return incoming.HandleTransferModeratorMessage(ctx, p.lobbyServer, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.PromoteSpectator:
+		return incoming.HandlePromoteSpectatorMessage(ctx, p.lobbyServer, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.ResolveSeatClaim:
+		return incoming.HandleResolveSeatClaimMessage(ctx, p.lobbyServer, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
 	case domain.Pause:
 		return incoming.HandlePauseMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
 	case domain.Unpause:
 		return incoming.HandleUnpauseMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.user, msg)
+	case domain.MutePlayer:
+		return incoming.HandleMutePlayerMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.UnmutePlayer:
+		return incoming.HandleUnmutePlayerMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.KickPlayer:
+		return incoming.HandleKickPlayerMessage(ctx, p.lobbyServer, p.roomServer, p.roomCache, p.id, p.user, msg)
 	case domain.BanPlayer:
-		return incoming.HandleBanPlayerMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+		return incoming.HandleBanPlayerMessage(ctx, p.lobbyServer, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.UnbanPlayer:
+		return incoming.HandleUnbanPlayerMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.JoinTeam:
+		return incoming.HandleJoinTeamMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.AssignTeam:
+		return incoming.HandleAssignTeamMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
+	case domain.SetCaptain:
+		return incoming.HandleSetCaptainMessage(ctx, p.roomServer, p.roomCache, p.id, p.user, msg)
 	}
 	return nil
 }
 
+// clientRTT is the measured round trip time to the client, or zero when its
+// channel can not measure one.
+func (p *RoomEventsProcessor) clientRTT() time.Duration {
+	if meter, ok := p.client.(realtime.LatencyMeter); ok {
+		return meter.RTT()
+	}
+	return 0
+}
+
 func (p *RoomEventsProcessor) handleClientClosure(ctx context.Context) error {
 	slog.Info("room client channel closed", "user", p.user.Name, "user_id", p.user.Id, "room_id", p.id)
 
@@ -181,7 +249,7 @@ func (p *RoomEventsProcessor) handleClientClosure(ctx context.Context) error {
 	if err := p.roomServer.Send(ctx, chatMessage); err != nil {
 		return err
 	}
-	userDisconnected := server.NewUserDisconnectedMessage(p.id)
+	userDisconnected := server.NewUserDisconnectedMessage(p.user.Id)
 	return p.roomInternalServer.Send(ctx, userDisconnected)
 }
 
@@ -189,6 +257,11 @@ func (p *RoomEventsProcessor) handleServerMessage(ctx context.Context, msg messa
 	switch msg.Event {
 	case domain.Chat:
 		return client.HandleServerChatMessage(ctx, p.client, msg)
+	case domain.SpectatorChat:
+		if !p.isSpectator && p.hideSpectatorChat {
+			return nil
+		}
+		return client.HandleServerChatMessage(ctx, p.client, msg)
 	case domain.RoomUpdated:
 		return outgoing.HandleRoomUpdatedMessage(ctx, p.roomCache, p.client, p.user, msg)
 	case domain.RoundDemo:
@@ -197,6 +270,36 @@ func (p *RoomEventsProcessor) handleServerMessage(ctx context.Context, msg messa
 		return outgoing.HandleQuestionDemoMessage(ctx, p.client, msg)
 	case domain.CorrectAnswerDemo:
 		return outgoing.HandleCorrectAnswerDemoMessage(ctx, p.client, msg)
+	case domain.BuzzResolved:
+		return outgoing.HandleBuzzResolvedMessage(ctx, p.client, msg)
+	case domain.ModeratorChanged:
+		isSpectator, err := outgoing.HandleModeratorChangedMessage(ctx, p.roomCache, p.roomServer, p.id, p.user, p.isSpectator, msg)
+		if err != nil {
+			return err
+		}
+		p.isSpectator = isSpectator
+		return nil
+	case domain.SpectatorPromoted:
+		isSpectator, err := outgoing.HandleSpectatorPromotedMessage(ctx, p.roomCache, p.roomServer, p.id, p.user, p.isSpectator, msg)
+		if err != nil {
+			return err
+		}
+		p.isSpectator = isSpectator
+		return nil
+	case domain.SeatClaimed:
+		isSpectator, err := outgoing.HandleSeatClaimedMessage(ctx, p.roomCache, p.roomServer, p.id, p.user, p.isSpectator, msg)
+		if err != nil {
+			return err
+		}
+		p.isSpectator = isSpectator
+		return nil
+	case domain.UserRemoved:
+		isSpectator, err := outgoing.HandleUserRemovedMessage(ctx, p.roomCache, p.roomServer, p.client, p.id, p.user, p.isSpectator, msg)
+		if err != nil {
+			return err
+		}
+		p.isSpectator = isSpectator
+		return nil
 	case domain.RoomDeleted:
 		_ = p.lobbyServer.Close()
 		_ = p.roomServer.Close()
@@ -211,20 +314,28 @@ func (p *RoomEventsProcessor) handleServerClosure(_ context.Context) error {
 }
 
 type RoomInternalEventsProcessor struct {
-	lobbyServer        realtime.Channel
-	roomServer         realtime.Channel
-	roomInternalServer realtime.Channel
-	roomCache          cache.Room
-	roomRepository     repository.Room
-	storage            storage.Storage
-	id                 string
-	pack               *domain.Pack
-	cfg                *config.Config
+	lobbyServer         realtime.Channel
+	roomServer          realtime.Channel
+	roomInternalServer  realtime.Channel
+	roomCache           cache.Room
+	roomRepository      repository.Room
+	gameLogRepository   repository.GameLog
+	userStatsRepository repository.UserStats
+	ratingRepository    repository.Rating
+	storage             storage.Storage
+	id                  string
+	pack                *domain.Pack
+	cfg                 *config.Config
+	validator           ivalidator.AnswerValidator
+	timerCache          cache.Timer
+	armedTimersMu       sync.Mutex
+	armedTimers         map[string]*time.Timer
+	stopped             bool
 }
 
 type RoomInternalEventsProcessorGetter func(id string) (*RoomInternalEventsProcessor, error)
 
-func NewRoomInternalEventsProcessorGetter(lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter realtime.ChannelGetter, roomCache cache.Room, roomRepository repository.Room, packRepository repository.Pack, storage storage.Storage, cfg *config.Config) RoomInternalEventsProcessorGetter {
+func NewRoomInternalEventsProcessorGetter(lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter realtime.ChannelGetter, roomCache cache.Room, timerCache cache.Timer, roomRepository repository.Room, gameLogRepository repository.GameLog, userStatsRepository repository.UserStats, ratingRepository repository.Rating, packRepository repository.Pack, storage storage.Storage, cfg *config.Config, answerValidator ivalidator.AnswerValidator) RoomInternalEventsProcessorGetter {
 	return func(id string) (*RoomInternalEventsProcessor, error) {
 		room, err := roomCache.GetById(context.Background(), id)
 		if err != nil {
@@ -235,21 +346,28 @@ func NewRoomInternalEventsProcessorGetter(lobbyChannelGetter, roomChannelGetter,
 			return nil, err
 		}
 		return &RoomInternalEventsProcessor{
-			lobbyServer:        lobbyChannelGetter.Get(domain.LOBBY),
-			roomServer:         roomChannelGetter.Get(domain.ROOM_PREFIX + id),
-			roomInternalServer: roomInternalChannelGetter.Get(domain.ROOM_PREFIX + id + domain.INTERNAL_POSTFIX),
-			roomCache:          roomCache,
-			roomRepository:     roomRepository,
-			storage:            storage,
-			id:                 id,
-			pack:               pack,
-			cfg:                cfg,
+			lobbyServer:         lobbyChannelGetter.Get(domain.LOBBY),
+			roomServer:          roomChannelGetter.Get(domain.ROOM_PREFIX + id),
+			roomInternalServer:  roomInternalChannelGetter.Get(domain.ROOM_PREFIX + id + domain.INTERNAL_POSTFIX),
+			roomCache:           roomCache,
+			roomRepository:      roomRepository,
+			gameLogRepository:   gameLogRepository,
+			userStatsRepository: userStatsRepository,
+			ratingRepository:    ratingRepository,
+			storage:             storage,
+			id:                  id,
+			pack:                pack,
+			cfg:                 cfg,
+			validator:           answerValidator,
+			timerCache:          timerCache,
+			armedTimers:         make(map[string]*time.Timer),
 		}, nil
 	}
 }
 
 func (p *RoomInternalEventsProcessor) Listen(ctx context.Context) {
 	defer func() {
+		p.disarmTimers()
 		cleanupCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		if err := p.handleServerClosure(cleanupCtx); err != nil {
@@ -257,6 +375,15 @@ func (p *RoomInternalEventsProcessor) Listen(ctx context.Context) {
 		}
 	}()
 
+	if err := p.rearmTimers(ctx); err != nil {
+		slog.Error("error while re-arming room timers", "err", err, "room_id", p.id)
+	}
+	// AI grading is not a timer; answers the previous owner was still grading
+	// are graded again
+	if err := server.HandleFinalRoundAnswersSubmittedMessage(ctx, p.roomInternalServer, p.roomCache, p.id, p.validator, p.cfg); err != nil {
+		slog.Error("error while resuming final round grading", "err", err, "room_id", p.id)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -273,6 +400,8 @@ func (p *RoomInternalEventsProcessor) Listen(ctx context.Context) {
 		}
 	}()
 
+	go p.recordChat(ctx)
+
 	messages := p.roomInternalServer.Receive(ctx)
 	for {
 		msg, ok := <-messages
@@ -289,6 +418,24 @@ func (p *RoomInternalEventsProcessor) Listen(ctx context.Context) {
 	}
 }
 
+// recordChat keeps the room's chat history. Only the room's owner records it,
+// so every message is stored once.
+func (p *RoomInternalEventsProcessor) recordChat(ctx context.Context) {
+	for msg := range p.roomServer.Receive(ctx) {
+		if msg.Event != domain.Chat && msg.Event != domain.SpectatorChat {
+			continue
+		}
+		chatMessage, err := client.ParseChatMessage(msg)
+		if err != nil {
+			slog.Error("error parsing chat message", "err", err, "room_id", p.id)
+			continue
+		}
+		if err := p.roomCache.AppendChat(ctx, p.id, chatMessage, p.cfg.ChatHistorySize); err != nil {
+			slog.Error("error recording chat message", "err", err, "room_id", p.id)
+		}
+	}
+}
+
 func (p *RoomInternalEventsProcessor) handleMessage(ctx context.Context, msg message.Message) error {
 	getURL := func(key string) (string, error) {
 		return p.storage.URL(ctx, key, GET_URL_TTL)
@@ -296,28 +443,41 @@ func (p *RoomInternalEventsProcessor) handleMessage(ctx context.Context, msg mes
 	switch msg.Event {
 	case domain.RoundStarted:
 		return server.HandleRoundStartedMessage(ctx, p.roomServer, p.roomCache, p.id, p.pack)
+	case domain.SelectionStarted:
+		return server.HandleSelectionStartedMessage(ctx, p.roomCache, p, p.id, msg)
 	case domain.RevealingStarted:
-		return server.HandleRevealingStartedMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, msg)
+		return server.HandleRevealingStartedMessage(ctx, p.roomCache, p, p.id, msg)
 	case domain.QuestionStarted:
-		return server.HandleQuestionStartedMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, msg)
+		return server.HandleQuestionStartedMessage(ctx, p.roomCache, p, p.id, msg)
 	case domain.AnswerStarted:
-		return server.HandleAnswerStartedMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, msg)
+		return server.HandleAnswerStartedMessage(ctx, p.roomCache, p, p.id, msg)
+	case domain.BuzzWindowStarted:
+		return server.HandleBuzzWindowStartedMessage(ctx, p.roomCache, p, p.id, msg)
 	case domain.QuestionEnded:
 		return server.HandleQuestionEndedMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.pack, msg)
 	case domain.PassingStarted:
-		return server.HandlePassingStartedMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, msg)
+		return server.HandlePassingStartedMessage(ctx, p.roomCache, p, p.id, msg)
+	case domain.PriceChoosingStarted:
+		return server.HandlePriceChoosingStartedMessage(ctx, p.roomCache, p, p.id, msg)
 	case domain.BettingStarted:
-		return server.HandleBettingStartedMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, msg)
+		return server.HandleBettingStartedMessage(ctx, p.roomCache, p, p.id, msg)
 	case domain.FinalRoundBettingStarted:
-		return server.HandleFinalRoundBettingStartedMessage(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id)
+		return server.HandleFinalRoundBettingStartedMessage(ctx, p.roomCache, p, p.id, msg)
 	case domain.FinalRoundQuestionStarted:
-		return server.HandleFinalRoundQuestionStartedMessage(ctx, p.roomServer, p.roomCache, p.id)
+		return server.HandleFinalRoundQuestionStartedMessage(ctx, p.roomCache, p, p.id, msg)
+	case domain.FinalRoundAnswersSubmitted:
+		return server.HandleFinalRoundAnswersSubmittedMessage(ctx, p.roomInternalServer, p.roomCache, p.id, p.validator, p.cfg)
+	case domain.FinalRoundVerdictReveal:
+		return server.HandleFinalRoundVerdictRevealMessage(ctx, p, msg)
 	case domain.GameEnded:
-		return server.HandleGameEndedMessage(ctx, p.roomServer, p.roomInternalServer, p.lobbyServer, p.roomCache, p.roomRepository, p.id, time.Duration(p.cfg.IdleRoomTTL)*time.Second)
+		return server.HandleGameEndedMessage(ctx, p.roomCache, p.roomRepository, p.gameLogRepository, p.userStatsRepository, p.ratingRepository, p, p.id, time.Duration(p.cfg.IdleRoomTTL)*time.Second, msg)
 	case domain.UserDisconnected:
-		return server.HandleUserDisconnectedMessage(ctx, p.roomServer, p.roomInternalServer, p.lobbyServer, p.roomCache, p.roomRepository, p.id, msg, time.Duration(p.cfg.IdleRoomTTL)*time.Second)
+		return server.HandleUserDisconnectedMessage(ctx, p.roomServer, p.roomCache, p, p.id, msg, time.Duration(p.cfg.IdleRoomTTL)*time.Second, time.Duration(p.cfg.ModeratorFallback)*time.Second)
 	case domain.RoomDeleted:
 		slog.Info("internal room server got room_deleted event")
+		if err := p.timerCache.Delete(ctx, p.id); err != nil {
+			slog.Error("error deleting room timers", "err", err, "room_id", p.id)
+		}
 		_ = p.lobbyServer.Close()
 		_ = p.roomServer.Close()
 		return p.roomInternalServer.Close()
@@ -325,6 +485,131 @@ func (p *RoomInternalEventsProcessor) handleMessage(ctx context.Context, msg mes
 	return nil
 }
 
+// Schedule persists the timer before arming it locally, so that whichever
+// instance owns the room next can re-arm it if this one goes away.
+func (p *RoomInternalEventsProcessor) Schedule(ctx context.Context, dueAt time.Time, msg message.Message) error {
+	scheduled := cache.ScheduledMessage{Id: uuid.NewString(), DueAt: dueAt, Msg: msg}
+	if err := p.timerCache.Schedule(ctx, p.id, scheduled); err != nil {
+		return err
+	}
+	p.armTimer(scheduled, dueAt)
+	return nil
+}
+
+func (p *RoomInternalEventsProcessor) rearmTimers(ctx context.Context) error {
+	scheduled, err := p.timerCache.GetScheduled(ctx, p.id)
+	if err != nil {
+		return err
+	}
+	for _, sm := range scheduled {
+		p.armTimer(sm, sm.ClaimableAt)
+	}
+	return nil
+}
+
+// armTimer fires the timer at the given time. The timer is only removed from
+// Redis once it has been handled; a failed attempt is retried when its lease
+// runs out.
+func (p *RoomInternalEventsProcessor) armTimer(scheduled cache.ScheduledMessage, at time.Time) {
+	p.armedTimersMu.Lock()
+	defer p.armedTimersMu.Unlock()
+	if p.stopped {
+		return
+	}
+	if _, ok := p.armedTimers[scheduled.Id]; ok {
+		return
+	}
+	p.armedTimers[scheduled.Id] = time.AfterFunc(time.Until(at), func() {
+		p.armedTimersMu.Lock()
+		delete(p.armedTimers, scheduled.Id)
+		p.armedTimersMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		// Claiming leases the timer; only one instance can hold it at a time.
+		leaseEndsAt := time.Now().Add(TIMER_LEASE)
+		claimed, err := p.timerCache.Claim(ctx, p.id, scheduled.Id, TIMER_LEASE)
+		if err != nil {
+			slog.Error("error claiming room timer", "err", err, "room_id", p.id)
+			p.armTimer(scheduled, leaseEndsAt)
+			return
+		}
+		if !claimed {
+			return
+		}
+		if err := p.handleTimer(ctx, scheduled); err != nil && err != server.ErrDeferredFunctionCancelled {
+			slog.Error("error handling room timer, retrying after its lease", "err", err, "room_id", p.id, "event", scheduled.Msg.Event)
+			p.armTimer(scheduled, leaseEndsAt)
+			return
+		}
+		if err := p.timerCache.Complete(ctx, p.id, scheduled.Id); err != nil {
+			slog.Error("error completing room timer", "err", err, "room_id", p.id)
+		}
+	})
+}
+
+// disarmTimers stops local timers without touching Redis, leaving them for the
+// next owner of the room.
+func (p *RoomInternalEventsProcessor) disarmTimers() {
+	p.armedTimersMu.Lock()
+	defer p.armedTimersMu.Unlock()
+	p.stopped = true
+	for id, t := range p.armedTimers {
+		t.Stop()
+		delete(p.armedTimers, id)
+	}
+}
+
+func (p *RoomInternalEventsProcessor) handleTimer(ctx context.Context, scheduled cache.ScheduledMessage) error {
+	slog.Info("room timer fired", "room_id", p.id, "event", scheduled.Msg.Event)
+	msg := scheduled.Msg
+	switch msg.Event {
+	case domain.SelectionStarted:
+		return server.HandleSelectionStartedTimer(ctx, p.roomServer, p.roomCache, p, p.id, p.pack, p.cfg.QuestionDemoDuration, scheduled.DueAt)
+	case domain.QuestionAutoSelected:
+		getURL := func(key string) (string, error) {
+			return p.storage.URL(ctx, key, GET_URL_TTL)
+		}
+		return server.HandleQuestionAutoSelectedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, p.pack, msg)
+	case domain.RevealingStarted:
+		return server.HandleRevealingStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt, msg)
+	case domain.QuestionStarted:
+		return server.HandleQuestionStartedTimer(ctx, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt, msg)
+	case domain.BuzzWindowStarted:
+		return server.HandleBuzzWindowStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt, msg)
+	case domain.AnswerStarted:
+		return server.HandleAnswerStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt, msg)
+	case domain.PassingStarted:
+		return server.HandlePassingStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt, msg)
+	case domain.PriceChoosingStarted:
+		return server.HandlePriceChoosingStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt, msg)
+	case domain.BettingStarted:
+		return server.HandleBettingStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt, msg)
+	case domain.FinalRoundBettingStarted:
+		return server.HandleFinalRoundBettingStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, scheduled.DueAt)
+	case domain.FinalRoundQuestionStarted:
+		getURL := func(key string) (string, error) {
+			return p.storage.URL(ctx, key, GET_URL_TTL)
+		}
+		return server.HandleFinalRoundQuestionStartedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, scheduled.DueAt)
+	case domain.FinalRoundVerdictReveal:
+		getURL := func(key string) (string, error) {
+			return p.storage.URL(ctx, key, GET_URL_TTL)
+		}
+		return server.HandleFinalRoundVerdictRevealTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, getURL, p.id, msg)
+	case domain.GameEnded:
+		return server.HandleGameEndedTimer(ctx, p.roomServer, p.roomInternalServer, p.lobbyServer, p.roomCache, p.id)
+	case domain.ModeratorFallback:
+		return server.HandleModeratorFallbackTimer(ctx, p.lobbyServer, p.roomServer, p.roomInternalServer, p.roomCache, p.id, p.validator != nil, msg)
+	case domain.DisconnectGraceEnded:
+		return server.HandleDisconnectGraceEndedTimer(ctx, p.roomServer, p.roomInternalServer, p.roomCache, p.id, msg)
+	case domain.RoomExpired:
+		return server.HandleRoomExpiredTimer(ctx, p.roomServer, p.roomInternalServer, p.lobbyServer, p.roomCache, p.roomRepository, p.gameLogRepository, p.id, msg)
+	}
+	return nil
+}
+
 func (p *RoomInternalEventsProcessor) handleServerClosure(ctx context.Context) error {
 	slog.Info("room channel closed", "room_id", p.id)
 	if err := p.roomServer.Delete(ctx); err != nil {
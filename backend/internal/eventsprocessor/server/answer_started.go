@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log/slog"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
@@ -27,66 +26,53 @@ func NewAnswerStartedMessage(question domain.Question, userId string) message.Me
 	return message.Message{Event: domain.AnswerStarted, Payload: payload}
 }
 
-func HandleAnswerStartedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, msg message.Message) error {
-	var asp AnswerStartedMessage
-	if err := json.Unmarshal(msg.Payload, &asp); err != nil {
-		return err
-	}
+func HandleAnswerStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
-	time.AfterFunc(time.Until(room.AnsweringPlayer.TimerEndsAt), func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
-			answerRequestEnded :=
-				newRoom.State != domain.Answering ||
-					newRoom.AnsweringPlayer.Id != asp.UserId ||
-					!asp.IsCurrent(newRoom)
-			deadlineChanged := newRoom.AnsweringPlayer != nil &&
-				!room.AnsweringPlayer.TimerEndsAt.Equal(newRoom.AnsweringPlayer.TimerEndsAt)
-			if answerRequestEnded || deadlineChanged || newRoom.PausedState.Paused {
-				return ErrDeferredFunctionCancelled
-			}
+	return scheduler.Schedule(ctx, room.AnsweringPlayer.TimerEndsAt, msg)
+}
 
-			return newRoom.ValidateAnswer(domain.SYSTEM, false)
-		})
-		if err != nil {
-			if err != ErrDeferredFunctionCancelled {
-				slog.Error("error", "err", err)
-			}
-			return
-		}
+func HandleAnswerStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time, msg message.Message) error {
+	var asp AnswerStartedMessage
+	if err := json.Unmarshal(msg.Payload, &asp); err != nil {
+		return err
+	}
 
-		roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
-		if err := server.Send(ctx, roomUpdatedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
+	newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		answerRequestEnded :=
+			newRoom.State != domain.Answering ||
+				newRoom.AnsweringPlayer.Id != asp.UserId ||
+				!asp.IsCurrent(newRoom)
+		deadlineChanged := newRoom.AnsweringPlayer != nil &&
+			!dueAt.Equal(newRoom.AnsweringPlayer.TimerEndsAt)
+		if answerRequestEnded || deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
 		}
 
-		switch newerRoom.State {
-		case domain.RevealingQuestion:
-			revealingStartedMessage := NewRevealingStartedMessage(asp.Question)
-			if err := internalServer.Send(ctx, revealingStartedMessage); err != nil {
-				slog.Error("error", "err", err)
-				return
-			}
-		case domain.ShowingQuestion:
-			questionStartedMessage := NewQuestionStartedMessage(asp.Question)
-			if err := internalServer.Send(ctx, questionStartedMessage); err != nil {
-				slog.Error("error", "err", err)
-				return
-			}
-		case domain.SelectingQuestion:
-			questionEndedMessage := NewQuestionEndedMessage(asp.Question)
-			if err := internalServer.Send(ctx, questionEndedMessage); err != nil {
-				slog.Error("error", "err", err)
-				return
-			}
-		}
+		return newRoom.ValidateAnswer(domain.SYSTEM, false)
 	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	switch newerRoom.State {
+	case domain.RevealingQuestion:
+		revealingStartedMessage := NewRevealingStartedMessage(asp.Question)
+		return internalServer.Send(ctx, revealingStartedMessage)
+	case domain.ShowingQuestion:
+		questionStartedMessage := NewQuestionStartedMessage(asp.Question)
+		return internalServer.Send(ctx, questionStartedMessage)
+	case domain.SelectingQuestion:
+		questionEndedMessage := NewQuestionEndedMessage(asp.Question)
+		return internalServer.Send(ctx, questionEndedMessage)
+	}
 	return nil
 }
 
@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"log/slog"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
@@ -22,59 +21,52 @@ func NewBettingStartedMessage(question domain.Question) message.Message {
 	return message.Message{Event: domain.BettingStarted, Payload: payload}
 }
 
-func HandleBettingStartedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, msg message.Message) error {
-	var bsp BettingStartedPayload
-	if err := json.Unmarshal(msg.Payload, &bsp); err != nil {
-		return err
-	}
+func HandleBettingStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
-	time.AfterFunc(time.Until(room.CurrentQuestion.BettingEndsAt), func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	return scheduler.Schedule(ctx, room.CurrentQuestion.BettingEndsAt, msg)
+}
 
-		newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
-			if newRoom.State != domain.Betting || !bsp.IsCurrent(newRoom) {
-				return ErrDeferredFunctionCancelled
-			}
-			deadlineChanged := newRoom.CurrentQuestion != nil &&
-				!room.CurrentQuestion.BettingEndsAt.Equal(newRoom.CurrentQuestion.BettingEndsAt)
-			if deadlineChanged || newRoom.PausedState.Paused {
-				return ErrDeferredFunctionCancelled
-			}
+func HandleBettingStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time, msg message.Message) error {
+	var bsp BettingStartedPayload
+	if err := json.Unmarshal(msg.Payload, &bsp); err != nil {
+		return err
+	}
 
-			newRoom.PlaceBetsAuto()
-			return nil
-		})
-		if err != nil {
-			if err != ErrDeferredFunctionCancelled {
-				slog.Error("error", "err", err)
-			}
-			return
+	newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if newRoom.State != domain.Betting || !bsp.IsCurrent(newRoom) {
+			return ErrDeferredFunctionCancelled
 		}
-
-		roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
-		if err := server.Send(ctx, roomUpdatedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
+		deadlineChanged := newRoom.CurrentQuestion != nil &&
+			!dueAt.Equal(newRoom.CurrentQuestion.BettingEndsAt)
+		if deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
 		}
 
-		switch newerRoom.State {
-		case domain.SelectingQuestion:
-			questionEndedMessage := NewQuestionEndedMessage(room.CurrentQuestion.Question)
-			if err := internalServer.Send(ctx, questionEndedMessage); err != nil {
-				slog.Error("error", "err", err)
-				return
-			}
-		case domain.Answering:
-			answerStartedMessage := NewAnswerStartedMessage(bsp.Question, newerRoom.AnsweringPlayer.Id)
-			if err := internalServer.Send(ctx, answerStartedMessage); err != nil {
-				slog.Error("error", "err", err)
-				return
-			}
-		}
+		newRoom.PlaceBetsAuto()
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	switch newerRoom.State {
+	case domain.Betting:
+		bettingStartedMessage := NewBettingStartedMessage(bsp.Question)
+		return internalServer.Send(ctx, bettingStartedMessage)
+	case domain.SelectingQuestion:
+		questionEndedMessage := NewQuestionEndedMessage(bsp.Question)
+		return internalServer.Send(ctx, questionEndedMessage)
+	case domain.Answering:
+		answerStartedMessage := NewAnswerStartedMessage(bsp.Question, newerRoom.AnsweringPlayer.Id)
+		return internalServer.Send(ctx, answerStartedMessage)
+	}
 	return nil
 }
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type BuzzWindowStartedPayload struct {
+	domain.Question
+}
+
+func NewBuzzWindowStartedMessage(question domain.Question) message.Message {
+	payload, _ := json.Marshal(BuzzWindowStartedPayload{Question: question})
+	return message.Message{Event: domain.BuzzWindowStarted, Payload: payload}
+}
+
+func HandleBuzzWindowStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
+	room, err := roomCache.GetById(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	if !room.HasPendingBuzzes() {
+		return nil
+	}
+	return scheduler.Schedule(ctx, room.CurrentQuestion.BuzzWindowEndsAt, msg)
+}
+
+func HandleBuzzWindowStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time, msg message.Message) error {
+	var bwsp BuzzWindowStartedPayload
+	if err := json.Unmarshal(msg.Payload, &bwsp); err != nil {
+		return err
+	}
+
+	var winner *domain.Buzz
+	var buzzes []domain.Buzz
+	newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		windowClosed :=
+			(newRoom.State != domain.RevealingQuestion && newRoom.State != domain.ShowingQuestion) ||
+				!bwsp.IsCurrent(newRoom) ||
+				!newRoom.HasPendingBuzzes()
+		if windowClosed {
+			return ErrDeferredFunctionCancelled
+		}
+		deadlineChanged := !dueAt.Equal(newRoom.CurrentQuestion.BuzzWindowEndsAt)
+		if deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
+		}
+
+		winner, buzzes = newRoom.ResolveBuzzes()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	buzzResolvedMessage := outgoing.NewBuzzResolvedMessage(winner, buzzes)
+	if err := server.Send(ctx, buzzResolvedMessage); err != nil {
+		return err
+	}
+
+	switch newerRoom.State {
+	case domain.Answering:
+		answerStartedMessage := NewAnswerStartedMessage(bwsp.Question, newerRoom.AnsweringPlayer.Id)
+		return internalServer.Send(ctx, answerStartedMessage)
+	case domain.ShowingQuestion:
+		// the question clock was held while the window was open
+		questionStartedMessage := NewQuestionStartedMessage(bwsp.Question)
+		return internalServer.Send(ctx, questionStartedMessage)
+	}
+	return nil
+}
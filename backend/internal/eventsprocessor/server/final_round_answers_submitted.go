@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/config"
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	ivalidator "github.com/holdennekt/sgame/backend/internal/interface/validator"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+const FinalRoundVerdictRevealInterval = 3 * time.Second
+
+func NewFinalRoundAnswersSubmittedMessage() message.Message {
+	return message.Message{Event: domain.FinalRoundAnswersSubmitted}
+}
+
+type FinalRoundVerdictRevealPayload struct {
+	PlayerId string `json:"playerId"`
+}
+
+func NewFinalRoundVerdictRevealMessage(playerId string) message.Message {
+	payload, _ := json.Marshal(FinalRoundVerdictRevealPayload{PlayerId: playerId})
+	return message.Message{Event: domain.FinalRoundVerdictReveal, Payload: payload}
+}
+
+// HandleFinalRoundAnswersSubmittedMessage grades all final round (or "for
+// everyone" question) answers of an AI host room concurrently and stores the
+// verdicts in the room. They are then revealed one player at a time by room
+// timers, so clients see the results in order. Rooms with a human moderator
+// are left for the moderator to validate.
+func HandleFinalRoundAnswersSubmittedMessage(ctx context.Context, internalServer realtime.Channel, roomCache cache.Room, roomId string, validator ivalidator.AnswerValidator, cfg *config.Config) error {
+	room, err := roomCache.GetById(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	if !room.Options.AIHost || room.State != domain.ValidatingFinalRoundAnswers || room.FinalRoundState.SystemVerdicts != nil {
+		return nil
+	}
+	if validator == nil {
+		return errors.New("AI host room has no answer validator configured")
+	}
+
+	question := *room.FinalRoundState.Question
+	players := room.FinalRoundState.Players
+	answers := room.FinalRoundState.PlayersAnswers
+
+	go func() {
+		timeout := time.Duration(cfg.AIValidationTimeout) * time.Second
+		aiCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		iQuestion := BuildValidatorQuestion(question.Text, question.Attachment, question.Answers, cfg.BucketName)
+		verdicts := validateFinalRoundAnswers(aiCtx, validator, iQuestion, players, answers, roomId)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+			if !slices.Equal(room.FinalRoundState.Players, players) {
+				return ErrDeferredFunctionCancelled
+			}
+			return room.SetSystemVerdicts(verdicts)
+		})
+		if err != nil {
+			if !errors.Is(err, ErrDeferredFunctionCancelled) {
+				slog.Error("error storing final round verdicts", "err", err, "room_id", roomId)
+			}
+			return
+		}
+		if err := internalServer.Send(ctx, NewFinalRoundVerdictRevealMessage(*newRoom.CurrentPlayer)); err != nil {
+			slog.Error("error", "err", err)
+		}
+	}()
+	return nil
+}
+
+func validateFinalRoundAnswers(ctx context.Context, validator ivalidator.AnswerValidator, question ivalidator.Question, players []string, answers map[string]string, roomId string) map[string]domain.SystemVerdict {
+	verdicts := make(map[string]domain.SystemVerdict, len(players))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, playerId := range players {
+		answer, ok := answers[playerId]
+		if !ok || strings.TrimSpace(answer) == "" {
+			verdicts[playerId] = domain.SystemVerdict{}
+			continue
+		}
+		if IsExactMatch(question.CorrectAnswers, answer) {
+			verdicts[playerId] = domain.SystemVerdict{IsCorrect: true, Confidence: 1}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var verdict domain.SystemVerdict
+			result, err := validator.Validate(ctx, question, answer)
+			if err != nil {
+				slog.Error("AI validation failed, defaulting to wrong answer", "err", err, "room_id", roomId, "player_id", playerId)
+			} else {
+				verdict = domain.SystemVerdict{IsCorrect: result.IsCorrect, Confidence: result.Confidence}
+			}
+			mu.Lock()
+			verdicts[playerId] = verdict
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return verdicts
+}
+
+func HandleFinalRoundVerdictRevealMessage(ctx context.Context, scheduler Scheduler, msg message.Message) error {
+	return scheduler.Schedule(ctx, time.Now().Add(FinalRoundVerdictRevealInterval), msg)
+}
+
+// HandleFinalRoundVerdictRevealTimer reveals the stored verdict of one player
+// and schedules the next player's reveal. A reveal that comes due while the
+// game is paused is dropped; unpausing schedules it again.
+func HandleFinalRoundVerdictRevealTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, getAttachmentUrl func(key string) (string, error), roomId string, msg message.Message) error {
+	var frvrp FinalRoundVerdictRevealPayload
+	if err := json.Unmarshal(msg.Payload, &frvrp); err != nil {
+		return err
+	}
+
+	var question domain.Question
+	var finalRoundQuestion *domain.FinalRoundQuestion
+	var playerName, answer string
+	var verdict domain.SystemVerdict
+	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		if room.State != domain.ValidatingFinalRoundAnswers || room.CurrentPlayer == nil || *room.CurrentPlayer != frvrp.PlayerId || room.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
+		}
+		if room.CurrentQuestion != nil {
+			question = room.CurrentQuestion.Question
+		}
+		finalRoundQuestion = room.FinalRoundState.Question
+		if playerIndex := room.UsersPlayerIndex(frvrp.PlayerId); playerIndex != -1 {
+			playerName = room.Players[playerIndex].Name
+		}
+		answer = room.FinalRoundState.PlayersAnswers[frvrp.PlayerId]
+		verdict = room.FinalRoundState.SystemVerdicts[frvrp.PlayerId]
+		return room.RevealSystemVerdict()
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+
+	verdictText := "wrong"
+	if verdict.IsCorrect {
+		verdictText = "correct"
+	}
+	if answer == "" {
+		answer = "—"
+	}
+	chatMessage := client.NewSystemChatMessage(fmt.Sprintf("%s answered \"%s\": %s", playerName, answer, verdictText))
+	if err := server.Send(ctx, chatMessage); err != nil {
+		return err
+	}
+
+	switch newRoom.State {
+	case domain.ValidatingFinalRoundAnswers:
+		return internalServer.Send(ctx, NewFinalRoundVerdictRevealMessage(*newRoom.CurrentPlayer))
+	case domain.SelectingQuestion:
+		return internalServer.Send(ctx, NewQuestionEndedMessage(question))
+	case domain.GameOver, domain.TieBreaker:
+		nextMessage := NewGameEndedMessage()
+		if newRoom.State == domain.TieBreaker {
+			nextMessage = NewFinalRoundQuestionStartedMessage()
+		}
+		if err := internalServer.Send(ctx, nextMessage); err != nil {
+			slog.Error("error", "err", err)
+		}
+
+		correctAnswerDemoMessage := outgoing.NewCorrectAnswerDemoMessage(finalRoundQuestion, getAttachmentUrl)
+		if err := server.Send(ctx, correctAnswerDemoMessage); err != nil {
+			slog.Error("error", "err", err)
+		}
+	}
+	return nil
+}
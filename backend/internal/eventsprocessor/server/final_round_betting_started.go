@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"log/slog"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
@@ -16,56 +15,51 @@ func NewFinalRoundBettingStartedMessage() message.Message {
 	return message.Message{Event: domain.FinalRoundBettingStarted}
 }
 
-func HandleFinalRoundBettingStartedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string) error {
+func HandleFinalRoundBettingStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
+	return scheduler.Schedule(ctx, *room.FinalRoundState.BettingEndsAt, msg)
+}
 
-	time.AfterFunc(time.Until(*room.FinalRoundState.BettingEndsAt), func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
-			if newRoom.State != domain.FinalRoundBetting {
-				return ErrDeferredFunctionCancelled
-			}
-			deadlineChanged := newRoom.FinalRoundState != nil &&
-				newRoom.FinalRoundState.BettingEndsAt != nil &&
-				!room.FinalRoundState.BettingEndsAt.Equal(*newRoom.FinalRoundState.BettingEndsAt)
-			if deadlineChanged || newRoom.PausedState.Paused {
-				return ErrDeferredFunctionCancelled
-			}
-			newRoom.PlaceFinalRoundBetsAuto()
-			return nil
-		})
-		if err != nil {
-			if err != ErrDeferredFunctionCancelled {
-				slog.Error("error", "err", err)
-			}
-			return
+func HandleFinalRoundBettingStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time) error {
+	var question domain.Question
+	newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if newRoom.State != domain.FinalRoundBetting {
+			return ErrDeferredFunctionCancelled
 		}
-
-		roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
-		if err := server.Send(ctx, roomUpdatedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
+		if newRoom.CurrentQuestion != nil {
+			question = newRoom.CurrentQuestion.Question
 		}
-
-		switch newerRoom.State {
-		case domain.ShowingFinalRoundQuestion:
-			finalRoundQuestionStartedMessage := NewFinalRoundQuestionStartedMessage()
-			if err := internalServer.Send(ctx, finalRoundQuestionStartedMessage); err != nil {
-				slog.Error("error", "err", err)
-				return
-			}
-		case domain.GameOver:
-			gameEndedMessage := NewGameEndedMessage()
-			if err := internalServer.Send(ctx, gameEndedMessage); err != nil {
-				slog.Error("error", "err", err)
-				return
-			}
+		deadlineChanged := newRoom.FinalRoundState != nil &&
+			newRoom.FinalRoundState.BettingEndsAt != nil &&
+			!dueAt.Equal(*newRoom.FinalRoundState.BettingEndsAt)
+		if deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
 		}
+		newRoom.PlaceFinalRoundBetsAuto()
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	switch newerRoom.State {
+	case domain.ShowingFinalRoundQuestion, domain.TieBreaker:
+		finalRoundQuestionStartedMessage := NewFinalRoundQuestionStartedMessage()
+		return internalServer.Send(ctx, finalRoundQuestionStartedMessage)
+	case domain.GameOver:
+		gameEndedMessage := NewGameEndedMessage()
+		return internalServer.Send(ctx, gameEndedMessage)
+	case domain.SelectingQuestion:
+		questionEndedMessage := NewQuestionEndedMessage(question)
+		return internalServer.Send(ctx, questionEndedMessage)
+	}
 	return nil
 }
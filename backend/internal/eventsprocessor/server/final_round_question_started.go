@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"log/slog"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
@@ -16,41 +15,47 @@ func NewFinalRoundQuestionStartedMessage() message.Message {
 	return message.Message{Event: domain.FinalRoundQuestionStarted}
 }
 
-func HandleFinalRoundQuestionStartedMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, roomId string) error {
+func HandleFinalRoundQuestionStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
-	time.AfterFunc(time.Until(*room.FinalRoundState.TimerEndsAt), func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		_, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
-			if newRoom.State != domain.ShowingFinalRoundQuestion {
-				return ErrDeferredFunctionCancelled
-			}
-			deadlineChanged := newRoom.FinalRoundState != nil &&
-				newRoom.FinalRoundState.TimerEndsAt != nil &&
-				!room.FinalRoundState.TimerEndsAt.Equal(*newRoom.FinalRoundState.TimerEndsAt)
-			if deadlineChanged || newRoom.PausedState.Paused {
-				return ErrDeferredFunctionCancelled
-			}
-
-			newRoom.EndFinalRoundQuestion()
-			return nil
-		})
-		if err != nil {
-			if err != ErrDeferredFunctionCancelled {
-				slog.Error("error", "err", err)
-			}
-			return
+	return scheduler.Schedule(ctx, *room.FinalRoundState.TimerEndsAt, msg)
+}
+
+func HandleFinalRoundQuestionStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, getAttachmentUrl func(key string) (string, error), roomId string, dueAt time.Time) error {
+	var question domain.Question
+	var finalRoundQuestion *domain.FinalRoundQuestion
+	newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if newRoom.State != domain.ShowingFinalRoundQuestion && newRoom.State != domain.TieBreaker {
+			return ErrDeferredFunctionCancelled
+		}
+		deadlineChanged := newRoom.FinalRoundState != nil &&
+			newRoom.FinalRoundState.TimerEndsAt != nil &&
+			!dueAt.Equal(*newRoom.FinalRoundState.TimerEndsAt)
+		if deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
 		}
 
-		roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
-		if err := server.Send(ctx, roomUpdatedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
+		if newRoom.CurrentQuestion != nil {
+			question = newRoom.CurrentQuestion.Question
 		}
+		finalRoundQuestion = newRoom.FinalRoundState.Question
+		newRoom.EndFinalRoundQuestion()
+		return nil
 	})
-	return nil
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	if finalRoundQuestion != nil && finalRoundQuestion.Numeric != nil {
+		return SendNumericScored(ctx, server, internalServer, getAttachmentUrl, newerRoom, question, finalRoundQuestion)
+	}
+	finalRoundAnswersSubmittedMessage := NewFinalRoundAnswersSubmittedMessage()
+	return internalServer.Send(ctx, finalRoundAnswersSubmittedMessage)
 }
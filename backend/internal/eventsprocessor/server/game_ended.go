@@ -18,38 +18,73 @@ func NewGameEndedMessage() message.Message {
 	return message.Message{Event: domain.GameEnded}
 }
 
-func HandleGameEndedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, lobbyServer realtime.Channel, roomCache cache.Room, roomRepository repository.Room, roomId string, idleRoomTTL time.Duration) error {
+func HandleGameEndedMessage(ctx context.Context, roomCache cache.Room, roomRepository repository.Room, gameLogRepository repository.GameLog, userStatsRepository repository.UserStats, ratingRepository repository.Rating, scheduler Scheduler, roomId string, idleRoomTTL time.Duration, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
 	now := time.Now()
 	room.FinishedAt = &now
+	room.Chat, err = roomCache.GetChat(ctx, roomId, 0)
+	if err != nil {
+		return err
+	}
 	if err := roomRepository.Create(ctx, room); err != nil {
 		return err
 	}
-	time.AfterFunc(idleRoomTTL, func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		if err := roomCache.Delete(ctx, roomId); err != nil {
-			slog.Error("error", "err", err)
-			return
-		}
-		metrics.RoomsActive.Dec()
-		deletedRoomMsg := outgoing.NewRoomDeletedMessage(roomId)
-		if err := lobbyServer.Send(ctx, deletedRoomMsg); err != nil {
-			slog.Error("error", "err", err)
-			return
-		}
-		if err := server.Send(ctx, deletedRoomMsg); err != nil {
-			slog.Error("error", "err", err)
-			return
-		}
-		if err := internalServer.Send(ctx, deletedRoomMsg); err != nil {
-			slog.Error("error", "err", err)
-			return
+	events, err := saveGameLog(ctx, roomCache, gameLogRepository, roomId)
+	if err != nil {
+		return err
+	}
+	for _, stats := range domain.NewGameStats(room, events) {
+		if err := userStatsRepository.Increment(ctx, &stats); err != nil {
+			slog.Error("error updating user stats", "err", err, "room_id", roomId, "user_id", stats.UserId)
 		}
-	})
-	return nil
+	}
+	if err := updateRatings(ctx, ratingRepository, room, now); err != nil {
+		slog.Error("error updating ratings", "err", err, "room_id", roomId)
+	}
+	return scheduler.Schedule(ctx, now.Add(idleRoomTTL), msg)
+}
+
+func saveGameLog(ctx context.Context, roomCache cache.Room, gameLogRepository repository.GameLog, roomId string) ([]domain.GameEvent, error) {
+	events, err := roomCache.GetGameLog(ctx, roomId)
+	if err != nil {
+		return nil, err
+	}
+	if err := gameLogRepository.Create(ctx, &domain.GameLog{RoomId: roomId, Events: events}); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func updateRatings(ctx context.Context, ratingRepository repository.Rating, room *domain.Room, finishedAt time.Time) error {
+	players := room.RatedPlayers()
+	if players == nil {
+		return nil
+	}
+	userIds := make([]string, len(players))
+	for i, player := range players {
+		userIds[i] = player.Id
+	}
+	ratings, err := ratingRepository.GetByUsers(ctx, room.RatingPool(), userIds)
+	if err != nil {
+		return err
+	}
+	return ratingRepository.Apply(ctx, domain.NewRatingChanges(room, ratings, finishedAt))
+}
+
+func HandleGameEndedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, lobbyServer realtime.Channel, roomCache cache.Room, roomId string) error {
+	if err := roomCache.Delete(ctx, roomId); err != nil {
+		return err
+	}
+	metrics.RoomsActive.Dec()
+	deletedRoomMsg := outgoing.NewRoomDeletedMessage(roomId)
+	if err := lobbyServer.Send(ctx, deletedRoomMsg); err != nil {
+		return err
+	}
+	if err := server.Send(ctx, deletedRoomMsg); err != nil {
+		return err
+	}
+	return internalServer.Send(ctx, deletedRoomMsg)
 }
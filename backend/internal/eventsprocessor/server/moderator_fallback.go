@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type moderatorFallbackPayload struct {
+	ModeratorId string `json:"moderatorId"`
+}
+
+func NewModeratorFallbackMessage(moderatorId string) message.Message {
+	payload, _ := json.Marshal(moderatorFallbackPayload{ModeratorId: moderatorId})
+	return message.Message{Event: domain.ModeratorFallback, Payload: payload}
+}
+
+func HandleModeratorFallbackTimer(ctx context.Context, lobbyServer realtime.Channel, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, canUseAIHost bool, msg message.Message) error {
+	var mfp moderatorFallbackPayload
+	if err := json.Unmarshal(msg.Payload, &mfp); err != nil {
+		return err
+	}
+
+	var wasAIHost, wasPaused bool
+	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if !newRoom.IsUserModerator(mfp.ModeratorId) || newRoom.Moderator.IsConnected {
+			return ErrDeferredFunctionCancelled
+		}
+		wasAIHost = newRoom.Options.AIHost
+		wasPaused = newRoom.PausedState.Paused
+		return newRoom.FallBackModerator(canUseAIHost)
+	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+	if err := lobbyServer.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	if newRoom.Options.AIHost && !wasAIHost {
+		chatMsg := client.NewSystemChatMessage("The moderator is gone, AI host takes over")
+		if err := server.Send(ctx, chatMsg); err != nil {
+			return err
+		}
+	}
+	if newRoom.Moderator.Id != mfp.ModeratorId {
+		if err := server.Send(ctx, outgoing.NewModeratorChangedMessage(mfp.ModeratorId, newRoom.Moderator.Id)); err != nil {
+			return err
+		}
+		chatMsg := client.NewSystemChatMessage(fmt.Sprintf("The moderator is gone, %s is the new moderator", newRoom.Moderator.Name))
+		if err := server.Send(ctx, chatMsg); err != nil {
+			return err
+		}
+	}
+	if wasPaused && !newRoom.PausedState.Paused {
+		return Reschedule(ctx, internalServer, newRoom)
+	}
+	return nil
+}
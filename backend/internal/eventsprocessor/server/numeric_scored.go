@@ -0,0 +1,32 @@
+package server
+
+import (
+	"context"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+)
+
+// SendNumericScored moves the game on once numeric answers have been scored:
+// a regular round question ends as usual, while in the final round or a
+// tie-breaker the correct answer is shown before the game ends or the next
+// tie-breaker starts.
+func SendNumericScored(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, getAttachmentUrl func(key string) (string, error), room *domain.Room, question domain.Question, finalRoundQuestion *domain.FinalRoundQuestion) error {
+	switch room.State {
+	case domain.SelectingQuestion:
+		return internalServer.Send(ctx, NewQuestionEndedMessage(question))
+	case domain.TieBreaker:
+		if err := internalServer.Send(ctx, NewFinalRoundQuestionStartedMessage()); err != nil {
+			return err
+		}
+	case domain.GameOver:
+		if err := internalServer.Send(ctx, NewGameEndedMessage()); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	correctAnswerDemoMessage := outgoing.NewCorrectAnswerDemoMessage(finalRoundQuestion, getAttachmentUrl)
+	return server.Send(ctx, correctAnswerDemoMessage)
+}
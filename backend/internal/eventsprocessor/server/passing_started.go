@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"log/slog"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
@@ -22,50 +21,41 @@ func NewPassingStartedMessage(question domain.Question) message.Message {
 	return message.Message{Event: domain.PassingStarted, Payload: payload}
 }
 
-func HandlePassingStartedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, msg message.Message) error {
-	var psp PassingStartedPayload
-	if err := json.Unmarshal(msg.Payload, &psp); err != nil {
-		return err
-	}
+func HandlePassingStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
-	time.AfterFunc(time.Until(room.CurrentQuestion.PassingEndsAt), func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	return scheduler.Schedule(ctx, room.CurrentQuestion.PassingEndsAt, msg)
+}
 
-		newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
-			if newRoom.State != domain.Passing || !psp.IsCurrent(newRoom) {
-				return ErrDeferredFunctionCancelled
-			}
-			deadlineChanged := newRoom.CurrentQuestion != nil &&
-				!room.CurrentQuestion.PassingEndsAt.Equal(newRoom.CurrentQuestion.PassingEndsAt)
-			if deadlineChanged || newRoom.PausedState.Paused {
-				return ErrDeferredFunctionCancelled
-			}
+func HandlePassingStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time, msg message.Message) error {
+	var psp PassingStartedPayload
+	if err := json.Unmarshal(msg.Payload, &psp); err != nil {
+		return err
+	}
 
-			newRoom.PassQuestionAuto()
-			return nil
-		})
-		if err != nil {
-			if err != ErrDeferredFunctionCancelled {
-				slog.Error("error", "err", err)
-			}
-			return
+	newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if newRoom.State != domain.Passing || !psp.IsCurrent(newRoom) {
+			return ErrDeferredFunctionCancelled
 		}
-
-		roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
-		if err := server.Send(ctx, roomUpdatedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
+		deadlineChanged := newRoom.CurrentQuestion != nil &&
+			!dueAt.Equal(newRoom.CurrentQuestion.PassingEndsAt)
+		if deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
 		}
 
-		answerStartedMessage := NewAnswerStartedMessage(psp.Question, newerRoom.AnsweringPlayer.Id)
-		if err := internalServer.Send(ctx, answerStartedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
-		}
+		newRoom.PassQuestionAuto()
+		return nil
 	})
-	return nil
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	return internalServer.Send(ctx, NewCatInBagHandedMessage(newerRoom))
 }
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+type PriceChoosingStartedPayload struct {
+	domain.Question
+}
+
+func NewPriceChoosingStartedMessage(question domain.Question) message.Message {
+	payload, _ := json.Marshal(PriceChoosingStartedPayload{Question: question})
+	return message.Message{Event: domain.PriceChoosingStarted, Payload: payload}
+}
+
+// NewCatInBagHandedMessage picks what follows handing a cat in bag over: the
+// receiver either chooses its price first or starts answering straight away.
+func NewCatInBagHandedMessage(room *domain.Room) message.Message {
+	if room.State == domain.ChoosingPrice {
+		return NewPriceChoosingStartedMessage(room.CurrentQuestion.Question)
+	}
+	return NewAnswerStartedMessage(room.CurrentQuestion.Question, room.AnsweringPlayer.Id)
+}
+
+func HandlePriceChoosingStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
+	room, err := roomCache.GetById(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	return scheduler.Schedule(ctx, room.CurrentQuestion.PriceEndsAt, msg)
+}
+
+func HandlePriceChoosingStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time, msg message.Message) error {
+	var pcsp PriceChoosingStartedPayload
+	if err := json.Unmarshal(msg.Payload, &pcsp); err != nil {
+		return err
+	}
+
+	newerRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if newRoom.State != domain.ChoosingPrice || !pcsp.IsCurrent(newRoom) {
+			return ErrDeferredFunctionCancelled
+		}
+		deadlineChanged := newRoom.CurrentQuestion != nil &&
+			!dueAt.Equal(newRoom.CurrentQuestion.PriceEndsAt)
+		if deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
+		}
+
+		newRoom.ChoosePriceAuto()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	answerStartedMessage := NewAnswerStartedMessage(newerRoom.CurrentQuestion.Question, newerRoom.AnsweringPlayer.Id)
+	return internalServer.Send(ctx, answerStartedMessage)
+}
@@ -64,11 +64,19 @@ func HandleQuestionEndedMessage(ctx context.Context, server realtime.Channel, in
 				return err
 			}
 		}
+		if err := internalServer.Send(ctx, NewSelectionStartedMessage()); err != nil {
+			return err
+		}
 	case domain.FinalRoundBetting:
 		bettingStartedMessage := NewFinalRoundBettingStartedMessage()
 		if err := internalServer.Send(ctx, bettingStartedMessage); err != nil {
 			return err
 		}
+	case domain.TieBreaker:
+		tieBreakerStartedMessage := NewFinalRoundQuestionStartedMessage()
+		if err := internalServer.Send(ctx, tieBreakerStartedMessage); err != nil {
+			return err
+		}
 	case domain.GameOver:
 		gameEndedMessage := NewGameEndedMessage()
 		if err := internalServer.Send(ctx, gameEndedMessage); err != nil {
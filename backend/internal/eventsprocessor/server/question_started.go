@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"log/slog"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
@@ -21,45 +20,39 @@ func NewQuestionStartedMessage(question domain.Question) message.Message {
 	return message.Message{Event: domain.QuestionStarted, Payload: payload}
 }
 
-func HandleQuestionStartedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, msg message.Message) error {
-	var qsp QuestionStartedPayload
-	if err := json.Unmarshal(msg.Payload, &qsp); err != nil {
-		return err
-	}
+func HandleQuestionStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
+	return scheduler.Schedule(ctx, room.CurrentQuestion.TimerEndsAt, msg)
+}
 
-	time.AfterFunc(time.Until(room.CurrentQuestion.TimerEndsAt), func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		_, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
-			questionEnded :=
-				newRoom.State != domain.ShowingQuestion ||
-					!qsp.IsCurrent(newRoom)
-			deadlineChanged := newRoom.CurrentQuestion != nil &&
-				!room.CurrentQuestion.TimerEndsAt.Equal(newRoom.CurrentQuestion.TimerEndsAt)
-			if questionEnded || deadlineChanged || newRoom.PausedState.Paused {
-				return ErrDeferredFunctionCancelled
-			}
+func HandleQuestionStartedTimer(ctx context.Context, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time, msg message.Message) error {
+	var qsp QuestionStartedPayload
+	if err := json.Unmarshal(msg.Payload, &qsp); err != nil {
+		return err
+	}
 
-			newRoom.EndQuestion()
-			return nil
-		})
-		if err != nil {
-			if err != ErrDeferredFunctionCancelled {
-				slog.Error("error", "err", err)
-			}
-			return
+	_, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		// pending buzzes were pressed in time; the window resolves them first
+		questionEnded :=
+			newRoom.State != domain.ShowingQuestion ||
+				!qsp.IsCurrent(newRoom) ||
+				newRoom.HasPendingBuzzes()
+		deadlineChanged := newRoom.CurrentQuestion != nil &&
+			!dueAt.Equal(newRoom.CurrentQuestion.TimerEndsAt)
+		if questionEnded || deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
 		}
 
-		questionEndedMessage := NewQuestionEndedMessage(qsp.Question)
-		if err := internalServer.Send(ctx, questionEndedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
-		}
+		newRoom.EndQuestion()
+		return nil
 	})
-	return nil
+	if err != nil {
+		return err
+	}
+
+	questionEndedMessage := NewQuestionEndedMessage(qsp.Question)
+	return internalServer.Send(ctx, questionEndedMessage)
 }
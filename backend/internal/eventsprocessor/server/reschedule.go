@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+)
+
+// Reschedule re-arms the timers of the room's current state, after they were
+// dropped by a pause or an undo.
+func Reschedule(ctx context.Context, internalServer realtime.Channel, room *domain.Room) error {
+	if room.HasPendingBuzzes() {
+		if err := internalServer.Send(ctx, NewBuzzWindowStartedMessage(room.CurrentQuestion.Question)); err != nil {
+			return err
+		}
+	}
+	switch room.State {
+	case domain.SelectingQuestion:
+		return internalServer.Send(ctx, NewSelectionStartedMessage())
+	case domain.RevealingQuestion:
+		return internalServer.Send(ctx, NewRevealingStartedMessage(room.CurrentQuestion.Question))
+	case domain.ShowingQuestion:
+		return internalServer.Send(ctx, NewQuestionStartedMessage(room.CurrentQuestion.Question))
+	case domain.Answering:
+		return internalServer.Send(ctx, NewAnswerStartedMessage(room.CurrentQuestion.Question, room.AnsweringPlayer.Id))
+	case domain.Betting:
+		return internalServer.Send(ctx, NewBettingStartedMessage(room.CurrentQuestion.Question))
+	case domain.Passing:
+		return internalServer.Send(ctx, NewPassingStartedMessage(room.CurrentQuestion.Question))
+	case domain.ChoosingPrice:
+		return internalServer.Send(ctx, NewPriceChoosingStartedMessage(room.CurrentQuestion.Question))
+	case domain.FinalRoundBetting:
+		return internalServer.Send(ctx, NewFinalRoundBettingStartedMessage())
+	case domain.ShowingFinalRoundQuestion, domain.TieBreaker:
+		return internalServer.Send(ctx, NewFinalRoundQuestionStartedMessage())
+	case domain.ValidatingFinalRoundAnswers:
+		if !room.Options.AIHost {
+			return nil
+		}
+		if room.FinalRoundState.SystemVerdicts == nil {
+			return internalServer.Send(ctx, NewFinalRoundAnswersSubmittedMessage())
+		}
+		return internalServer.Send(ctx, NewFinalRoundVerdictRevealMessage(*room.CurrentPlayer))
+	}
+	return nil
+}
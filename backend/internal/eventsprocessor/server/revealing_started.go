@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"log/slog"
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
@@ -22,51 +21,42 @@ func NewRevealingStartedMessage(question domain.Question) message.Message {
 	return message.Message{Event: domain.RevealingStarted, Payload: payload}
 }
 
-func HandleRevealingStartedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, msg message.Message) error {
-	var rsp RevealingStartedPayload
-	if err := json.Unmarshal(msg.Payload, &rsp); err != nil {
-		return err
-	}
+func HandleRevealingStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
 	room, err := roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return err
 	}
+	return scheduler.Schedule(ctx, room.CurrentQuestion.TimerStartsAt, msg)
+}
 
-	time.AfterFunc(time.Until(room.CurrentQuestion.TimerStartsAt), func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		_, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
-			questionEnded :=
-				newRoom.State != domain.RevealingQuestion ||
-					!rsp.IsCurrent(newRoom)
-			deadlineChanged := newRoom.CurrentQuestion != nil &&
-				!room.CurrentQuestion.TimerStartsAt.Equal(newRoom.CurrentQuestion.TimerStartsAt)
-			if questionEnded || deadlineChanged || newRoom.PausedState.Paused {
-				return ErrDeferredFunctionCancelled
-			}
-
-			newRoom.StartRegularQuestion()
-			return nil
-		})
-		if err != nil {
-			if err != ErrDeferredFunctionCancelled {
-				slog.Error("error", "err", err)
-			}
-			return
-		}
+func HandleRevealingStartedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, dueAt time.Time, msg message.Message) error {
+	var rsp RevealingStartedPayload
+	if err := json.Unmarshal(msg.Payload, &rsp); err != nil {
+		return err
+	}
 
-		roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
-		if err := server.Send(ctx, roomUpdatedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
+	_, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		questionEnded :=
+			newRoom.State != domain.RevealingQuestion ||
+				!rsp.IsCurrent(newRoom)
+		deadlineChanged := newRoom.CurrentQuestion != nil &&
+			!dueAt.Equal(newRoom.CurrentQuestion.TimerStartsAt)
+		if questionEnded || deadlineChanged || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
 		}
 
-		questionStartedMessage := NewQuestionStartedMessage(rsp.Question)
-		if err := internalServer.Send(ctx, questionStartedMessage); err != nil {
-			slog.Error("error", "err", err)
-			return
-		}
+		newRoom.StartRegularQuestion()
+		return nil
 	})
-	return nil
+	if err != nil {
+		return err
+	}
+
+	roomUpdatedMessage := outgoing.NewRoomUpdatedMessage(roomId)
+	if err := server.Send(ctx, roomUpdatedMessage); err != nil {
+		return err
+	}
+
+	questionStartedMessage := NewQuestionStartedMessage(rsp.Question)
+	return internalServer.Send(ctx, questionStartedMessage)
 }
@@ -0,0 +1,15 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+// Scheduler arms a room timer that hands msg back to the room's internal events
+// processor at dueAt. Timers are persisted, so a new owner of the room re-arms
+// them after the previous instance goes away.
+type Scheduler interface {
+	Schedule(ctx context.Context, dueAt time.Time, msg message.Message) error
+}
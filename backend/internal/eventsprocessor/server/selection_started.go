@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+func NewSelectionStartedMessage() message.Message {
+	return message.Message{Event: domain.SelectionStarted}
+}
+
+func HandleSelectionStartedMessage(ctx context.Context, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message) error {
+	room, err := roomCache.GetById(ctx, roomId)
+	if err != nil {
+		return err
+	}
+	if room.State != domain.SelectingQuestion || room.SelectionEndsAt == nil {
+		return nil
+	}
+	return scheduler.Schedule(ctx, *room.SelectionEndsAt, msg)
+}
+
+// HandleSelectionStartedTimer picks a random question for a side that ran out
+// of time to select, and shows it like a selected one before playing it.
+func HandleSelectionStartedTimer(ctx context.Context, server realtime.Channel, roomCache cache.Room, scheduler Scheduler, roomId string, pack *domain.Pack, demoDuration int, dueAt time.Time) error {
+	var category string
+	var index int
+	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if newRoom.State != domain.SelectingQuestion || newRoom.PausedState.Paused {
+			return ErrDeferredFunctionCancelled
+		}
+		if newRoom.SelectionEndsAt == nil || !dueAt.Equal(*newRoom.SelectionEndsAt) {
+			return ErrDeferredFunctionCancelled
+		}
+		var err error
+		category, index, err = newRoom.HoldRandomQuestion()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	question, err := pack.GetQuestion(*newRoom.CurrentRoundName, category, index)
+	if err != nil {
+		return err
+	}
+	packCategory, err := pack.GetCategory(*newRoom.CurrentRoundName, category)
+	if err != nil {
+		return err
+	}
+	if err := server.Send(ctx, outgoing.NewQuestionDemoMessage(*question, packCategory.Comment, demoDuration)); err != nil {
+		return err
+	}
+	return scheduler.Schedule(ctx, time.Now().Add(time.Duration(demoDuration)*time.Second), NewQuestionAutoSelectedMessage(category, index))
+}
+
+type questionAutoSelectedPayload struct {
+	Category string `json:"category"`
+	Index    int    `json:"index"`
+}
+
+func NewQuestionAutoSelectedMessage(category string, index int) message.Message {
+	payload, _ := json.Marshal(questionAutoSelectedPayload{Category: category, Index: index})
+	return message.Message{Event: domain.QuestionAutoSelected, Payload: payload}
+}
+
+func HandleQuestionAutoSelectedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, getAttachmentUrl func(key string) (string, error), roomId string, pack *domain.Pack, msg message.Message) error {
+	var qasp questionAutoSelectedPayload
+	if err := json.Unmarshal(msg.Payload, &qasp); err != nil {
+		return err
+	}
+
+	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		return newRoom.SelectQuestionAuto(pack, qasp.Category, qasp.Index, getAttachmentUrl)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+	return Reschedule(ctx, internalServer, newRoom)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
 	"github.com/holdennekt/sgame/backend/internal/interface/cache"
 	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
@@ -31,7 +32,7 @@ func NewUserDisconnectedMessage(userId string) message.Message {
 	return message.Message{Event: domain.UserDisconnected, Payload: payload}
 }
 
-func HandleUserDisconnectedMessage(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, lobbyServer realtime.Channel, roomCache cache.Room, roomRepository repository.Room, roomId string, msg message.Message, idleRoomTTL time.Duration) error {
+func HandleUserDisconnectedMessage(ctx context.Context, server realtime.Channel, roomCache cache.Room, scheduler Scheduler, roomId string, msg message.Message, idleRoomTTL, moderatorFallback time.Duration) error {
 	var udp userDisconnectedPayload
 	if err := json.Unmarshal(msg.Payload, &udp); err != nil {
 		return err
@@ -46,38 +47,135 @@ func HandleUserDisconnectedMessage(ctx context.Context, server realtime.Channel,
 		return p.IsConnected
 	})
 	if !isModeratorConnected && connectedPlayerIndex == -1 {
-		if err := roomCache.Expire(ctx, roomId, idleRoomTTL); err != nil {
+		// the timer is scheduled first so its keys get the idle TTL as well
+		if err := scheduler.Schedule(ctx, time.Now().Add(idleRoomTTL+EXPIRE_GRACE_PERIOD), NewRoomExpiredMessage(room)); err != nil {
 			return err
 		}
-		time.AfterFunc(idleRoomTTL+EXPIRE_GRACE_PERIOD, func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			newRoom, err := roomCache.GetById(ctx, roomId)
-			if newRoom != nil {
-				return
-			}
-			if _, ok := err.(custerr.NotFoundErr); !ok {
-				slog.Error("error", "err", err)
-				return
-			}
-			metrics.RoomsActive.Dec()
-			deletedRoomMsg := outgoing.NewRoomDeletedMessage(roomId)
-			if err := lobbyServer.Send(ctx, deletedRoomMsg); err != nil {
-				slog.Error("error", "err", err)
-			}
-			if err := server.Send(ctx, deletedRoomMsg); err != nil {
-				slog.Error("error", "err", err)
-			}
-			if err := internalServer.Send(ctx, deletedRoomMsg); err != nil {
-				slog.Error("error", "err", err)
-			}
-			if room.State != domain.WaitingForStart && room.State != domain.GameOver {
-				if err := roomRepository.Create(ctx, room); err != nil {
-					slog.Error("error", "err", err)
-				}
-			}
-		})
-	}
-	return nil
+		return roomCache.Expire(ctx, roomId, idleRoomTTL)
+	}
+	if room.IsUserModerator(udp.UserId) && !isModeratorConnected && room.State != domain.GameOver {
+		if err := scheduler.Schedule(ctx, time.Now().Add(moderatorFallback), NewModeratorFallbackMessage(udp.UserId)); err != nil {
+			return err
+		}
+	}
+	return pauseForDisconnect(ctx, server, roomCache, scheduler, roomId, udp.UserId)
+}
+
+// pauseForDisconnect pauses the game if it can't go on without the user, and
+// gives them the room's grace period to come back before moving on without
+// them.
+func pauseForDisconnect(ctx context.Context, server realtime.Channel, roomCache cache.Room, scheduler Scheduler, roomId, userId string) error {
+	var paused bool
+	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+		paused = room.PauseForDisconnect(userId)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !paused {
+		return nil
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+	chatMsg := client.NewSystemChatMessage("Game was paused until the disconnected user is back")
+	if err := server.Send(ctx, chatMsg); err != nil {
+		return err
+	}
+
+	gracePeriod := domain.MaxPauseDuration
+	if newRoom.Options.DisconnectGracePeriod > 0 {
+		gracePeriod = time.Duration(newRoom.Options.DisconnectGracePeriod) * time.Second
+	}
+	pausedAt := *newRoom.PausedState.PausedAt
+	return scheduler.Schedule(ctx, pausedAt.Add(gracePeriod), NewDisconnectGraceEndedMessage(pausedAt))
+}
+
+type disconnectGraceEndedPayload struct {
+	PausedAt time.Time `json:"pausedAt"`
+}
+
+func NewDisconnectGraceEndedMessage(pausedAt time.Time) message.Message {
+	payload, _ := json.Marshal(disconnectGraceEndedPayload{PausedAt: pausedAt})
+	return message.Message{Event: domain.DisconnectGraceEnded, Payload: payload}
+}
+
+func HandleDisconnectGraceEndedTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, roomCache cache.Room, roomId string, msg message.Message) error {
+	var dgep disconnectGraceEndedPayload
+	if err := json.Unmarshal(msg.Payload, &dgep); err != nil {
+		return err
+	}
+
+	newRoom, err := roomCache.SafeUpdate(ctx, roomId, func(newRoom *domain.Room) error {
+		if !newRoom.IsPauseSession(dgep.PausedAt) || newRoom.PausedState.AwaitingUserId == nil {
+			return ErrDeferredFunctionCancelled
+		}
+		newRoom.HandOverTurn()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := server.Send(ctx, outgoing.NewRoomUpdatedMessage(roomId)); err != nil {
+		return err
+	}
+	chatMsg := client.NewSystemChatMessage("Game was resumed without the disconnected user")
+	if err := server.Send(ctx, chatMsg); err != nil {
+		return err
+	}
+	return Reschedule(ctx, internalServer, newRoom)
+}
+
+type roomExpiredPayload struct {
+	Room *domain.Room `json:"room"`
+}
+
+// NewRoomExpiredMessage carries the room as it was when the last user left, since
+// the cached copy is gone by the time the timer fires.
+func NewRoomExpiredMessage(room *domain.Room) message.Message {
+	payload, _ := json.Marshal(roomExpiredPayload{Room: room})
+	return message.Message{Event: domain.RoomExpired, Payload: payload}
+}
+
+func HandleRoomExpiredTimer(ctx context.Context, server realtime.Channel, internalServer realtime.Channel, lobbyServer realtime.Channel, roomCache cache.Room, roomRepository repository.Room, gameLogRepository repository.GameLog, roomId string, msg message.Message) error {
+	var rep roomExpiredPayload
+	if err := json.Unmarshal(msg.Payload, &rep); err != nil {
+		return err
+	}
+
+	newRoom, err := roomCache.GetById(ctx, roomId)
+	if newRoom != nil {
+		return nil
+	}
+	if _, ok := err.(custerr.NotFoundErr); !ok {
+		return err
+	}
+	metrics.RoomsActive.Dec()
+	deletedRoomMsg := outgoing.NewRoomDeletedMessage(roomId)
+	if err := lobbyServer.Send(ctx, deletedRoomMsg); err != nil {
+		slog.Error("error", "err", err)
+	}
+	if err := server.Send(ctx, deletedRoomMsg); err != nil {
+		slog.Error("error", "err", err)
+	}
+	if err := internalServer.Send(ctx, deletedRoomMsg); err != nil {
+		slog.Error("error", "err", err)
+	}
+	room := rep.Room
+	if room.State != domain.WaitingForStart && room.State != domain.GameOver {
+		room.Chat, err = roomCache.GetChat(ctx, roomId, 0)
+		if err != nil {
+			return err
+		}
+		if err := roomRepository.Create(ctx, room); err != nil {
+			return err
+		}
+		if _, err := saveGameLog(ctx, roomCache, gameLogRepository, roomId); err != nil {
+			return err
+		}
+	}
+	return roomCache.Delete(ctx, roomId)
 }
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	ivalidator "github.com/holdennekt/sgame/backend/internal/interface/validator"
+)
+
+func IsExactMatch(correctAnswers []string, playerAnswer string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(playerAnswer))
+	for _, correct := range correctAnswers {
+		if strings.ToLower(strings.TrimSpace(correct)) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+func BuildValidatorQuestion(text *string, attachment *domain.Attachment, answers []string, bucketName string) ivalidator.Question {
+	q := ivalidator.Question{
+		CorrectAnswers: answers,
+	}
+	if text != nil {
+		q.Text = *text
+	}
+	if attachment != nil {
+		q.MediaURI = "gs://" + bucketName + "/" + attachment.Key
+		q.MediaMIMEType = attachment.MimeType
+		switch attachment.Type {
+		case domain.Image:
+			q.MediaType = ivalidator.MediaTypeImage
+		case domain.Audio:
+			q.MediaType = ivalidator.MediaTypeAudio
+		case domain.Video:
+			q.MediaType = ivalidator.MediaTypeVideo
+		}
+	}
+	return q
+}
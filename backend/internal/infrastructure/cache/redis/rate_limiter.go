@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimiter counts actions in fixed windows: the counter key lives for one
+// window from the first action in it.
+type rateLimiter struct {
+	client *redis.Client
+}
+
+func NewRateLimiter(client *redis.Client) cache.RateLimiter {
+	return &rateLimiter{client: client}
+}
+
+func (l *rateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
+	var incr *redis.IntCmd
+	_, err := l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return false, custerr.NewInternalErr(err)
+	}
+	return incr.Val() <= int64(limit), nil
+}
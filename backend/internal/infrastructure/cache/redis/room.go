@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
 	"time"
 
 	"github.com/bsm/redislock"
@@ -28,6 +29,22 @@ func getSpectatorsKey(id string) string {
 	return domain.ROOM_PREFIX + id + domain.SPECTATORS_POSTFIX
 }
 
+func getSpectatorUsersKey(id string) string {
+	return domain.ROOM_PREFIX + id + domain.SPECTATOR_USERS_POSTFIX
+}
+
+func getGameLogKey(id string) string {
+	return domain.ROOM_PREFIX + id + domain.GAME_LOG_POSTFIX
+}
+
+func getChatKey(id string) string {
+	return domain.ROOM_PREFIX + id + domain.CHAT_POSTFIX
+}
+
+func getUndoKey(id string) string {
+	return domain.ROOM_PREFIX + id + domain.UNDO_POSTFIX
+}
+
 type roomCache struct {
 	client *redis.Client
 	locker *redislock.Client
@@ -83,6 +100,16 @@ func (c *roomCache) Set(ctx context.Context, room *domain.Room) error {
 }
 
 func (c *roomCache) SafeUpdate(ctx context.Context, roomId string, updateFunc func(room *domain.Room) error) (*domain.Room, error) {
+	return c.safeUpdate(ctx, roomId, updateFunc, nil)
+}
+
+func (c *roomCache) SafeUpdateUndoable(ctx context.Context, roomId string, userId string, updateFunc func(room *domain.Room) error) (*domain.Room, error) {
+	return c.safeUpdate(ctx, roomId, updateFunc, &userId)
+}
+
+// safeUpdate snapshots the room for undo when undoBy moderates it. AI-hosted
+// rooms can not undo, so they are never snapshotted.
+func (c *roomCache) safeUpdate(ctx context.Context, roomId string, updateFunc func(room *domain.Room) error, undoBy *string) (*domain.Room, error) {
 	lock, err := c.waitAndLock(ctx, roomId)
 	if err != nil {
 		return nil, custerr.NewInternalErr(err)
@@ -94,18 +121,152 @@ func (c *roomCache) SafeUpdate(ctx context.Context, roomId string, updateFunc fu
 		return nil, err
 	}
 
+	var snapshot []byte
+	if undoBy != nil && room.IsUserModerator(*undoBy) && !room.Options.AIHost {
+		logLength, err := c.client.LLen(ctx, getGameLogKey(roomId)).Result()
+		if err != nil {
+			return nil, custerr.NewInternalErr(err)
+		}
+		snapshot, err = json.Marshal(domain.NewRoomSnapshot(*room, int(logLength)))
+		if err != nil {
+			return nil, custerr.NewInternalErr(err)
+		}
+	}
+
 	if err := updateFunc(room); err != nil {
 		return nil, err
 	}
 
-	if err := c.client.JSONSet(ctx, getKey(room.Id), "$", room).Err(); err != nil {
+	events := room.DrainEvents()
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.JSONSet(ctx, getKey(room.Id), "$", room)
+		if snapshot != nil {
+			pipe.LPush(ctx, getUndoKey(room.Id), snapshot)
+			pipe.LTrim(ctx, getUndoKey(room.Id), 0, domain.UndoStackSize-1)
+		}
+		return pushEvents(ctx, pipe, room.Id, events)
+	})
+	if err != nil {
 		return nil, custerr.NewInternalErr(err)
 	}
 	return room, nil
 }
 
+func (c *roomCache) Undo(ctx context.Context, roomId string, undoFunc func(room *domain.Room, snapshot domain.RoomSnapshot) error) (*domain.Room, error) {
+	lock, err := c.waitAndLock(ctx, roomId)
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	defer func() { _ = lock.Release(ctx) }()
+
+	room, err := c.GetById(ctx, roomId)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.LIndex(ctx, getUndoKey(roomId), 0).Result()
+	if err == redis.Nil {
+		return nil, custerr.NewConflictErr("nothing to undo")
+	}
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	var snapshot domain.RoomSnapshot
+	if err := json.Unmarshal([]byte(res), &snapshot); err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+
+	if err := undoFunc(room, snapshot); err != nil {
+		return nil, err
+	}
+
+	events := room.DrainEvents()
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.JSONSet(ctx, getKey(room.Id), "$", room)
+		pipe.LPop(ctx, getUndoKey(room.Id))
+		return pushEvents(ctx, pipe, room.Id, events)
+	})
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	return room, nil
+}
+
+func pushEvents(ctx context.Context, pipe redis.Pipeliner, roomId string, events []domain.GameEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	values := make([]any, len(events))
+	for i, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+	pipe.RPush(ctx, getGameLogKey(roomId), values...)
+	return nil
+}
+
+func (c *roomCache) GetGameLog(ctx context.Context, roomId string) ([]domain.GameEvent, error) {
+	values, err := c.client.LRange(ctx, getGameLogKey(roomId), 0, -1).Result()
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	events := make([]domain.GameEvent, len(values))
+	for i, value := range values {
+		if err := json.Unmarshal([]byte(value), &events[i]); err != nil {
+			return nil, custerr.NewInternalErr(err)
+		}
+	}
+	return events, nil
+}
+
+func (c *roomCache) AppendChat(ctx context.Context, roomId string, chatMessage domain.ChatMessage, maxLen int) error {
+	value, err := json.Marshal(chatMessage)
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	err = c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: getChatKey(roomId),
+		MaxLen: int64(maxLen),
+		Values: map[string]any{"payload": string(value)},
+	}).Err()
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (c *roomCache) GetChat(ctx context.Context, roomId string, count int) ([]domain.ChatMessage, error) {
+	var entries []redis.XMessage
+	var err error
+	if count == 0 {
+		entries, err = c.client.XRange(ctx, getChatKey(roomId), "-", "+").Result()
+	} else {
+		entries, err = c.client.XRevRangeN(ctx, getChatKey(roomId), "+", "-", int64(count)).Result()
+		slices.Reverse(entries)
+	}
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	chat := make([]domain.ChatMessage, 0, len(entries))
+	for _, entry := range entries {
+		value, ok := entry.Values["payload"].(string)
+		if !ok {
+			continue
+		}
+		var chatMessage domain.ChatMessage
+		if err := json.Unmarshal([]byte(value), &chatMessage); err != nil {
+			return nil, custerr.NewInternalErr(err)
+		}
+		chat = append(chat, chatMessage)
+	}
+	return chat, nil
+}
+
 func (c *roomCache) Delete(ctx context.Context, roomId string) error {
-	if err := c.client.Del(ctx, getKey(roomId), getLockKey(roomId), getSpectatorsKey(roomId)).Err(); err != nil {
+	if err := c.client.Del(ctx, getKey(roomId), getLockKey(roomId), getSpectatorsKey(roomId), getSpectatorUsersKey(roomId), getGameLogKey(roomId), getUndoKey(roomId), getChatKey(roomId)).Err(); err != nil {
 		return custerr.NewInternalErr(err)
 	}
 	return nil
@@ -129,15 +290,96 @@ func (c *roomCache) GetSpectatorCount(ctx context.Context, roomId string) (int,
 	return n, err
 }
 
+func (c *roomCache) AddSpectator(ctx context.Context, roomId string, user domain.User) error {
+	value, err := json.Marshal(user)
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	if err := c.client.HSet(ctx, getSpectatorUsersKey(roomId), user.Id, value).Err(); err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (c *roomCache) RemoveSpectator(ctx context.Context, roomId, userId string) error {
+	if err := c.client.HDel(ctx, getSpectatorUsersKey(roomId), userId).Err(); err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (c *roomCache) GetSpectator(ctx context.Context, roomId, userId string) (*domain.User, error) {
+	value, err := c.client.HGet(ctx, getSpectatorUsersKey(roomId), userId).Result()
+	if err == redis.Nil {
+		return nil, custerr.NewNotFoundErr("user is not watching this room")
+	}
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	var user domain.User
+	if err := json.Unmarshal([]byte(value), &user); err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	return &user, nil
+}
+
+func (c *roomCache) GetSpectators(ctx context.Context, roomId string) ([]domain.User, error) {
+	values, err := c.client.HVals(ctx, getSpectatorUsersKey(roomId)).Result()
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	spectators := make([]domain.User, 0, len(values))
+	for _, value := range values {
+		var user domain.User
+		if err := json.Unmarshal([]byte(value), &user); err != nil {
+			return nil, custerr.NewInternalErr(err)
+		}
+		spectators = append(spectators, user)
+	}
+	return spectators, nil
+}
+
+// ROOM_DATA_TTL_MARGIN is how much longer than the room state the rest of an
+// idle room's keys live. They are normally deleted with the room once it
+// expires; the TTL only keeps them from leaking when that never happens.
+const ROOM_DATA_TTL_MARGIN = time.Hour
+
+// roomDataKeys are the keys kept alongside the room state.
+func roomDataKeys(roomId string) []string {
+	return []string{
+		getSpectatorsKey(roomId),
+		getSpectatorUsersKey(roomId),
+		getGameLogKey(roomId),
+		getUndoKey(roomId),
+		getChatKey(roomId),
+		getTimersKey(roomId),
+		getTimerPayloadsKey(roomId),
+	}
+}
+
 func (c *roomCache) Expire(ctx context.Context, roomId string, duration time.Duration) error {
-	if err := c.client.Expire(ctx, getKey(roomId), duration).Err(); err != nil {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Expire(ctx, getKey(roomId), duration)
+		for _, key := range roomDataKeys(roomId) {
+			pipe.Expire(ctx, key, duration+ROOM_DATA_TTL_MARGIN)
+		}
+		return nil
+	})
+	if err != nil {
 		return custerr.NewInternalErr(err)
 	}
 	return nil
 }
 
 func (c *roomCache) Persist(ctx context.Context, roomId string) error {
-	if err := c.client.Persist(ctx, getKey(roomId)).Err(); err != nil {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Persist(ctx, getKey(roomId))
+		for _, key := range roomDataKeys(roomId) {
+			pipe.Persist(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
 		return custerr.NewInternalErr(err)
 	}
 	return nil
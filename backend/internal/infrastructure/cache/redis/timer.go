@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+	"github.com/redis/go-redis/v9"
+)
+
+func getTimersKey(id string) string {
+	return domain.ROOM_PREFIX + id + domain.TIMERS_POSTFIX
+}
+
+func getTimerPayloadsKey(id string) string {
+	return domain.ROOM_PREFIX + id + domain.TIMER_PAYLOADS_POSTFIX
+}
+
+// claimTimerScript pushes the score of a due timer to the end of the lease,
+// so no other instance can claim it until the lease runs out.
+var claimTimerScript = redis.NewScript(`
+local score = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if not score or tonumber(score) > tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[3], ARGV[1])
+return 1
+`)
+
+// timerCache keeps the ids of room timers in a sorted set scored by the time
+// they can be claimed at and the timers themselves in a hash keyed by id. A
+// timer stays in both until it is completed, so a claim that is never
+// completed only delays it by the lease.
+type timerCache struct {
+	client *redis.Client
+}
+
+func NewTimerCache(client *redis.Client) cache.Timer {
+	return &timerCache{client: client}
+}
+
+func (c *timerCache) Schedule(ctx context.Context, roomId string, scheduled cache.ScheduledMessage) error {
+	payload, err := json.Marshal(scheduled)
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, getTimerPayloadsKey(roomId), scheduled.Id, payload)
+		pipe.ZAdd(ctx, getTimersKey(roomId), redis.Z{Score: float64(scheduled.DueAt.UnixMilli()), Member: scheduled.Id})
+		return nil
+	})
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (c *timerCache) GetScheduled(ctx context.Context, roomId string) ([]cache.ScheduledMessage, error) {
+	timers, err := c.client.ZRangeWithScores(ctx, getTimersKey(roomId), 0, -1).Result()
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	if len(timers) == 0 {
+		return []cache.ScheduledMessage{}, nil
+	}
+	ids := make([]string, len(timers))
+	for i, timer := range timers {
+		ids[i] = timer.Member.(string)
+	}
+	payloads, err := c.client.HMGet(ctx, getTimerPayloadsKey(roomId), ids...).Result()
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	scheduled := make([]cache.ScheduledMessage, 0, len(ids))
+	for i, payload := range payloads {
+		value, ok := payload.(string)
+		if !ok {
+			continue
+		}
+		var sm cache.ScheduledMessage
+		if err := json.Unmarshal([]byte(value), &sm); err != nil {
+			return nil, custerr.NewInternalErr(err)
+		}
+		sm.ClaimableAt = time.UnixMilli(int64(timers[i].Score))
+		scheduled = append(scheduled, sm)
+	}
+	return scheduled, nil
+}
+
+func (c *timerCache) Claim(ctx context.Context, roomId string, id string, lease time.Duration) (bool, error) {
+	now := time.Now()
+	claimed, err := claimTimerScript.Run(ctx, c.client, []string{getTimersKey(roomId)},
+		id, now.UnixMilli(), now.Add(lease).UnixMilli()).Int()
+	if err != nil {
+		return false, custerr.NewInternalErr(err)
+	}
+	return claimed == 1, nil
+}
+
+func (c *timerCache) Complete(ctx context.Context, roomId string, id string) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, getTimersKey(roomId), id)
+		pipe.HDel(ctx, getTimerPayloadsKey(roomId), id)
+		return nil
+	})
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (c *timerCache) Delete(ctx context.Context, roomId string) error {
+	if err := c.client.Del(ctx, getTimersKey(roomId), getTimerPayloadsKey(roomId)).Err(); err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
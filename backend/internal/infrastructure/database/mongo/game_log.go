@@ -0,0 +1,63 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/repository"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const GAME_LOGS_COLLECTION = "game_logs"
+
+type gameLogRepository struct {
+	db *mongo.Database
+}
+
+func NewGameLogRepository(db *mongo.Database) repository.GameLog {
+	repo := gameLogRepository{db}
+	if err := repo.init(context.Background()); err != nil {
+		var mongoErr mongo.CommandError
+		if errors.As(err, &mongoErr) {
+			const CODE_NAMESPACE_EXISTS = 48
+			if mongoErr.Code == CODE_NAMESPACE_EXISTS {
+				return &repo
+			}
+		}
+
+		panic(fmt.Errorf("failed to initialize game log repository: %w", err))
+	}
+	return &repo
+}
+
+func (r *gameLogRepository) init(ctx context.Context) error {
+	return r.db.CreateCollection(ctx, GAME_LOGS_COLLECTION)
+}
+
+func (r *gameLogRepository) Create(ctx context.Context, gameLog *domain.GameLog) error {
+	_, err := r.db.Collection(GAME_LOGS_COLLECTION).InsertOne(ctx, gameLog)
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (r *gameLogRepository) GetByRoomId(ctx context.Context, roomId string) (*domain.GameLog, error) {
+	var gameLog domain.GameLog
+	err := r.db.Collection(GAME_LOGS_COLLECTION).FindOne(
+		ctx,
+		bson.M{"_id": roomId},
+	).Decode(&gameLog)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no game log for room \"%s\"", roomId))
+		}
+		return nil, custerr.NewInternalErr(err)
+	}
+
+	return &gameLog, nil
+}
@@ -58,16 +58,17 @@ func (r *packRepository) init(ctx context.Context) error {
 }
 
 type mongoPack struct {
-	Id             primitive.ObjectID `bson:"_id,omitempty"`
-	CreatedBy      domain.User        `bson:"createdBy"`
-	RoundsChecksum []byte             `bson:"roundsChecksum"`
-	Content        string             `bson:"content"`
-	Name           string             `bson:"name"`
-	Type           domain.PrivacyType `bson:"type"`
-	Rounds         []domain.Round     `bson:"rounds"`
-	FinalRound     domain.FinalRound  `bson:"finalRound"`
-	CreatedAt      time.Time          `bson:"createdAt"`
-	UpdatedAt      time.Time          `bson:"updatedAt"`
+	Id             primitive.ObjectID          `bson:"_id,omitempty"`
+	CreatedBy      domain.User                 `bson:"createdBy"`
+	RoundsChecksum []byte                      `bson:"roundsChecksum"`
+	Content        string                      `bson:"content"`
+	Name           string                      `bson:"name"`
+	Type           domain.PrivacyType          `bson:"type"`
+	Rounds         []domain.Round              `bson:"rounds"`
+	FinalRound     domain.FinalRound           `bson:"finalRound"`
+	TieBreakers    []domain.FinalRoundQuestion `bson:"tieBreakers"`
+	CreatedAt      time.Time                   `bson:"createdAt"`
+	UpdatedAt      time.Time                   `bson:"updatedAt"`
 }
 
 func fromDomainPack(pack *domain.Pack) *mongoPack {
@@ -81,6 +82,7 @@ func fromDomainPack(pack *domain.Pack) *mongoPack {
 		Type:           pack.Type,
 		Rounds:         pack.Rounds,
 		FinalRound:     pack.FinalRound,
+		TieBreakers:    pack.TieBreakers,
 		CreatedAt:      pack.CreatedAt,
 		UpdatedAt:      pack.UpdatedAt,
 	}
@@ -96,6 +98,7 @@ func toDomainPack(mPack *mongoPack) *domain.Pack {
 		Type:           mPack.Type,
 		Rounds:         mPack.Rounds,
 		FinalRound:     mPack.FinalRound,
+		TieBreakers:    mPack.TieBreakers,
 		CreatedAt:      mPack.CreatedAt,
 		UpdatedAt:      mPack.UpdatedAt,
 	}
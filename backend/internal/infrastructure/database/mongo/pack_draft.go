@@ -45,15 +45,16 @@ func (r *packDraftRepository) init(ctx context.Context) error {
 }
 
 type mongoPackDraft struct {
-	Id           primitive.ObjectID `bson:"_id,omitempty"`
-	CreatedBy    domain.User        `bson:"createdBy"`
-	Name         string             `bson:"name"`
-	Type         domain.PrivacyType `bson:"type"`
-	Rounds       []domain.Round     `bson:"rounds"`
-	FinalRound   domain.FinalRound  `bson:"finalRound"`
-	LinkedPackId *string            `bson:"linkedPackId"`
-	CreatedAt    time.Time          `bson:"createdAt"`
-	UpdatedAt    time.Time          `bson:"updatedAt"`
+	Id           primitive.ObjectID          `bson:"_id,omitempty"`
+	CreatedBy    domain.User                 `bson:"createdBy"`
+	Name         string                      `bson:"name"`
+	Type         domain.PrivacyType          `bson:"type"`
+	Rounds       []domain.Round              `bson:"rounds"`
+	FinalRound   domain.FinalRound           `bson:"finalRound"`
+	TieBreakers  []domain.FinalRoundQuestion `bson:"tieBreakers"`
+	LinkedPackId *string                     `bson:"linkedPackId"`
+	CreatedAt    time.Time                   `bson:"createdAt"`
+	UpdatedAt    time.Time                   `bson:"updatedAt"`
 }
 
 func fromDomainDraft(d *domain.PackDraft) *mongoPackDraft {
@@ -65,6 +66,7 @@ func fromDomainDraft(d *domain.PackDraft) *mongoPackDraft {
 		Type:         d.Type,
 		Rounds:       d.Rounds,
 		FinalRound:   d.FinalRound,
+		TieBreakers:  d.TieBreakers,
 		LinkedPackId: d.LinkedPackId,
 		CreatedAt:    d.CreatedAt,
 		UpdatedAt:    d.UpdatedAt,
@@ -90,6 +92,10 @@ func toDomainDraft(m *mongoPackDraft) *domain.PackDraft {
 	if finalRound.Categories == nil {
 		finalRound.Categories = []domain.FinalRoundCategory{}
 	}
+	tieBreakers := m.TieBreakers
+	if tieBreakers == nil {
+		tieBreakers = []domain.FinalRoundQuestion{}
+	}
 	return &domain.PackDraft{
 		Id:           m.Id.Hex(),
 		CreatedBy:    m.CreatedBy,
@@ -97,6 +103,7 @@ func toDomainDraft(m *mongoPackDraft) *domain.PackDraft {
 		Type:         m.Type,
 		Rounds:       rounds,
 		FinalRound:   finalRound,
+		TieBreakers:  tieBreakers,
 		LinkedPackId: m.LinkedPackId,
 		CreatedAt:    m.CreatedAt,
 		UpdatedAt:    m.UpdatedAt,
@@ -0,0 +1,274 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/dto"
+	"github.com/holdennekt/sgame/backend/internal/interface/repository"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	RATINGS_COLLECTION        = "ratings"
+	RATING_HISTORY_COLLECTION = "rating_history"
+)
+
+type ratingRepository struct {
+	db *mongo.Database
+}
+
+func NewRatingRepository(db *mongo.Database) repository.Rating {
+	repo := ratingRepository{db}
+	if err := repo.init(context.Background()); err != nil {
+		var mongoErr mongo.CommandError
+		if errors.As(err, &mongoErr) {
+			const CODE_NAMESPACE_EXISTS = 48
+			if mongoErr.Code == CODE_NAMESPACE_EXISTS {
+				return &repo
+			}
+		}
+
+		panic(fmt.Errorf("failed to initialize rating repository: %w", err))
+	}
+	return &repo
+}
+
+func (r *ratingRepository) init(ctx context.Context) error {
+	if err := r.db.CreateCollection(ctx, RATINGS_COLLECTION); err != nil {
+		return err
+	}
+	_, err := r.db.Collection(RATINGS_COLLECTION).Indexes().CreateMany(
+		ctx,
+		[]mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "pool", Value: 1}},
+				Options: options.Index().SetName("user_pool_unique").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "pool", Value: 1}, {Key: "rating", Value: -1}},
+				Options: options.Index().SetName("pool_rating"),
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if err := r.db.CreateCollection(ctx, RATING_HISTORY_COLLECTION); err != nil {
+		return err
+	}
+	_, err = r.db.Collection(RATING_HISTORY_COLLECTION).Indexes().CreateMany(
+		ctx,
+		[]mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "pool", Value: 1}, {Key: "at", Value: -1}},
+				Options: options.Index().SetName("user_pool_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "pool", Value: 1}, {Key: "at", Value: -1}},
+				Options: options.Index().SetName("pool_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "pool", Value: 1}, {Key: "packId", Value: 1}, {Key: "at", Value: -1}},
+				Options: options.Index().SetName("pool_pack_at"),
+			},
+		},
+	)
+	return err
+}
+
+func (r *ratingRepository) GetByUsers(ctx context.Context, pool domain.RatingPool, userIds []string) (map[string]float64, error) {
+	cur, err := r.db.Collection(RATINGS_COLLECTION).Find(
+		ctx,
+		bson.M{"pool": pool, "userId": bson.M{"$in": userIds}},
+	)
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	ratings := make([]domain.Rating, 0)
+	if err := cur.All(ctx, &ratings); err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	result := make(map[string]float64, len(ratings))
+	for _, rating := range ratings {
+		result[rating.UserId] = rating.Rating
+	}
+	return result, nil
+}
+
+func (r *ratingRepository) GetByUser(ctx context.Context, userId string) ([]domain.Rating, error) {
+	cur, err := r.db.Collection(RATINGS_COLLECTION).Find(ctx, bson.M{"userId": userId})
+	if err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	ratings := make([]domain.Rating, 0)
+	if err := cur.All(ctx, &ratings); err != nil {
+		return nil, custerr.NewInternalErr(err)
+	}
+	return ratings, nil
+}
+
+// Apply moves each rating by its change's delta rather than overwriting it, so
+// games of the same player finishing concurrently do not lose updates.
+func (r *ratingRepository) Apply(ctx context.Context, changes []domain.RatingChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	for _, change := range changes {
+		_, err := r.db.Collection(RATINGS_COLLECTION).UpdateOne(
+			ctx,
+			bson.M{"userId": change.UserId, "pool": change.Pool},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{
+				"name":      change.Name,
+				"updatedAt": change.At,
+				"rating": bson.M{"$add": bson.A{
+					bson.M{"$ifNull": bson.A{"$rating", domain.InitialRating}},
+					change.After - change.Before,
+				}},
+				"games": bson.M{"$add": bson.A{
+					bson.M{"$ifNull": bson.A{"$games", 0}},
+					1,
+				}},
+			}}}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return custerr.NewInternalErr(err)
+		}
+	}
+
+	history := make([]any, len(changes))
+	for i, change := range changes {
+		history[i] = change
+	}
+	if _, err := r.db.Collection(RATING_HISTORY_COLLECTION).InsertMany(ctx, history); err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (r *ratingRepository) GetHistory(ctx context.Context, userId string, pool domain.RatingPool, search dto.SearchRequest) ([]domain.RatingChange, int, error) {
+	filter := bson.M{"userId": userId, "pool": pool}
+	total, err := r.db.Collection(RATING_HISTORY_COLLECTION).CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	cur, err := r.db.Collection(RATING_HISTORY_COLLECTION).Find(
+		ctx,
+		filter,
+		options.Find().
+			SetSort(bson.D{{Key: "at", Value: -1}}).
+			SetSkip(int64((search.Page-1)*search.Limit)).
+			SetLimit(int64(search.Limit)),
+	)
+	if err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	changes := make([]domain.RatingChange, 0)
+	if err := cur.All(ctx, &changes); err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	return changes, int(total), nil
+}
+
+func (r *ratingRepository) GetLeaderboard(ctx context.Context, pool domain.RatingPool, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error) {
+	filter := bson.M{
+		"pool": pool,
+		"name": primitive.Regex{Pattern: search.SearchRequest, Options: "i"},
+	}
+	total, err := r.db.Collection(RATINGS_COLLECTION).CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	cur, err := r.db.Collection(RATINGS_COLLECTION).Find(
+		ctx,
+		filter,
+		options.Find().
+			SetSort(bson.D{{Key: "rating", Value: -1}, {Key: "userId", Value: 1}}).
+			SetSkip(int64((search.Page-1)*search.Limit)).
+			SetLimit(int64(search.Limit)),
+	)
+	if err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	ratings := make([]domain.Rating, 0)
+	if err := cur.All(ctx, &ratings); err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	entries := make([]domain.LeaderboardEntry, len(ratings))
+	for i, rating := range ratings {
+		entries[i] = domain.LeaderboardEntry{
+			UserId: rating.UserId,
+			Name:   rating.Name,
+			Rating: rating.Rating,
+			Games:  rating.Games,
+		}
+	}
+	return entries, int(total), nil
+}
+
+func (r *ratingRepository) GetPeriodLeaderboard(ctx context.Context, pool domain.RatingPool, from, to time.Time, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error) {
+	return r.getHistoryLeaderboard(ctx, bson.M{"pool": pool, "at": bson.M{"$gte": from, "$lt": to}}, search)
+}
+
+func (r *ratingRepository) GetPackLeaderboard(ctx context.Context, pool domain.RatingPool, packId string, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error) {
+	return r.getHistoryLeaderboard(ctx, bson.M{"pool": pool, "packId": packId}, search)
+}
+
+// getHistoryLeaderboard ranks the players of the matching games by the rating
+// they reached after their latest one.
+func (r *ratingRepository) getHistoryLeaderboard(ctx context.Context, filter bson.M, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$userId",
+			"name":   bson.M{"$first": "$name"},
+			"rating": bson.M{"$first": "$after"},
+			"games":  bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"name": primitive.Regex{Pattern: search.SearchRequest, Options: "i"}}}},
+		{{Key: "$facet", Value: bson.M{
+			"items": bson.A{
+				bson.M{"$sort": bson.D{{Key: "rating", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$skip": (search.Page - 1) * search.Limit},
+				bson.M{"$limit": search.Limit},
+			},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	}
+	cur, err := r.db.Collection(RATING_HISTORY_COLLECTION).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+
+	var result []struct {
+		Items []domain.LeaderboardEntry `bson:"items"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+	}
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, 0, custerr.NewInternalErr(err)
+	}
+	if len(result) == 0 || len(result[0].Total) == 0 {
+		return make([]domain.LeaderboardEntry, 0), 0, nil
+	}
+	return result[0].Items, result[0].Total[0].Count, nil
+}
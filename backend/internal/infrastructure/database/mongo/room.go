@@ -89,7 +89,8 @@ func (r *roomRepository) GetByParticipant(ctx context.Context, userId string, se
 		options.Find().
 			SetSort(bson.D{{Key: orderBy, Value: sortDir}}).
 			SetSkip(int64((search.Page-1)*search.Limit)).
-			SetLimit(int64(search.Limit)),
+			SetLimit(int64(search.Limit)).
+			SetProjection(bson.M{"chat": 0}),
 	)
 	if err != nil {
 		return nil, 0, custerr.NewInternalErr(err)
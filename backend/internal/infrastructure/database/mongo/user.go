@@ -62,8 +62,9 @@ type MongoUser struct {
 
 type mongoDbUser struct {
 	MongoUser `bson:"inline"`
-	Login     string `bson:"login"`
-	Password  string `bson:"password"`
+	Login     string   `bson:"login"`
+	Password  string   `bson:"password"`
+	BlockList []string `bson:"blockList"`
 }
 
 func fromDomainDbUser(dbUser *domain.DbUser) *mongoDbUser {
@@ -74,8 +75,9 @@ func fromDomainDbUser(dbUser *domain.DbUser) *mongoDbUser {
 			Name:   dbUser.Name,
 			Avatar: dbUser.Avatar,
 		},
-		Login:    dbUser.Login,
-		Password: dbUser.Password,
+		Login:     dbUser.Login,
+		Password:  dbUser.Password,
+		BlockList: dbUser.BlockList,
 	}
 }
 
@@ -86,8 +88,9 @@ func toDomainDbUser(dbUser *mongoDbUser) *domain.DbUser {
 			Name:   dbUser.Name,
 			Avatar: dbUser.Avatar,
 		},
-		Login:    dbUser.Login,
-		Password: dbUser.Password,
+		Login:     dbUser.Login,
+		Password:  dbUser.Password,
+		BlockList: dbUser.BlockList,
 	}
 }
 
@@ -171,3 +174,54 @@ func (r *userRepository) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (r *userRepository) Block(ctx context.Context, id, targetId string) error {
+	return r.updateBlockList(ctx, id, bson.M{"$addToSet": bson.M{"blockList": targetId}})
+}
+
+func (r *userRepository) Unblock(ctx context.Context, id, targetId string) error {
+	return r.updateBlockList(ctx, id, bson.M{"$pull": bson.M{"blockList": targetId}})
+}
+
+func (r *userRepository) updateBlockList(ctx context.Context, id string, update bson.M) error {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return custerr.NewBadRequestErr(fmt.Sprintf("\"%s\" is an invalid id", id))
+	}
+
+	res, err := r.db.Collection(USERS_COLLECTION).UpdateOne(
+		ctx,
+		bson.M{"_id": objId},
+		update,
+	)
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	if res.MatchedCount == 0 {
+		return custerr.NewNotFoundErr(fmt.Sprintf("no user with id \"%s\"", id))
+	}
+	return nil
+}
+
+func (r *userRepository) IsBlocked(ctx context.Context, userId, otherId string) (bool, error) {
+	// guests have no account, so only registered users can block anybody
+	filters := bson.A{}
+	if objId, err := primitive.ObjectIDFromHex(userId); err == nil {
+		filters = append(filters, bson.M{"_id": objId, "blockList": otherId})
+	}
+	if objId, err := primitive.ObjectIDFromHex(otherId); err == nil {
+		filters = append(filters, bson.M{"_id": objId, "blockList": userId})
+	}
+	if len(filters) == 0 {
+		return false, nil
+	}
+
+	count, err := r.db.Collection(USERS_COLLECTION).CountDocuments(
+		ctx,
+		bson.M{"$or": filters},
+	)
+	if err != nil {
+		return false, custerr.NewInternalErr(err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/interface/repository"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const USER_STATS_COLLECTION = "user_stats"
+
+type userStatsRepository struct {
+	db *mongo.Database
+}
+
+func NewUserStatsRepository(db *mongo.Database) repository.UserStats {
+	repo := userStatsRepository{db}
+	if err := repo.init(context.Background()); err != nil {
+		var mongoErr mongo.CommandError
+		if errors.As(err, &mongoErr) {
+			const CODE_NAMESPACE_EXISTS = 48
+			if mongoErr.Code == CODE_NAMESPACE_EXISTS {
+				return &repo
+			}
+		}
+
+		panic(fmt.Errorf("failed to initialize user stats repository: %w", err))
+	}
+	return &repo
+}
+
+func (r *userStatsRepository) init(ctx context.Context) error {
+	return r.db.CreateCollection(ctx, USER_STATS_COLLECTION)
+}
+
+func (r *userStatsRepository) Increment(ctx context.Context, stats *domain.UserStats) error {
+	_, err := r.db.Collection(USER_STATS_COLLECTION).UpdateOne(
+		ctx,
+		bson.M{"_id": stats.UserId},
+		bson.M{"$inc": bson.M{
+			"gamesPlayed":         stats.GamesPlayed,
+			"gamesWon":            stats.GamesWon,
+			"totalScore":          stats.TotalScore,
+			"correctAnswers":      stats.CorrectAnswers,
+			"incorrectAnswers":    stats.IncorrectAnswers,
+			"buzzes":              stats.Buzzes,
+			"timedBuzzes":         stats.TimedBuzzes,
+			"totalBuzzReactionMs": stats.TotalBuzzReactionMs,
+			"auctionsPlayed":      stats.AuctionsPlayed,
+			"auctionsWon":         stats.AuctionsWon,
+			"catInBagPlayed":      stats.CatInBagPlayed,
+			"catInBagWon":         stats.CatInBagWon,
+			"finalRoundsPlayed":   stats.FinalRoundsPlayed,
+			"finalRoundsCorrect":  stats.FinalRoundsCorrect,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return custerr.NewInternalErr(err)
+	}
+	return nil
+}
+
+func (r *userStatsRepository) GetByUserId(ctx context.Context, userId string) (*domain.UserStats, error) {
+	var stats domain.UserStats
+	err := r.db.Collection(USER_STATS_COLLECTION).FindOne(
+		ctx,
+		bson.M{"_id": userId},
+	).Decode(&stats)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, custerr.NewNotFoundErr(fmt.Sprintf("no stats for user \"%s\"", userId))
+		}
+		return nil, custerr.NewInternalErr(err)
+	}
+
+	return &stats, nil
+}
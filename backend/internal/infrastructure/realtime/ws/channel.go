@@ -6,6 +6,8 @@ import (
 	"io"
 	"log/slog"
 	"net"
+	"sync/atomic"
+	"time"
 
 	"github.com/coder/websocket"
 	"github.com/coder/websocket/wsjson"
@@ -13,8 +15,14 @@ import (
 	"github.com/holdennekt/sgame/backend/pkg/custerr"
 )
 
+const (
+	PING_INTERVAL = 5 * time.Second
+	PING_TIMEOUT  = 5 * time.Second
+)
+
 type channel struct {
 	conn *websocket.Conn
+	rtt  atomic.Int64
 }
 
 func NewChannel(conn *websocket.Conn) *channel {
@@ -22,6 +30,7 @@ func NewChannel(conn *websocket.Conn) *channel {
 }
 
 func (c *channel) Send(ctx context.Context, msg message.Message) error {
+	msg.ServerTime = message.ServerTime()
 	if err := wsjson.Write(ctx, c.conn, msg); err != nil {
 		return custerr.NewInternalErr(err)
 	}
@@ -30,8 +39,11 @@ func (c *channel) Send(ctx context.Context, msg message.Message) error {
 
 func (c *channel) Receive(ctx context.Context) <-chan message.Message {
 	messages := make(chan message.Message)
+	pingCtx, stopPing := context.WithCancel(ctx)
+	go c.measureRTT(pingCtx)
 	go func() {
 		defer func() {
+			stopPing()
 			close(messages)
 			_ = c.conn.Close(websocket.StatusNormalClosure, "context done or error")
 		}()
@@ -57,6 +69,45 @@ func (c *channel) Receive(ctx context.Context) <-chan message.Message {
 	return messages
 }
 
+// RTT is the smoothed round trip time of the connection, zero until the first
+// pong arrives.
+func (c *channel) RTT() time.Duration {
+	return time.Duration(c.rtt.Load())
+}
+
+// measureRTT pings the peer until ctx is done. Pongs are read by Receive, so it
+// only runs alongside it.
+func (c *channel) measureRTT(ctx context.Context) {
+	ticker := time.NewTicker(PING_INTERVAL)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		pingCtx, cancel := context.WithTimeout(ctx, PING_TIMEOUT)
+		err := c.conn.Ping(pingCtx)
+		cancel()
+		if err == nil {
+			c.observeRTT(time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// observeRTT smooths samples like TCP does (RFC 6298, alpha = 1/8) so a
+// single slow pong does not swing buzzer arbitration.
+func (c *channel) observeRTT(sample time.Duration) {
+	prev := c.rtt.Load()
+	if prev == 0 {
+		c.rtt.Store(int64(sample))
+		return
+	}
+	c.rtt.Store(prev + (int64(sample)-prev)/8)
+}
+
 func (c *channel) Delete(_ context.Context) error { return nil }
 
 func (c *channel) Close() error {
@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"strings"
+	"unicode"
+
+	ivalidator "github.com/holdennekt/sgame/backend/internal/interface/validator"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+// WordFilter rejects chat messages containing any of the banned words. Words
+// are matched whole and case-insensitively.
+type WordFilter struct {
+	words map[string]struct{}
+}
+
+func NewWordFilter(words []string) ivalidator.ChatFilter {
+	f := &WordFilter{words: make(map[string]struct{}, len(words))}
+	for _, word := range words {
+		f.words[strings.ToLower(word)] = struct{}{}
+	}
+	return f
+}
+
+func (f *WordFilter) Filter(text string) (string, error) {
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, token := range tokens {
+		if _, ok := f.words[token]; ok {
+			return "", custerr.NewBadRequestErr("message contains forbidden words")
+		}
+	}
+	return text, nil
+}
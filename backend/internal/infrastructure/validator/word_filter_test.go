@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+func TestWordFilter(t *testing.T) {
+	f := NewWordFilter([]string{"Darn", "heck"})
+	tests := []struct {
+		name     string
+		text     string
+		rejected bool
+	}{
+		{name: "clean message passes", text: "good luck everyone", rejected: false},
+		{name: "banned word is rejected", text: "oh darn it", rejected: true},
+		{name: "matching ignores case", text: "HECK!", rejected: true},
+		{name: "punctuation separates words", text: "what,the-heck?", rejected: true},
+		{name: "only whole words match", text: "darned checks", rejected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := f.Filter(tt.text)
+			if tt.rejected {
+				if _, ok := err.(custerr.BadRequestErr); !ok {
+					t.Fatalf("expected a bad request error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text != tt.text {
+				t.Errorf("text = %q, want %q", text, tt.text)
+			}
+		})
+	}
+}
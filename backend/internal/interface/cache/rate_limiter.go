@@ -0,0 +1,12 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type RateLimiter interface {
+	// Allow counts an action under key and reports whether it still fits into
+	// limit actions per window.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, error)
+}
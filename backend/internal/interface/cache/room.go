@@ -12,7 +12,25 @@ type Room interface {
 	Get(ctx context.Context) ([]domain.RoomLobby, error)
 	Set(ctx context.Context, room *domain.Room) error
 	SafeUpdate(ctx context.Context, roomId string, updateFunc func(room *domain.Room) error) (*domain.Room, error)
+	// SafeUpdateUndoable is SafeUpdate that also pushes the room as it was
+	// before the update onto the room's undo stack when userId moderates the
+	// room. Every moderator action goes through it, except the ones on live
+	// state that an undo keeps: pausing, bans, mutes and moderator transfer.
+	SafeUpdateUndoable(ctx context.Context, roomId string, userId string, updateFunc func(room *domain.Room) error) (*domain.Room, error)
+	// Undo pops the latest snapshot off the undo stack and lets undoFunc
+	// restore the room from it.
+	Undo(ctx context.Context, roomId string, undoFunc func(room *domain.Room, snapshot domain.RoomSnapshot) error) (*domain.Room, error)
+	GetGameLog(ctx context.Context, roomId string) ([]domain.GameEvent, error)
+	// AppendChat adds a message to the room's chat history, keeping only the
+	// latest maxLen messages.
+	AppendChat(ctx context.Context, roomId string, chatMessage domain.ChatMessage, maxLen int) error
+	// GetChat returns up to the latest count messages of the room's chat
+	// history, oldest first; zero count returns all of them.
+	GetChat(ctx context.Context, roomId string, count int) ([]domain.ChatMessage, error)
 	Delete(ctx context.Context, roomId string) error
+	// Expire lets an idle room's state expire after duration; the rest of the
+	// room's keys get a longer TTL so they are cleaned up even when the room
+	// is never deleted. Persist undoes it.
 	Expire(ctx context.Context, roomId string, duration time.Duration) error
 	Persist(ctx context.Context, roomId string) error
 	TrySetOwner(ctx context.Context, roomId string, ttl time.Duration) (bool, error)
@@ -21,4 +39,8 @@ type Room interface {
 	IncrSpectators(ctx context.Context, roomId string) (int, error)
 	DecrSpectators(ctx context.Context, roomId string) (int, error)
 	GetSpectatorCount(ctx context.Context, roomId string) (int, error)
+	AddSpectator(ctx context.Context, roomId string, user domain.User) error
+	RemoveSpectator(ctx context.Context, roomId, userId string) error
+	GetSpectator(ctx context.Context, roomId, userId string) (*domain.User, error)
+	GetSpectators(ctx context.Context, roomId string) ([]domain.User, error)
 }
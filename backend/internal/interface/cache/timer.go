@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/message"
+)
+
+// ScheduledMessage is a room timer: Msg is delivered back to the room's internal
+// events processor once DueAt is reached. ClaimableAt is when the timer can
+// next be claimed: DueAt, or the end of the lease of an instance handling it.
+type ScheduledMessage struct {
+	Id          string          `json:"id"`
+	DueAt       time.Time       `json:"dueAt"`
+	Msg         message.Message `json:"msg"`
+	ClaimableAt time.Time       `json:"-"`
+}
+
+type Timer interface {
+	Schedule(ctx context.Context, roomId string, scheduled ScheduledMessage) error
+	GetScheduled(ctx context.Context, roomId string) ([]ScheduledMessage, error)
+	// Claim leases a due timer to the caller for lease and reports whether
+	// this call won it, so a timer is handled by one instance at a time. A
+	// timer that is not completed before its lease ends can be claimed again.
+	Claim(ctx context.Context, roomId string, id string, lease time.Duration) (bool, error)
+	// Complete removes a handled timer.
+	Complete(ctx context.Context, roomId string, id string) error
+	Delete(ctx context.Context, roomId string) error
+}
@@ -2,6 +2,7 @@ package realtime
 
 import (
 	"context"
+	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/message"
 )
@@ -13,6 +14,12 @@ type Channel interface {
 	Delete(ctx context.Context) error
 }
 
+// LatencyMeter is implemented by channels that measure the round trip time to
+// their peer.
+type LatencyMeter interface {
+	RTT() time.Duration
+}
+
 type ChannelGetter interface {
 	Get(name string) Channel
 }
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+)
+
+type GameLog interface {
+	Create(ctx context.Context, gameLog *domain.GameLog) error
+	GetByRoomId(ctx context.Context, roomId string) (*domain.GameLog, error)
+}
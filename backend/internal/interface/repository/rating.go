@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/dto"
+)
+
+type Rating interface {
+	GetByUsers(ctx context.Context, pool domain.RatingPool, userIds []string) (map[string]float64, error)
+	GetByUser(ctx context.Context, userId string) ([]domain.Rating, error)
+	Apply(ctx context.Context, changes []domain.RatingChange) error
+	GetHistory(ctx context.Context, userId string, pool domain.RatingPool, search dto.SearchRequest) ([]domain.RatingChange, int, error)
+	GetLeaderboard(ctx context.Context, pool domain.RatingPool, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error)
+	GetPeriodLeaderboard(ctx context.Context, pool domain.RatingPool, from, to time.Time, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error)
+	GetPackLeaderboard(ctx context.Context, pool domain.RatingPool, packId string, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error)
+}
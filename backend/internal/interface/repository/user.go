@@ -12,4 +12,8 @@ type User interface {
 	GetByLogin(ctx context.Context, login string) (*domain.DbUser, error)
 	Update(ctx context.Context, dbUser *domain.DbUser) error
 	Delete(ctx context.Context, id string) error
+	Block(ctx context.Context, id, targetId string) error
+	Unblock(ctx context.Context, id, targetId string) error
+	// IsBlocked reports whether either of the users has blocked the other.
+	IsBlocked(ctx context.Context, userId, otherId string) (bool, error)
 }
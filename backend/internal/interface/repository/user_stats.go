@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+)
+
+type UserStats interface {
+	Increment(ctx context.Context, stats *domain.UserStats) error
+	GetByUserId(ctx context.Context, userId string) (*domain.UserStats, error)
+}
@@ -0,0 +1,7 @@
+package validator
+
+// ChatFilter vets chat messages before they are sent. It returns the text to
+// send, which a filter may rewrite, or an error when the message is rejected.
+type ChatFilter interface {
+	Filter(text string) (string, error)
+}
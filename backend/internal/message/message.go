@@ -2,12 +2,20 @@ package message
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/holdennekt/sgame/backend/internal/domain"
 )
 
 type Message struct {
-	Id      string          `json:"id"`
-	Event   domain.Event    `json:"event"`
-	Payload json.RawMessage `json:"payload"`
+	Id         string          `json:"id"`
+	Event      domain.Event    `json:"event"`
+	Payload    json.RawMessage `json:"payload"`
+	ServerTime int64           `json:"serverTime,omitempty"`
+}
+
+// ServerTime is the server's current wall-clock time in unix milliseconds,
+// the clock every deadline sent to clients is set on.
+func ServerTime() int64 {
+	return time.Now().UnixMilli()
 }
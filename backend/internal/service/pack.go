@@ -80,6 +80,7 @@ func (s *PackService) CreateFromDraft(ctx context.Context, user domain.User, dra
 		Type:           draft.Type,
 		Rounds:         draft.Rounds,
 		FinalRound:     draft.FinalRound,
+		TieBreakers:    draft.TieBreakers,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	})
@@ -142,6 +143,17 @@ func populatePackAttachmentURLs(ctx context.Context, stor storage.Storage, pack
 			}
 		}
 	}
+	for qi := range pack.TieBreakers {
+		q := &pack.TieBreakers[qi]
+		if err := setURL(q.Attachment); err != nil {
+			return err
+		}
+		if q.Comment != nil {
+			if err := setURL(q.Comment.Attachment); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -180,6 +192,7 @@ func (s *PackService) UpdateFromDraft(ctx context.Context, user domain.User, dra
 		Type:           draft.Type,
 		Rounds:         draft.Rounds,
 		FinalRound:     draft.FinalRound,
+		TieBreakers:    draft.TieBreakers,
 		CreatedAt:      oldPack.CreatedAt,
 		UpdatedAt:      time.Now(),
 	}); err != nil {
@@ -345,10 +358,13 @@ func (s *PackService) createDomain(ctx context.Context, dto dto.CreatePackReques
 						Index:    qi,
 						Value:    q.Value,
 					},
-					Type:       q.Type,
-					Text:       q.Text,
-					Attachment: nil,
-					Answers:    q.Answers,
+					Type:           q.Type,
+					Text:           q.Text,
+					Attachment:     nil,
+					Options:        q.Options,
+					Answers:        q.Answers,
+					CatInBagParams: catInBagToDomain(q.CatInBag),
+					Numeric:        numericToDomain(q.Numeric),
 				}
 
 				if q.Attachment != nil {
@@ -393,6 +409,7 @@ func (s *PackService) createDomain(ctx context.Context, dto dto.CreatePackReques
 					Text:     c.Question.Text,
 				},
 				Answers: c.Question.Answers,
+				Numeric: numericToDomain(c.Question.Numeric),
 			},
 		}
 
@@ -420,6 +437,11 @@ func (s *PackService) createDomain(ctx context.Context, dto dto.CreatePackReques
 		finalRound.Categories = append(finalRound.Categories, category)
 	}
 
+	tieBreakers, err := tieBreakersToDomain(dto.TieBreakers, s.attachmentService.creator(ctx, dto.Type))
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	return &domain.Pack{
 		CreatedBy:      user,
@@ -429,6 +451,7 @@ func (s *PackService) createDomain(ctx context.Context, dto dto.CreatePackReques
 		Type:           dto.Type,
 		Rounds:         rounds,
 		FinalRound:     finalRound,
+		TieBreakers:    tieBreakers,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}, nil
@@ -463,10 +486,13 @@ func (s *PackService) updateDomain(ctx context.Context, user domain.User, oldPac
 						Index:    qi,
 						Value:    q.Value,
 					},
-					Type:       q.Type,
-					Text:       q.Text,
-					Attachment: nil,
-					Answers:    q.Answers,
+					Type:           q.Type,
+					Text:           q.Text,
+					Attachment:     nil,
+					Options:        q.Options,
+					Answers:        q.Answers,
+					CatInBagParams: catInBagToDomain(q.CatInBag),
+					Numeric:        numericToDomain(q.Numeric),
 				}
 
 				if q.Attachment != nil {
@@ -511,6 +537,7 @@ func (s *PackService) updateDomain(ctx context.Context, user domain.User, oldPac
 					Text:     c.Question.Text,
 				},
 				Answers: c.Question.Answers,
+				Numeric: numericToDomain(c.Question.Numeric),
 			},
 		}
 
@@ -538,6 +565,13 @@ func (s *PackService) updateDomain(ctx context.Context, user domain.User, oldPac
 		finalRound.Categories = append(finalRound.Categories, category)
 	}
 
+	tieBreakers, err := tieBreakersToDomain(req.TieBreakers, func(att dto.CreateAttachmentRequest) (*domain.Attachment, error) {
+		return s.attachmentService.upsertDomain(ctx, oldPack.GetAttachment(att.Key), att, req.Type)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.Pack{
 		Id:             req.Id,
 		CreatedBy:      user,
@@ -547,7 +581,69 @@ func (s *PackService) updateDomain(ctx context.Context, user domain.User, oldPac
 		Type:           req.Type,
 		Rounds:         rounds,
 		FinalRound:     finalRound,
+		TieBreakers:    tieBreakers,
 		CreatedAt:      oldPack.CreatedAt,
 		UpdatedAt:      time.Now(),
 	}, nil
 }
+
+// attachmentFunc turns an attachment request into a stored attachment.
+type attachmentFunc func(dto.CreateAttachmentRequest) (*domain.Attachment, error)
+
+func (s *AttachmentService) creator(ctx context.Context, privacyType domain.PrivacyType) attachmentFunc {
+	return func(req dto.CreateAttachmentRequest) (*domain.Attachment, error) {
+		return s.createDomain(ctx, req, privacyType)
+	}
+}
+
+func tieBreakersToDomain(reqs []dto.CreateFinalRoundQuestionRequest, toAttachment attachmentFunc) ([]domain.FinalRoundQuestion, error) {
+	tieBreakers := []domain.FinalRoundQuestion{}
+	for _, q := range reqs {
+		question := domain.FinalRoundQuestion{
+			HiddenFinalRoundQuestion: domain.HiddenFinalRoundQuestion{
+				Category: domain.TieBreakerCategory,
+				Text:     q.Text,
+			},
+			Answers: q.Answers,
+			Numeric: numericToDomain(q.Numeric),
+		}
+
+		if q.Attachment != nil {
+			attachment, err := toAttachment(*q.Attachment)
+			if err != nil {
+				return nil, err
+			}
+			question.Attachment = attachment
+		}
+
+		if q.Comment != nil {
+			question.Comment = &domain.Comment{
+				Text: q.Comment.Text,
+			}
+			if q.Comment.Attachment != nil {
+				attachment, err := toAttachment(*q.Comment.Attachment)
+				if err != nil {
+					return nil, err
+				}
+				question.Comment.Attachment = attachment
+			}
+		}
+
+		tieBreakers = append(tieBreakers, question)
+	}
+	return tieBreakers, nil
+}
+
+func catInBagToDomain(c *dto.CreateCatInBagRequest) *domain.CatInBagParams {
+	if c == nil {
+		return nil
+	}
+	return &domain.CatInBagParams{Theme: c.Theme, MinPrice: c.MinPrice, MaxPrice: c.MaxPrice, Step: c.Step}
+}
+
+func numericToDomain(n *dto.CreateNumericRequest) *domain.NumericAnswer {
+	if n == nil {
+		return nil
+	}
+	return &domain.NumericAnswer{Value: n.Value, Tolerance: n.Tolerance, ExactBonus: n.ExactBonus}
+}
@@ -38,6 +38,7 @@ func NewPackDraftService(packDraftRepo repository.PackDraft, packRepo repository
 		return name
 	})
 	_ = v.RegisterValidation(custvalid.SameLength, custvalid.ValidateSameLength)
+	_ = v.RegisterValidation(custvalid.SubsetOf, custvalid.ValidateSubsetOf)
 	return &PackDraftService{
 		packDraftRepo:     packDraftRepo,
 		packRepo:          packRepo,
@@ -76,13 +77,14 @@ func (s *PackDraftService) GetOrCreateEditDraft(ctx context.Context, user domain
 	now := time.Now()
 	if packId == "" {
 		return s.packDraftRepo.Create(ctx, &domain.PackDraft{
-			CreatedBy:  user,
-			Name:       "New Pack",
-			Type:       domain.Public,
-			Rounds:     []domain.Round{{Name: "Round 1", Categories: []domain.Category{}}},
-			FinalRound: domain.FinalRound{Categories: []domain.FinalRoundCategory{}},
-			CreatedAt:  now,
-			UpdatedAt:  now,
+			CreatedBy:   user,
+			Name:        "New Pack",
+			Type:        domain.Public,
+			Rounds:      []domain.Round{{Name: "Round 1", Categories: []domain.Category{}}},
+			FinalRound:  domain.FinalRound{Categories: []domain.FinalRoundCategory{}},
+			TieBreakers: []domain.FinalRoundQuestion{},
+			CreatedAt:   now,
+			UpdatedAt:   now,
 		})
 	}
 
@@ -93,6 +95,7 @@ func (s *PackDraftService) GetOrCreateEditDraft(ctx context.Context, user domain
 		Type:         pack.Type,
 		Rounds:       pack.Rounds,
 		FinalRound:   pack.FinalRound,
+		TieBreakers:  pack.TieBreakers,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	})
@@ -140,6 +143,13 @@ func populateAttachmentURLs(ctx context.Context, stor storage.Storage, draft *do
 			setURL(q.Comment.Attachment)
 		}
 	}
+	for qi := range draft.TieBreakers {
+		q := &draft.TieBreakers[qi]
+		setURL(q.Attachment)
+		if q.Comment != nil {
+			setURL(q.Comment.Attachment)
+		}
+	}
 }
 
 func (s *PackDraftService) GetByUser(ctx context.Context, userId string, search dto.SearchRequest) ([]domain.PackDraft, int, error) {
@@ -318,6 +328,15 @@ func (s *PackDraftService) promoteAttachments(ctx context.Context, draft *domain
 			atts = append(atts, q.Comment.Attachment)
 		}
 	}
+	for qi := range draft.TieBreakers {
+		q := &draft.TieBreakers[qi]
+		if q.Attachment != nil {
+			atts = append(atts, q.Attachment)
+		}
+		if q.Comment != nil && q.Comment.Attachment != nil {
+			atts = append(atts, q.Comment.Attachment)
+		}
+	}
 
 	if len(atts) == 0 {
 		return nil
@@ -391,10 +410,13 @@ func (s *PackDraftService) updateDomain(ctx context.Context, user domain.User, o
 						Index:    qi,
 						Value:    q.Value,
 					},
-					Type:       q.Type,
-					Text:       q.Text,
-					Attachment: nil,
-					Answers:    q.Answers,
+					Type:           q.Type,
+					Text:           q.Text,
+					Attachment:     nil,
+					Options:        q.Options,
+					Answers:        q.Answers,
+					CatInBagParams: catInBagToDomain(q.CatInBag),
+					Numeric:        numericToDomain(q.Numeric),
 				}
 
 				if q.Attachment != nil {
@@ -439,6 +461,7 @@ func (s *PackDraftService) updateDomain(ctx context.Context, user domain.User, o
 					Text:     c.Question.Text,
 				},
 				Answers: c.Question.Answers,
+				Numeric: numericToDomain(c.Question.Numeric),
 			},
 		}
 
@@ -466,6 +489,41 @@ func (s *PackDraftService) updateDomain(ctx context.Context, user domain.User, o
 		finalRound.Categories = append(finalRound.Categories, category)
 	}
 
+	tieBreakers := []domain.FinalRoundQuestion{}
+	for _, q := range req.TieBreakers {
+		question := domain.FinalRoundQuestion{
+			HiddenFinalRoundQuestion: domain.HiddenFinalRoundQuestion{
+				Category: domain.TieBreakerCategory,
+				Text:     q.Text,
+			},
+			Answers: q.Answers,
+			Numeric: numericToDomain(q.Numeric),
+		}
+
+		if q.Attachment != nil {
+			attachment, err := s.attachmentService.upsertDomainDraft(ctx, oldDraft.GetAttachment(q.Attachment.Key), *q.Attachment)
+			if err != nil {
+				return nil, err
+			}
+			question.Attachment = attachment
+		}
+
+		if q.Comment != nil {
+			question.Comment = &domain.Comment{
+				Text: q.Comment.Text,
+			}
+			if q.Comment.Attachment != nil {
+				attachment, err := s.attachmentService.upsertDomainDraft(ctx, oldDraft.GetAttachment(q.Comment.Attachment.Key), *q.Comment.Attachment)
+				if err != nil {
+					return nil, err
+				}
+				question.Comment.Attachment = attachment
+			}
+		}
+
+		tieBreakers = append(tieBreakers, question)
+	}
+
 	return &domain.PackDraft{
 		Id:           oldDraft.Id,
 		LinkedPackId: oldDraft.LinkedPackId,
@@ -475,6 +533,7 @@ func (s *PackDraftService) updateDomain(ctx context.Context, user domain.User, o
 		Type:         req.Type,
 		Rounds:       rounds,
 		FinalRound:   finalRound,
+		TieBreakers:  tieBreakers,
 		CreatedAt:    oldDraft.CreatedAt,
 		UpdatedAt:    time.Now(),
 	}, nil
@@ -492,8 +551,11 @@ func draftToCreatePackRequest(draft *domain.PackDraft) dto.CreatePackRequest {
 					Type:       q.Type,
 					Text:       q.Text,
 					Attachment: attToDTO(q.Attachment),
+					Options:    q.Options,
 					Answers:    q.Answers,
 					Comment:    commentToDTO(q.Comment),
+					CatInBag:   catInBagToDTO(q.CatInBagParams),
+					Numeric:    numericToDTO(q.Numeric),
 				}
 			}
 			cats[ci] = dto.CreateCategoryRequest{Name: c.Name, Comment: c.Comment, Questions: qs}
@@ -510,15 +572,28 @@ func draftToCreatePackRequest(draft *domain.PackDraft) dto.CreatePackRequest {
 				Attachment: attToDTO(c.Question.Attachment),
 				Answers:    c.Question.Answers,
 				Comment:    commentToDTO(c.Question.Comment),
+				Numeric:    numericToDTO(c.Question.Numeric),
 			},
 		}
 	}
 
+	tieBreakers := make([]dto.CreateFinalRoundQuestionRequest, len(draft.TieBreakers))
+	for i, q := range draft.TieBreakers {
+		tieBreakers[i] = dto.CreateFinalRoundQuestionRequest{
+			Text:       q.Text,
+			Attachment: attToDTO(q.Attachment),
+			Answers:    q.Answers,
+			Comment:    commentToDTO(q.Comment),
+			Numeric:    numericToDTO(q.Numeric),
+		}
+	}
+
 	return dto.CreatePackRequest{
-		Name:       draft.Name,
-		Type:       draft.Type,
-		Rounds:     rounds,
-		FinalRound: dto.CreateFinalRoundRequest{Categories: finalCats},
+		Name:        draft.Name,
+		Type:        draft.Type,
+		Rounds:      rounds,
+		FinalRound:  dto.CreateFinalRoundRequest{Categories: finalCats},
+		TieBreakers: tieBreakers,
 	}
 }
 
@@ -536,6 +611,20 @@ func commentToDTO(c *domain.Comment) *dto.CreateCommentRequest {
 	return &dto.CreateCommentRequest{Text: c.Text, Attachment: attToDTO(c.Attachment)}
 }
 
+func catInBagToDTO(p *domain.CatInBagParams) *dto.CreateCatInBagRequest {
+	if p == nil {
+		return nil
+	}
+	return &dto.CreateCatInBagRequest{Theme: p.Theme, MinPrice: p.MinPrice, MaxPrice: p.MaxPrice, Step: p.Step}
+}
+
+func numericToDTO(n *domain.NumericAnswer) *dto.CreateNumericRequest {
+	if n == nil {
+		return nil
+	}
+	return &dto.CreateNumericRequest{Value: n.Value, Tolerance: n.Tolerance, ExactBonus: n.ExactBonus}
+}
+
 func (s *PackDraftService) Import(ctx context.Context, user domain.User, r io.ReaderAt, size int64) (string, error) {
 	if user.IsGuest {
 		return "", custerr.NewForbiddenErr("guest users cannot import packs")
@@ -82,6 +82,8 @@ type siqItem struct {
 
 type siqNumberSet struct {
 	Minimum int `xml:"minimum,attr"`
+	Maximum int `xml:"maximum,attr"`
+	Step    int `xml:"step,attr"`
 }
 
 type siqRight struct {
@@ -109,8 +111,12 @@ func siqTypeToQuestionType(t string) domain.QuestionType {
 	switch t {
 	case "secret":
 		return domain.CatInBag
-	case "stake", "stakeAll":
+	case "stake":
 		return domain.Auction
+	case "stakeAll":
+		return domain.ForEveryone
+	case "noRisk", "sponsored":
+		return domain.NoRisk
 	default:
 		return domain.Regular
 	}
@@ -192,6 +198,45 @@ func buildOptionsMap(param *siqParam) map[string]string {
 	return m
 }
 
+// extractCatInBagParams reads the secret theme and price range of a cat in
+// bag. A price without a maximum is a fixed price.
+func extractCatInBagParams(params []siqParam) *domain.CatInBagParams {
+	var theme *string
+	if themeParam := findParam(params, "theme"); themeParam != nil {
+		theme = strPtr(normalizeText(themeParam.CharData))
+	}
+	var numberSet *siqNumberSet
+	if priceParam := findParam(params, "price"); priceParam != nil {
+		numberSet = priceParam.NumberSet
+	}
+	if theme == nil && numberSet == nil {
+		return nil
+	}
+	catInBag := &domain.CatInBagParams{Theme: theme}
+	if numberSet != nil {
+		catInBag.MinPrice = numberSet.Minimum
+		catInBag.MaxPrice = max(numberSet.Maximum, numberSet.Minimum)
+		catInBag.Step = numberSet.Step
+	}
+	return catInBag
+}
+
+// selectOptions returns the option texts of an answerOptions group in
+// document order.
+func selectOptions(param *siqParam) []string {
+	if param == nil {
+		return nil
+	}
+	var options []string
+	for i := range param.Params {
+		text, _ := extractContent(&param.Params[i])
+		if text != "" {
+			options = append(options, text)
+		}
+	}
+	return options
+}
+
 // appendSelectOptions appends "A. ...\nB. ..." lines to qText in document order.
 func appendSelectOptions(qText string, param *siqParam) string {
 	if param == nil || len(param.Params) == 0 {
@@ -420,17 +465,31 @@ func (s *PackDraftService) parseSIQ(ctx context.Context, r io.ReaderAt, size int
 				qText, qMedia := extractContent(findParam(params, "question"))
 				aText, aMedia := extractContent(findParam(params, "answer"))
 
+				qType := siqTypeToQuestionType(q.Type)
+				var options []string
 				answers := extractAnswers(q.Right)
 				answerTypeParam := findParam(params, "answerType")
 				if answerTypeParam != nil && normalizeText(answerTypeParam.CharData) == "select" {
 					optParam := findParam(params, "answerOptions")
-					qText = appendSelectOptions(qText, optParam)
 					optMap := buildOptionsMap(optParam)
 					for j, a := range answers {
 						if text, ok := optMap[a]; ok {
 							answers[j] = text
 						}
 					}
+					// only buzzer questions can be played as multiple choice, the
+					// special types keep their options in the text
+					if qType == domain.Regular && len(optMap) >= 2 {
+						qType = domain.MultipleChoice
+						options = selectOptions(optParam)
+					} else {
+						qText = appendSelectOptions(qText, optParam)
+					}
+				}
+
+				var catInBag *domain.CatInBagParams
+				if qType == domain.CatInBag {
+					catInBag = extractCatInBagParams(params)
 				}
 
 				questions = append(questions, domain.Question{
@@ -440,11 +499,13 @@ func (s *PackDraftService) parseSIQ(ctx context.Context, r io.ReaderAt, size int
 						Index:    i,
 						Value:    q.Price,
 					},
-					Type:       siqTypeToQuestionType(q.Type),
-					Text:       strPtr(qText),
-					Attachment: lookupMedia(qMedia),
-					Answers:    answers,
-					Comment:    buildComment(aText, aMedia, lookupMedia),
+					Type:           qType,
+					Text:           strPtr(qText),
+					Attachment:     lookupMedia(qMedia),
+					Options:        options,
+					Answers:        answers,
+					Comment:        buildComment(aText, aMedia, lookupMedia),
+					CatInBagParams: catInBag,
 				})
 			}
 
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/dto"
+	"github.com/holdennekt/sgame/backend/internal/interface/repository"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+type RatingService struct {
+	ratingRepository repository.Rating
+}
+
+func NewRatingService(ratingRepository repository.Rating) *RatingService {
+	return &RatingService{ratingRepository}
+}
+
+func (s *RatingService) GetByUser(ctx context.Context, userId string) ([]domain.Rating, error) {
+	return s.ratingRepository.GetByUser(ctx, userId)
+}
+
+func (s *RatingService) GetHistory(ctx context.Context, userId string, pool domain.RatingPool, search dto.SearchRequest) ([]domain.RatingChange, int, error) {
+	if err := validatePool(pool); err != nil {
+		return nil, 0, err
+	}
+	return s.ratingRepository.GetHistory(ctx, userId, pool, search)
+}
+
+func (s *RatingService) GetLeaderboard(ctx context.Context, pool domain.RatingPool, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error) {
+	if err := validatePool(pool); err != nil {
+		return nil, 0, err
+	}
+	return s.ratingRepository.GetLeaderboard(ctx, pool, search)
+}
+
+// GetMonthlyLeaderboard ranks players of the month starting at month by the
+// rating they reached after their last game in it.
+func (s *RatingService) GetMonthlyLeaderboard(ctx context.Context, pool domain.RatingPool, month time.Time, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error) {
+	if err := validatePool(pool); err != nil {
+		return nil, 0, err
+	}
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return s.ratingRepository.GetPeriodLeaderboard(ctx, pool, from, from.AddDate(0, 1, 0), search)
+}
+
+func (s *RatingService) GetPackLeaderboard(ctx context.Context, pool domain.RatingPool, packId string, search dto.SearchRequest) ([]domain.LeaderboardEntry, int, error) {
+	if err := validatePool(pool); err != nil {
+		return nil, 0, err
+	}
+	return s.ratingRepository.GetPackLeaderboard(ctx, pool, packId, search)
+}
+
+func validatePool(pool domain.RatingPool) error {
+	if !domain.IsValidRatingPool(pool) {
+		return custerr.NewBadRequestErr(fmt.Sprintf("unknown rating pool \"%s\"", pool))
+	}
+	return nil
+}
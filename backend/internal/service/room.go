@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"slices"
 
 	"github.com/google/uuid"
@@ -10,6 +11,7 @@ import (
 	"github.com/holdennekt/sgame/backend/internal/dto"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor"
 	"github.com/holdennekt/sgame/backend/internal/eventsprocessor/client/outgoing"
+	serverevent "github.com/holdennekt/sgame/backend/internal/eventsprocessor/server"
 	"github.com/holdennekt/sgame/backend/internal/interface/cache"
 	"github.com/holdennekt/sgame/backend/internal/interface/realtime"
 	"github.com/holdennekt/sgame/backend/internal/interface/repository"
@@ -21,8 +23,10 @@ import (
 const UPDATE_ROOM_RETRIES = 3
 
 type RoomService struct {
+	userRepository                    repository.User
 	packRepository                    repository.Pack
 	roomRepository                    repository.Room
+	gameLogRepository                 repository.GameLog
 	roomCache                         cache.Room
 	lobbyChannelGetter                realtime.ChannelGetter
 	roomChannelGetter                 realtime.ChannelGetter
@@ -32,8 +36,8 @@ type RoomService struct {
 	answerValidator                   ivalidator.AnswerValidator
 }
 
-func NewRoomService(packRepository repository.Pack, roomRepository repository.Room, roomCache cache.Room, lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter realtime.ChannelGetter, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter, cfg *config.Config, answerValidator ivalidator.AnswerValidator) *RoomService {
-	return &RoomService{packRepository, roomRepository, roomCache, lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter, roomInternalEventsProcessorGetter, cfg, answerValidator}
+func NewRoomService(userRepository repository.User, packRepository repository.Pack, roomRepository repository.Room, gameLogRepository repository.GameLog, roomCache cache.Room, lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter realtime.ChannelGetter, roomInternalEventsProcessorGetter eventsprocessor.RoomInternalEventsProcessorGetter, cfg *config.Config, answerValidator ivalidator.AnswerValidator) *RoomService {
+	return &RoomService{userRepository, packRepository, roomRepository, gameLogRepository, roomCache, lobbyChannelGetter, roomChannelGetter, roomInternalChannelGetter, roomInternalEventsProcessorGetter, cfg, answerValidator}
 }
 
 func (s *RoomService) Create(ctx context.Context, userId string, crr dto.CreateRoomRequest) (string, error) {
@@ -54,6 +58,14 @@ func (s *RoomService) Create(ctx context.Context, userId string, crr dto.CreateR
 	options := crr.Options
 	options.TimeToBet = s.cfg.TimeToBet
 	options.TimeToPass = s.cfg.TimeToPass
+	if options.TeamMode {
+		if options.TeamCount == 0 {
+			options.TeamCount = domain.MinTeams
+		}
+		if options.TeamAnswering == "" {
+			options.TeamAnswering = domain.AnyMemberAnswers
+		}
+	}
 
 	room := &domain.Room{
 		Id:   id.String(),
@@ -67,6 +79,9 @@ func (s *RoomService) Create(ctx context.Context, userId string, crr dto.CreateR
 		Players:   make([]domain.Player, 0),
 		State:     domain.WaitingForStart,
 	}
+	if options.TeamMode {
+		room.Teams = domain.NewTeams(options.TeamCount)
+	}
 
 	if err := s.roomCache.Set(ctx, room); err != nil {
 		return "", custerr.NewInternalErr(err)
@@ -103,16 +118,44 @@ func (s *RoomService) GetProjection(ctx context.Context, userId, id, password st
 	}
 
 	if !room.IsUserIn(userId) {
-		if room.IsUserBanned(userId) {
-			return nil, custerr.NewForbiddenErr("you were banned from this room")
-		}
-		if room.Options.Type == domain.Private && *room.Options.Password != password {
-			return nil, custerr.NewForbiddenErr("wrong password")
+		if err := s.CheckAccess(ctx, room, userId, password); err != nil {
+			return nil, err
 		}
 	}
 
 	spectatorCount, _ := s.roomCache.GetSpectatorCount(ctx, id)
-	return room.GetProjection(userId, spectatorCount), nil
+	spectators, _ := s.roomCache.GetSpectators(ctx, id)
+	return room.GetProjection(userId, spectatorCount, spectators), nil
+}
+
+// CheckAccess checks that a user who is not in the room may watch or join it:
+// they are not banned from it, neither they nor the room's creator have
+// blocked the other, and they know the password of a private room.
+func (s *RoomService) CheckAccess(ctx context.Context, room *domain.Room, userId, password string) error {
+	if room.IsUserBanned(userId) {
+		return custerr.NewForbiddenErr("you were banned from this room")
+	}
+	if err := s.checkBlockList(ctx, room, userId); err != nil {
+		return err
+	}
+	if room.Options.Type == domain.Private && *room.Options.Password != password {
+		return custerr.NewForbiddenErr("wrong password")
+	}
+	return nil
+}
+
+func (s *RoomService) checkBlockList(ctx context.Context, room *domain.Room, userId string) error {
+	if room.CreatedBy == userId {
+		return nil
+	}
+	blocked, err := s.userRepository.IsBlocked(ctx, room.CreatedBy, userId)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return custerr.NewForbiddenErr("you can not enter rooms of this user")
+	}
+	return nil
 }
 
 func (s *RoomService) Get(ctx context.Context) ([]domain.RoomLobby, error) {
@@ -123,10 +166,66 @@ func (s *RoomService) GetSpectatorCount(ctx context.Context, id string) (int, er
 	return s.roomCache.GetSpectatorCount(ctx, id)
 }
 
+func (s *RoomService) GetSpectators(ctx context.Context, id string) ([]domain.User, error) {
+	return s.roomCache.GetSpectators(ctx, id)
+}
+
+// GetChatHistory returns the latest messages of the room's chat, which are
+// sent to everyone who connects, leaving out the spectators' chat for those
+// who hide it.
+func (s *RoomService) GetChatHistory(ctx context.Context, room *domain.Room, userId string) ([]domain.ChatMessage, error) {
+	chat, err := s.roomCache.GetChat(ctx, room.Id, s.cfg.ChatReplayCount)
+	if err != nil {
+		return nil, err
+	}
+	return room.ChatVisibleTo(userId, chat), nil
+}
+
 func (s *RoomService) GetHistory(ctx context.Context, userId string, search dto.SearchRequest) ([]domain.Room, int, error) {
 	return s.roomRepository.GetByParticipant(ctx, userId, search)
 }
 
+func (s *RoomService) GetReplay(ctx context.Context, id, userId string) (*domain.Replay, error) {
+	room, gameLog, err := s.getFinishedGameLog(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	replay := domain.NewReplay(room, gameLog.Events)
+	return &replay, nil
+}
+
+func (s *RoomService) GetReplayState(ctx context.Context, id, userId string, step int) (*domain.ReplayState, error) {
+	_, gameLog, err := s.getFinishedGameLog(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	state, err := domain.NewReplayState(gameLog.Events, step)
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// getFinishedGameLog loads a finished game for userId. Games of private rooms
+// are only shown to who took part in them; to anyone else they don't exist.
+func (s *RoomService) getFinishedGameLog(ctx context.Context, id, userId string) (*domain.Room, *domain.GameLog, error) {
+	room, err := s.roomRepository.GetById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if room.Options.Type == domain.Private && !room.IsUserIn(userId) {
+		return nil, nil, custerr.NewNotFoundErr(fmt.Sprintf("no room with id \"%s\"", id))
+	}
+	if room.FinishedAt == nil {
+		return nil, nil, custerr.NewConflictErr("game has not finished")
+	}
+	gameLog, err := s.gameLogRepository.GetByRoomId(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return room, gameLog, nil
+}
+
 func (s *RoomService) Join(ctx context.Context, user domain.User, id, password string) (any, error) {
 	room, err := s.roomCache.GetById(ctx, id)
 	if err != nil {
@@ -135,7 +234,11 @@ func (s *RoomService) Join(ctx context.Context, user domain.User, id, password s
 
 	if room.IsUserIn(user.Id) {
 		spectatorCount, _ := s.roomCache.GetSpectatorCount(ctx, id)
-		return room.GetProjection(user.Id, spectatorCount), nil
+		spectators, _ := s.roomCache.GetSpectators(ctx, id)
+		return room.GetProjection(user.Id, spectatorCount, spectators), nil
+	}
+	if err := s.checkBlockList(ctx, room, user.Id); err != nil {
+		return nil, err
 	}
 
 	newRoom, err := s.roomCache.SafeUpdate(ctx, id, func(room *domain.Room) error {
@@ -157,10 +260,10 @@ func (s *RoomService) Join(ctx context.Context, user domain.User, id, password s
 			room.Moderator = &domain.Moderator{User: user}
 			if room.Options.AIHost {
 				// In AI mode the moderator also plays, so they occupy a player slot.
-				room.Players = append(room.Players, domain.Player{User: user})
+				room.AddPlayer(user)
 			}
 		} else {
-			room.Players = append(room.Players, domain.Player{User: user})
+			room.AddPlayer(user)
 		}
 		return nil
 	})
@@ -178,7 +281,8 @@ func (s *RoomService) Join(ctx context.Context, user domain.User, id, password s
 		return nil, err
 	}
 	spectatorCount, _ := s.roomCache.GetSpectatorCount(ctx, id)
-	return newRoom.GetProjection(user.Id, spectatorCount), nil
+	spectators, _ := s.roomCache.GetSpectators(ctx, id)
+	return newRoom.GetProjection(user.Id, spectatorCount, spectators), nil
 }
 
 func (s *RoomService) Leave(ctx context.Context, userId, id string) error {
@@ -189,6 +293,7 @@ func (s *RoomService) Leave(ctx context.Context, userId, id string) error {
 		if room.State != domain.WaitingForStart && room.State != domain.GameOver {
 			return custerr.NewConflictErr("cannot leave ongoing game")
 		}
+		room.RemoveFromTeam(userId)
 
 		if room.IsUserModerator(userId) {
 			if room.Options.AIHost {
@@ -224,13 +329,17 @@ func (s *RoomService) Leave(ctx context.Context, userId, id string) error {
 	return lobbyServerChannel.Send(ctx, roomUpdatedMessage)
 }
 
-func (s *RoomService) Connect(ctx context.Context, userId, roomId string) (*domain.Room, error) {
+func (s *RoomService) Connect(ctx context.Context, user domain.User, roomId string) (*domain.Room, error) {
 	room, err := s.roomCache.GetById(ctx, roomId)
 	if err != nil {
 		return nil, err
 	}
 
+	userId := user.Id
 	if !room.IsUserIn(userId) {
+		if err := s.roomCache.AddSpectator(ctx, roomId, user); err != nil {
+			return nil, err
+		}
 		_, err := s.roomCache.IncrSpectators(ctx, roomId)
 		return room, err
 	}
@@ -239,7 +348,8 @@ func (s *RoomService) Connect(ctx context.Context, userId, roomId string) (*doma
 		return nil, err
 	}
 
-	return s.roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
+	var resumed bool
+	newRoom, err := s.roomCache.SafeUpdate(ctx, roomId, func(room *domain.Room) error {
 		if room.IsUserModerator(userId) {
 			room.Moderator.IsConnected = true
 		}
@@ -247,8 +357,20 @@ func (s *RoomService) Connect(ctx context.Context, userId, roomId string) (*doma
 		if playerIndex != -1 {
 			room.Players[playerIndex].IsConnected = true
 		}
+		resumed = room.ResumeOnReconnect(userId)
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resumed {
+		roomInternalServerChannel := s.roomInternalChannelGetter.Get(domain.ROOM_PREFIX + roomId + domain.INTERNAL_POSTFIX)
+		if err := serverevent.Reschedule(ctx, roomInternalServerChannel, newRoom); err != nil {
+			return nil, err
+		}
+	}
+	return newRoom, nil
 }
 
 func (s *RoomService) Disconnect(ctx context.Context, userId, roomId string) (*domain.Room, error) {
@@ -261,6 +383,9 @@ func (s *RoomService) Disconnect(ctx context.Context, userId, roomId string) (*d
 	}
 
 	if !room.IsUserIn(userId) {
+		if err := s.roomCache.RemoveSpectator(ctx, roomId, userId); err != nil {
+			return nil, err
+		}
 		_, err := s.roomCache.DecrSpectators(ctx, roomId)
 		return room, err
 	}
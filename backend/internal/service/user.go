@@ -12,12 +12,13 @@ import (
 )
 
 type UserService struct {
-	userRepository repository.User
-	sessionCache   cache.Session
+	userRepository      repository.User
+	userStatsRepository repository.UserStats
+	sessionCache        cache.Session
 }
 
-func NewUserService(userRepository repository.User, sessionCache cache.Session) *UserService {
-	return &UserService{userRepository, sessionCache}
+func NewUserService(userRepository repository.User, userStatsRepository repository.UserStats, sessionCache cache.Session) *UserService {
+	return &UserService{userRepository, userStatsRepository, sessionCache}
 }
 
 func (s *UserService) Create(ctx context.Context, user *domain.DbUser) (string, error) {
@@ -44,12 +45,41 @@ func (s *UserService) GetById(ctx context.Context, id string) (*domain.User, err
 	return &user.User, nil
 }
 
+// GetStats returns the user's lifetime stats. Stats are only kept for
+// registered users, so guests and users without finished games get zeros;
+// unknown users are not found.
+func (s *UserService) GetStats(ctx context.Context, id string) (*domain.UserStatsView, error) {
+	if _, err := s.userRepository.GetById(ctx, id); err != nil {
+		switch err.(type) {
+		case custerr.NotFoundErr, custerr.BadRequestErr:
+			// guest ids are not database ids, guests only have a session
+			if _, err := s.sessionCache.GetKey(ctx, id); err != nil {
+				return nil, custerr.NewNotFoundErr("user not found")
+			}
+			view := domain.UserStats{UserId: id}.View()
+			return &view, nil
+		default:
+			return nil, err
+		}
+	}
+	stats, err := s.userStatsRepository.GetByUserId(ctx, id)
+	if err != nil {
+		if _, ok := err.(custerr.NotFoundErr); !ok {
+			return nil, err
+		}
+		stats = &domain.UserStats{UserId: id}
+	}
+	view := stats.View()
+	return &view, nil
+}
+
 func (s *UserService) Update(ctx context.Context, user *domain.DbUser) error {
 	existing, err := s.userRepository.GetById(ctx, user.Id)
 	if err != nil {
 		return err
 	}
 	user.Login = existing.Login
+	user.BlockList = existing.BlockList
 	if user.Password != "" {
 		hashed, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 		if err != nil {
@@ -84,3 +114,25 @@ func (s *UserService) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (s *UserService) GetBlockList(ctx context.Context, id string) ([]string, error) {
+	user, err := s.userRepository.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.BlockList == nil {
+		return []string{}, nil
+	}
+	return user.BlockList, nil
+}
+
+func (s *UserService) Block(ctx context.Context, id, targetId string) error {
+	if id == targetId {
+		return custerr.NewBadRequestErr("cannot block yourself")
+	}
+	return s.userRepository.Block(ctx, id, targetId)
+}
+
+func (s *UserService) Unblock(ctx context.Context, id, targetId string) error {
+	return s.userRepository.Unblock(ctx, id, targetId)
+}
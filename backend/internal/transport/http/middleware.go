@@ -32,15 +32,23 @@ func messageForTag(fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
 		return "is required"
+	case "required_if":
+		field, _, _ := strings.Cut(param, " ")
+		return fmt.Sprintf("is required for this %s", strings.ToLower(field))
 	case "required_without":
 		return fmt.Sprintf("is required when %s is not provided", strings.ToLower(param))
 	case "excluded_with":
 		return fmt.Sprintf("cannot be used together with %s", strings.ToLower(param))
+	case "excluded_unless":
+		field, _, _ := strings.Cut(param, " ")
+		return fmt.Sprintf("is not allowed for this %s", strings.ToLower(field))
+	case "gtefield":
+		return fmt.Sprintf("must be greater than or equal to %s", strings.ToLower(param))
 	case "min":
 		switch baseKind(fe.Type()) {
 		case reflect.String:
 			return fmt.Sprintf("must be at least %s characters", param)
-		case reflect.Int:
+		case reflect.Int, reflect.Float64:
 			return fmt.Sprintf("must be at least %s", param)
 		case reflect.Slice:
 			return fmt.Sprintf("must have at least %s items", param)
@@ -58,6 +66,8 @@ func messageForTag(fe validator.FieldError) string {
 		return fmt.Sprintf("must be one of: %s", param)
 	case "unique":
 		return fmt.Sprintf("must have unique %s", strings.ToLower(param))
+	case "subset_of":
+		return fmt.Sprintf("must only contain values from %s", strings.ToLower(param))
 	case "same_length":
 		return "all categories must have the same number of questions"
 	}
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/internal/dto"
+	"github.com/holdennekt/sgame/backend/internal/service"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
+)
+
+const MONTH_QUERY_PARAM = "month"
+const MONTH_LAYOUT = "2006-01"
+
+type RatingController struct {
+	ratingService *service.RatingService
+}
+
+func NewRatingController(ratingService *service.RatingService) *RatingController {
+	return &RatingController{ratingService}
+}
+
+func (c *RatingController) RegisterRoutes(r *gin.RouterGroup) {
+	leaderboards := r.Group("/leaderboards")
+	leaderboards.GET("/:pool", c.getLeaderboard)
+	leaderboards.GET("/:pool/monthly", c.getMonthlyLeaderboard)
+	leaderboards.GET("/:pool/packs/:packId", c.getPackLeaderboard)
+	users := r.Group("/users")
+	users.GET("/:id/ratings", c.getUserRatings)
+	users.GET("/:id/ratings/:pool/history", c.getUserRatingHistory)
+}
+
+// @Summary      Get all-time leaderboard
+// @Description  Returns a paginated leaderboard of current ratings in a pool (moderated or aiHost)
+// @Tags         ratings
+// @Produce      json
+// @Param        pool  path      string             true   "Rating pool"  Enums(moderated, aiHost)
+// @Param        query query     dto.SearchRequest  false  "Pagination and search parameters"
+// @Success      200  {object}  dto.SearchResponse
+// @Failure      400  {object}  dto.ErrorResponse "Unknown rating pool"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /leaderboards/{pool} [get]
+func (c *RatingController) getLeaderboard(ctx *gin.Context) {
+	pool := domain.RatingPool(ctx.Param("pool"))
+
+	query, ok := bindSearchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	entries, total, err := c.ratingService.GetLeaderboard(ctx, pool, query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newSearchResponse(entries, total, query))
+}
+
+// @Summary      Get monthly leaderboard
+// @Description  Returns a paginated leaderboard of the players of a month, ranked by the rating reached after their last game in it
+// @Tags         ratings
+// @Produce      json
+// @Param        pool   path      string             true   "Rating pool"  Enums(moderated, aiHost)
+// @Param        month  query     string             false  "Month as YYYY-MM, defaults to the current one"
+// @Param        query  query     dto.SearchRequest  false  "Pagination and search parameters"
+// @Success      200  {object}  dto.SearchResponse
+// @Failure      400  {object}  dto.ErrorResponse "Unknown rating pool or invalid month"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /leaderboards/{pool}/monthly [get]
+func (c *RatingController) getMonthlyLeaderboard(ctx *gin.Context) {
+	pool := domain.RatingPool(ctx.Param("pool"))
+	month := time.Now().UTC()
+	if rawMonth := ctx.Query(MONTH_QUERY_PARAM); rawMonth != "" {
+		parsed, err := time.Parse(MONTH_LAYOUT, rawMonth)
+		if err != nil {
+			_ = ctx.Error(custerr.NewBadRequestErr("month must be formatted as YYYY-MM"))
+			return
+		}
+		month = parsed
+	}
+
+	query, ok := bindSearchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	entries, total, err := c.ratingService.GetMonthlyLeaderboard(ctx, pool, month, query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newSearchResponse(entries, total, query))
+}
+
+// @Summary      Get pack leaderboard
+// @Description  Returns a paginated leaderboard of the players of a pack, ranked by the rating reached after their last game on it
+// @Tags         ratings
+// @Produce      json
+// @Param        pool    path      string             true   "Rating pool"  Enums(moderated, aiHost)
+// @Param        packId  path      string             true   "Pack ID"
+// @Param        query   query     dto.SearchRequest  false  "Pagination and search parameters"
+// @Success      200  {object}  dto.SearchResponse
+// @Failure      400  {object}  dto.ErrorResponse "Unknown rating pool"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /leaderboards/{pool}/packs/{packId} [get]
+func (c *RatingController) getPackLeaderboard(ctx *gin.Context) {
+	pool := domain.RatingPool(ctx.Param("pool"))
+	packId := ctx.Param("packId")
+
+	query, ok := bindSearchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	entries, total, err := c.ratingService.GetPackLeaderboard(ctx, pool, packId, query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newSearchResponse(entries, total, query))
+}
+
+// @Summary      Get user ratings
+// @Description  Returns the user's current rating in every pool they have played in
+// @Tags         ratings
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {array}   domain.Rating
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /users/{id}/ratings [get]
+func (c *RatingController) getUserRatings(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	ratings, err := c.ratingService.GetByUser(ctx, id)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ratings)
+}
+
+// @Summary      Get user rating history
+// @Description  Returns a paginated list of the user's rating changes in a pool, newest first
+// @Tags         ratings
+// @Produce      json
+// @Param        id    path      string             true   "User ID"
+// @Param        pool  path      string             true   "Rating pool"  Enums(moderated, aiHost)
+// @Param        query query     dto.SearchRequest  false  "Pagination parameters"
+// @Success      200  {object}  dto.SearchResponse
+// @Failure      400  {object}  dto.ErrorResponse "Unknown rating pool"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /users/{id}/ratings/{pool}/history [get]
+func (c *RatingController) getUserRatingHistory(ctx *gin.Context) {
+	id := ctx.Param("id")
+	pool := domain.RatingPool(ctx.Param("pool"))
+
+	query, ok := bindSearchRequest(ctx)
+	if !ok {
+		return
+	}
+
+	changes, total, err := c.ratingService.GetHistory(ctx, id, pool, query)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newSearchResponse(changes, total, query))
+}
+
+func bindSearchRequest(ctx *gin.Context) (dto.SearchRequest, bool) {
+	var query dto.SearchRequest
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		_ = ctx.Error(err)
+		return query, false
+	}
+	if query.Page == 0 {
+		query.Page = DEFAULT_PAGE
+	}
+	if query.Limit == 0 {
+		query.Limit = DEFAULT_LIMIT
+	}
+	return query, true
+}
+
+func newSearchResponse(items any, total int, query dto.SearchRequest) dto.SearchResponse {
+	return dto.SearchResponse{
+		Items:    items,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.Limit,
+		HasNext:  query.Page*query.Limit < total,
+	}
+}
@@ -2,11 +2,13 @@ package http
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/holdennekt/sgame/backend/internal/domain"
 	"github.com/holdennekt/sgame/backend/internal/dto"
 	"github.com/holdennekt/sgame/backend/internal/service"
+	"github.com/holdennekt/sgame/backend/pkg/custerr"
 )
 
 const PASSWORD_QUERY_PARAM = "password"
@@ -26,6 +28,8 @@ func (c *RoomController) RegisterRoutes(r *gin.RouterGroup) {
 	rooms.GET("/", c.get)
 	rooms.GET("/history", c.getHistory)
 	rooms.GET("/:id", c.getProjection)
+	rooms.GET("/:id/replay", c.getReplay)
+	rooms.GET("/:id/replay/:step", c.getReplayState)
 	rooms.PATCH("/:id/join", c.join)
 	rooms.PATCH("/:id/leave", c.leave)
 }
@@ -199,3 +203,62 @@ func (c *RoomController) getHistory(ctx *gin.Context) {
 		HasNext:  query.Page*query.Limit < total,
 	})
 }
+
+// @Summary      Get game replay
+// @Description  Returns the timeline of a finished game: boards per round, played questions with buzzes and verdicts, and scores after every step
+// @Tags         rooms
+// @Produce      json
+// @Param        id   path      string  true  "Room ID"
+// @Success      200  {object}  domain.Replay
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Room or game log not found"
+// @Failure      409  {object}  dto.ErrorResponse "Game has not finished"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /rooms/{id}/replay [get]
+func (c *RoomController) getReplay(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	userId := ctx.MustGet(USER_CONTEXT_KEY).(domain.User).Id
+
+	replay, err := c.roomService.GetReplay(ctx, id, userId)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, replay)
+}
+
+// @Summary      Get game replay state
+// @Description  Reconstructs the state of a finished game right after the given step of its timeline
+// @Tags         rooms
+// @Produce      json
+// @Param        id    path      string  true  "Room ID"
+// @Param        step  path      int     true  "Zero-based step index"
+// @Success      200  {object}  domain.ReplayState
+// @Failure      400  {object}  dto.ErrorResponse "Invalid step"
+// @Failure      401  {object}  dto.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  dto.ErrorResponse "Room, game log or step not found"
+// @Failure      409  {object}  dto.ErrorResponse "Game has not finished"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /rooms/{id}/replay/{step} [get]
+func (c *RoomController) getReplayState(ctx *gin.Context) {
+	id := ctx.Param("id")
+	step, err := strconv.Atoi(ctx.Param("step"))
+	if err != nil {
+		_ = ctx.Error(custerr.NewBadRequestErr("step must be an integer"))
+		return
+	}
+
+	userId := ctx.MustGet(USER_CONTEXT_KEY).(domain.User).Id
+
+	state, err := c.roomService.GetReplayState(ctx, id, userId, step)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, state)
+}
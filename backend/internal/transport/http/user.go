@@ -23,8 +23,12 @@ func (c *UserController) RegisterRoutes(r *gin.RouterGroup) {
 	users := r.Group("/users")
 	users.POST("/", c.create)
 	users.GET("/:id", c.getById)
+	users.GET("/:id/stats", c.getStats)
 	users.PUT("/:id", c.update)
 	users.DELETE("/:id", c.delete)
+	users.GET("/:id/blocks", c.getBlockList)
+	users.PUT("/:id/blocks/:targetId", c.block)
+	users.DELETE("/:id/blocks/:targetId", c.unblock)
 }
 
 // @Summary      Get current user
@@ -91,6 +95,28 @@ func (c *UserController) getById(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, user)
 }
 
+// @Summary      Get user stats
+// @Description  Retrieves lifetime game statistics of a user. Guests and users without finished games get zeroed stats
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  domain.UserStatsView
+// @Failure      404  {object}  dto.ErrorResponse "User not found"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /users/{id}/stats [get]
+func (c *UserController) getStats(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	stats, err := c.userService.GetStats(ctx, id)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
 // @Summary      Update user
 // @Description  Updates an existing user's information by ID
 // @Tags         users
@@ -149,3 +175,92 @@ func (c *UserController) delete(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// @Summary      Get block list
+// @Description  Retrieves the ids of users the current user has blocked. Blocked users can not join or watch rooms the user creates, and vice versa
+// @Tags         users
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {array}   string
+// @Failure      403  {object}  dto.ErrorResponse "Forbidden: not the current user"
+// @Failure      404  {object}  dto.ErrorResponse "User not found"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /users/{id}/blocks [get]
+func (c *UserController) getBlockList(ctx *gin.Context) {
+	id, ok := c.ownId(ctx)
+	if !ok {
+		return
+	}
+
+	blockList, err := c.userService.GetBlockList(ctx, id)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, blockList)
+}
+
+// @Summary      Block user
+// @Description  Adds a user to the current user's block list
+// @Tags         users
+// @Param        id        path  string  true  "User ID"
+// @Param        targetId  path  string  true  "ID of the user to block"
+// @Success      204  "No Content"
+// @Failure      400  {object}  dto.ErrorResponse "Cannot block yourself"
+// @Failure      403  {object}  dto.ErrorResponse "Forbidden: not the current user"
+// @Failure      404  {object}  dto.ErrorResponse "User not found"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /users/{id}/blocks/{targetId} [put]
+func (c *UserController) block(ctx *gin.Context) {
+	id, ok := c.ownId(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.userService.Block(ctx, id, ctx.Param("targetId")); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary      Unblock user
+// @Description  Removes a user from the current user's block list
+// @Tags         users
+// @Param        id        path  string  true  "User ID"
+// @Param        targetId  path  string  true  "ID of the user to unblock"
+// @Success      204  "No Content"
+// @Failure      403  {object}  dto.ErrorResponse "Forbidden: not the current user"
+// @Failure      404  {object}  dto.ErrorResponse "User not found"
+// @Failure      500  {object}  dto.ErrorResponse "Internal server error"
+// @Security     CookieAuth
+// @Router       /users/{id}/blocks/{targetId} [delete]
+func (c *UserController) unblock(ctx *gin.Context) {
+	id, ok := c.ownId(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.userService.Unblock(ctx, id, ctx.Param("targetId")); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ownId returns the id from the path, failing the request unless it belongs
+// to the current user.
+func (c *UserController) ownId(ctx *gin.Context) (string, bool) {
+	requesterId := ctx.MustGet(USER_CONTEXT_KEY).(domain.User).Id
+	id := ctx.Param("id")
+	if requesterId != id {
+		_ = ctx.Error(custerr.NewForbiddenErr("not allowed to manage other users' block lists"))
+		return "", false
+	}
+	return id, true
+}
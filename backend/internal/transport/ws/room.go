@@ -45,7 +45,7 @@ func (h *RoomHandler) RegisterRoute(r *gin.RouterGroup) {
 
 // @Summary      Connect to Room WebSocket
 // @Description  Establishes a WebSocket connection to the playing room.
-// @Description  Requires a valid session cookie. Once connected, sends a chat message "{User} has connected".
+// @Description  Requires a valid session cookie. Once connected, sends the latest chat history and a chat message "{User} has connected".
 // @Tags         room
 // @Success      101  {string}  string "Switching Protocols"
 // @Failure      401  {object}  dto.ErrorResponse "Unauthorized: Session missing"
@@ -64,12 +64,9 @@ func (h *RoomHandler) connect(ctx *gin.Context) {
 
 	isSpectator := !room.IsUserIn(user.Id)
 	if isSpectator {
-		if room.Options.Type == domain.Private {
-			password := ctx.Query(http.PASSWORD_QUERY_PARAM)
-			if *room.Options.Password != password {
-				_ = ctx.Error(custerr.NewForbiddenErr("wrong password"))
-				return
-			}
+		if err := h.roomService.CheckAccess(ctx, room, user.Id, ctx.Query(http.PASSWORD_QUERY_PARAM)); err != nil {
+			_ = ctx.Error(err)
+			return
 		}
 	}
 
@@ -83,7 +80,7 @@ func (h *RoomHandler) connect(ctx *gin.Context) {
 
 	clientChannel := ws.NewChannel(conn)
 
-	newRoom, err := h.roomService.Connect(ctx, user.Id, id)
+	newRoom, err := h.roomService.Connect(ctx, user, id)
 	if err != nil {
 		slog.Error("error", "err", err)
 		return
@@ -108,12 +105,22 @@ func (h *RoomHandler) connect(ctx *gin.Context) {
 	}()
 
 	spectatorCount, _ := h.roomService.GetSpectatorCount(ctx, id)
-	payload, _ := json.Marshal(newRoom.GetProjection(user.Id, spectatorCount))
+	spectators, _ := h.roomService.GetSpectators(ctx, id)
+	payload, _ := json.Marshal(newRoom.GetProjection(user.Id, spectatorCount, spectators))
 	clientRoomUpdatedMessage := message.Message{Event: domain.RoomUpdated, Payload: payload}
 	if err := clientChannel.Send(ctx, clientRoomUpdatedMessage); err != nil {
 		slog.Error("error", "err", err)
 		return
 	}
+	chat, err := h.roomService.GetChatHistory(ctx, newRoom, user.Id)
+	if err != nil {
+		slog.Error("error", "err", err)
+		return
+	}
+	if err := clientChannel.Send(ctx, client.NewChatHistoryMessage(chat)); err != nil {
+		slog.Error("error", "err", err)
+		return
+	}
 
 	if !isSpectator {
 		serverRoomUpdatedMessage := outgoing.NewRoomUpdatedMessage(id)
@@ -0,0 +1,45 @@
+package custvalid
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const SubsetOf = "subset_of"
+
+// ValidateSubsetOf checks that every element of the field is also an element
+// of the sibling slice named by the param. An empty sibling slice imposes no
+// restriction.
+func ValidateSubsetOf(fl validator.FieldLevel) bool {
+	sliceVal := fl.Field()
+	if sliceVal.Kind() != reflect.Slice && sliceVal.Kind() != reflect.Array {
+		return false
+	}
+
+	parent := fl.Parent()
+	if parent.Kind() == reflect.Pointer {
+		parent = parent.Elem()
+	}
+	set := parent.FieldByName(fl.Param())
+	if set.Kind() != reflect.Slice && set.Kind() != reflect.Array {
+		return false
+	}
+	if set.Len() == 0 {
+		return true
+	}
+
+	for i := 0; i < sliceVal.Len(); i++ {
+		found := false
+		for j := 0; j < set.Len(); j++ {
+			if sliceVal.Index(i).Interface() == set.Index(j).Interface() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
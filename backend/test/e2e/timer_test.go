@@ -0,0 +1,100 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	redisCache "github.com/holdennekt/sgame/backend/internal/infrastructure/cache/redis"
+	"github.com/holdennekt/sgame/backend/internal/interface/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerCacheSurvivesOwnerChange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rds := newRedisClient(t)
+	roomId := "timer-survives"
+	t.Cleanup(func() { _ = redisCache.NewTimerCache(rds).Delete(context.Background(), roomId) })
+
+	scheduled := cache.ScheduledMessage{
+		Id:    "t1",
+		DueAt: time.Now().Add(30 * time.Second),
+		Msg:   testMsg(domain.AnswerStarted),
+	}
+	require.NoError(t, redisCache.NewTimerCache(rds).Schedule(ctx, roomId, scheduled))
+
+	// a fresh cache instance plays the part of the instance taking over the room
+	pending, err := redisCache.NewTimerCache(rds).GetScheduled(ctx, roomId)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, scheduled.Id, pending[0].Id)
+	assert.True(t, scheduled.DueAt.Equal(pending[0].DueAt))
+	assert.Equal(t, scheduled.Msg.Event, pending[0].Msg.Event)
+}
+
+func TestTimerCacheClaimOnce(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rds := newRedisClient(t)
+	timers := redisCache.NewTimerCache(rds)
+	roomId := "timer-claim-once"
+	t.Cleanup(func() { _ = timers.Delete(context.Background(), roomId) })
+
+	require.NoError(t, timers.Schedule(ctx, roomId, cache.ScheduledMessage{
+		Id:    "t1",
+		DueAt: time.Now(),
+		Msg:   testMsg(domain.BettingStarted),
+	}))
+	pending, err := timers.GetScheduled(ctx, roomId)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	claimed, err := timers.Claim(ctx, roomId, pending[0].Id, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = timers.Claim(ctx, roomId, pending[0].Id, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	require.NoError(t, timers.Complete(ctx, roomId, pending[0].Id))
+	pending, err = timers.GetScheduled(ctx, roomId)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestTimerCacheReclaimAfterLease(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rds := newRedisClient(t)
+	timers := redisCache.NewTimerCache(rds)
+	roomId := "timer-reclaim"
+	t.Cleanup(func() { _ = timers.Delete(context.Background(), roomId) })
+
+	require.NoError(t, timers.Schedule(ctx, roomId, cache.ScheduledMessage{
+		Id:    "t1",
+		DueAt: time.Now(),
+		Msg:   testMsg(domain.RoomExpired),
+	}))
+
+	// the instance holding the lease never completes the timer
+	claimed, err := timers.Claim(ctx, roomId, "t1", 200*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	pending, err := timers.GetScheduled(ctx, roomId)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.True(t, pending[0].ClaimableAt.After(time.Now()))
+
+	time.Sleep(300 * time.Millisecond)
+	claimed, err = timers.Claim(ctx, roomId, "t1", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
@@ -0,0 +1,47 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/holdennekt/sgame/backend/internal/domain"
+	"github.com/holdennekt/sgame/backend/test/e2e/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getStats(t *testing.T, app *testhelper.TestApp, session, userId string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, app.Server.URL+"/api/users/"+userId+"/stats", nil)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: testhelper.SessionCookieName, Value: session})
+
+	resp, err := app.Server.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestUserStatsOfGuest(t *testing.T) {
+	app := newApp(t)
+	session, userId := app.Guest(t, "StatsTester")
+
+	resp := getStats(t, app, session, userId)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var stats domain.UserStatsView
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	assert.Equal(t, userId, stats.UserId)
+	assert.Zero(t, stats.GamesPlayed)
+}
+
+func TestUserStatsOfUnknownUser(t *testing.T) {
+	app := newApp(t)
+	session := app.GuestSession(t, "StatsTester")
+
+	resp := getStats(t, app, session, "not-a-user")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = getStats(t, app, session, "65f1c2a4b7e8d9c0a1b2c3d4")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}